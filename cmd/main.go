@@ -8,7 +8,9 @@ import (
 
 	"github.com/palettechain/deploy-tool/config"
 	"github.com/palettechain/deploy-tool/core"
+	"github.com/palettechain/deploy-tool/pkg/abis"
 	"github.com/palettechain/deploy-tool/pkg/frame"
+	"github.com/palettechain/deploy-tool/pkg/gas"
 	"github.com/palettechain/deploy-tool/pkg/log"
 )
 
@@ -16,12 +18,16 @@ var (
 	loglevel   int    // log level [1: debug, 2: info]
 	configpath string // config file
 	Methods    string // methods list in cmdline
+	dryRun     bool   // estimate transactions cost without sending
+	eventJSON  bool   // print decoded event logs as json
 )
 
 func init() {
 	flag.StringVar(&configpath, "config", "config.json", "config path of palette deploy tool")
 	flag.StringVar(&Methods, "m", "connect", "methods to run. use ',' to split methods")
 	flag.IntVar(&loglevel, "loglevel", 2, "loglevel [1: debug, 2: info]")
+	flag.BoolVar(&dryRun, "dryrun", false, "estimate gas and report the expected cost of every method without sending transactions")
+	flag.BoolVar(&eventJSON, "eventjson", false, "print decoded event logs of transactions as json")
 
 	flag.Parse()
 }
@@ -32,8 +38,16 @@ func main() {
 
 	log.InitLog(loglevel, log.Stdout)
 	config.Init(configpath)
+	gas.SetDryRun(dryRun)
+	abis.SetEventJSON(eventJSON)
 	core.Endpoint()
 
+	// run command if there are cmdline arguments after flags, e.g: `wallet derive --from 0 --count 10`
+	if args := flag.Args(); len(args) > 0 {
+		frame.Tool.RunCommand(args)
+		return
+	}
+
 	methods := make([]string, 0)
 	if Methods != "" {
 		methods = strings.Split(Methods, ",")
@@ -7,8 +7,11 @@ import (
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
+	"math/big"
 	"os"
 	"path"
+	"strings"
+	"time"
 
 	"github.com/ethereum/go-ethereum/accounts/keystore"
 	"github.com/ethereum/go-ethereum/common"
@@ -16,10 +19,14 @@ import (
 	"github.com/howeyc/gopass"
 	"github.com/ontio/ontology-crypto/keypair"
 	"github.com/palettechain/deploy-tool/pkg/dao"
+	"github.com/palettechain/deploy-tool/pkg/eth"
 	"github.com/palettechain/deploy-tool/pkg/files"
+	"github.com/palettechain/deploy-tool/pkg/frame"
 	"github.com/palettechain/deploy-tool/pkg/log"
 	"github.com/palettechain/deploy-tool/pkg/poly"
+	"github.com/palettechain/deploy-tool/pkg/rpcpool"
 	"github.com/palettechain/deploy-tool/pkg/sdk"
+	"github.com/palettechain/deploy-tool/pkg/txwait"
 	polysdk "github.com/polynetwork/poly-go-sdk"
 )
 
@@ -30,24 +37,93 @@ const (
 	pwdSessionETH
 	pwdSessionPLT
 	pwdSessionPoly
+	pwdSessionMnemonic
+
+	// pwdSessionNone decrypts without reading or saving the password session, e.g: keystore commands.
+	pwdSessionNone pwdSessionType = 0xff
 )
 
 var (
 	Conf           = new(Config)
 	ConfigFilePath string
+
+	// endpoints pools of chains created by Init, clients of the same chain share the current endpoint.
+	PalettePool  *rpcpool.Pool
+	EthereumPool *rpcpool.Pool
+	PolyPool     *rpcpool.Pool
 )
 
 type Config struct {
 	LevelDB string
 
-	PolyRPCUrl     string
+	// extra struct field or map key names masked in log output, besides private keys and passwords.
+	LogSensitiveFields []string
+
+	// max retry times and interval in seconds of methods failed with rpc unavailable or timeout
+	// errors, default are 3 times and 10 seconds.
+	MethodRetryTimes    int
+	MethodRetryInterval uint64
+	// timeout in seconds of every method, rpc requests and transaction waits of the method are
+	// canceled after it, 0 means no timeout.
+	MethodTimeout uint64
+
+	// endpoints are health checked by latest height and chain id, and fall behind the highest one
+	// more than RPCMaxLag blocks are unhealthy, default is 5. reads are sent to two endpoints and
+	// compared if RPCCompareReads is true.
+	RPCMaxLag       uint64
+	RPCCompareReads bool
+
+	// rpc urls accept a single url or a list of urls, e.g: ["http://node1:8545", "http://node2:8545"]
+	PolyRPCUrl     rpcpool.Endpoints
 	PolyAccountDir string
+	// password source of poly wallet file, e.g: {"wallet1.dat": "env:POLY_WALLET1_PWD"},
+	// wallets without password source read password from leveldb session or terminal.
+	PolyAccountPwdSources map[string]string
+	// minimum number of poly validators signatures, default is 2/3+1 of the wallets in PolyAccountDir.
+	PolyQuorum int
 
-	EthereumRPCUrl          string
+	EthereumRPCUrl          rpcpool.Endpoints
 	EthereumCrossChainAdmin string
-
-	PaletteRPCUrl          string
+	// number of blocks including the tx block to confirm ethereum transactions, default is 1.
+	EthereumConfirmations uint64
+	// seconds to wait ethereum transactions before timeout, default is 600.
+	EthereumTxTimeout uint64
+	// seconds to wait the pending ethereum transaction before replacing it with bumped fees, 0 means never.
+	EthereumEscalateAfter uint64
+	// caps of max fee and priority fee per gas of ethereum transactions in wei, no cap if it is empty.
+	// the max fee also caps the legacy gas price on chains without London.
+	EthereumMaxFee         *big.Int
+	EthereumMaxPriorityFee *big.Int
+	// percent added to the estimated gas limit, default is 20.
+	EthereumGasMargin uint64
+	// number of blocks in one `eth_getLogs` request when scanning cross chain events, default is 1000.
+	EthereumScanBatchSize uint64
+
+	PaletteRPCUrl          rpcpool.Endpoints
 	PaletteCrossChainAdmin string
+	// number of blocks including the tx block to confirm palette transactions, default is 1.
+	PaletteConfirmations uint64
+	// seconds to wait palette transactions before timeout, default is 600.
+	PaletteTxTimeout uint64
+	// seconds to wait the pending palette transaction before replacing it with bumped gas price, 0 means never.
+	PaletteEscalateAfter uint64
+	// expected chain id of palette node, transactions refused if the node chain id is different.
+	PaletteChainID uint64
+	// gas price of palette transactions, fetched from node if it is empty.
+	PaletteGasPrice *big.Int
+	// percent added to the estimated gas limit, default is 20.
+	PaletteGasMargin uint64
+
+	// role separated key sources, roles without key source fall back to the cross chain admin
+	PaletteRoles  RoleKeys
+	EthereumRoles RoleKeys
+	PolyRoles     RoleKeys
+
+	// hd wallet mnemonic file encrypted by `wallet encrypt-mnemonic` and the password source of it,
+	// the mnemonic will be read from terminal if the file is empty.
+	MnemonicFile       string
+	MnemonicPwdSource  string
+	DerivationTemplate string
 
 	// palette side chain
 	PaletteSideChainID   uint64
@@ -69,6 +145,11 @@ type Config struct {
 	EthereumPLTAsset      common.Address
 	EthereumPLTProxy      common.Address
 	EthereumNFTProxy      common.Address
+	// compiled PLT ERC20 artifact deployed by `eth-deploy-plt-asset`, which is the json file of hardhat,
+	// truffle or foundry, or the bytecode in hex. the asset ownership is handed to EthereumPLTProxy if
+	// EthereumPLTAssetProxyOwned is true, e.g: the token is minted and burnt by the lock proxy.
+	EthereumPLTAssetBin        string
+	EthereumPLTAssetProxyOwned bool
 
 	// bind nft asset
 	PaletteNFTAsset  common.Address
@@ -93,13 +174,44 @@ func Init(filepath string) {
 	if err != nil {
 		panic(err)
 	}
+	if err := Conf.checkRoles(); err != nil {
+		panic(err)
+	}
+	log.SetSensitiveFields(Conf.LogSensitiveFields...)
+	frame.SetRetry(Conf.MethodRetryTimes, time.Duration(Conf.MethodRetryInterval)*time.Second)
+	frame.SetMethodTimeout(time.Duration(Conf.MethodTimeout) * time.Second)
 
 	sdk.Init()
+	sdk.SetGasPolicy(Conf.PaletteGasPrice, Conf.PaletteGasMargin)
+	sdk.SetExpectedChainID(Conf.PaletteChainID)
+	sdk.SetWaitOptions(txwait.Options{
+		Timeout:       time.Duration(Conf.PaletteTxTimeout) * time.Second,
+		Confirmations: Conf.PaletteConfirmations,
+		EscalateAfter: time.Duration(Conf.PaletteEscalateAfter) * time.Second,
+	})
+	eth.SetFeePolicy(Conf.EthereumMaxFee, Conf.EthereumMaxPriorityFee, Conf.EthereumGasMargin)
+	eth.SetWaitOptions(txwait.Options{
+		Timeout:       time.Duration(Conf.EthereumTxTimeout) * time.Second,
+		Confirmations: Conf.EthereumConfirmations,
+		EscalateAfter: time.Duration(Conf.EthereumEscalateAfter) * time.Second,
+	})
+
+	PalettePool = rpcpool.New("palette", Conf.PaletteRPCUrl, Conf.rpcOptions(Conf.PaletteChainID))
+	EthereumPool = rpcpool.New("ethereum", Conf.EthereumRPCUrl, Conf.rpcOptions(0))
+	PolyPool = rpcpool.New("poly", Conf.PolyRPCUrl, Conf.rpcOptions(0)).SetProbe(poly.Probe)
 
 	// init leveldb
 	dao.NewDao(Conf.LevelDB)
 }
 
+func (c *Config) rpcOptions(chainID uint64) rpcpool.Options {
+	return rpcpool.Options{
+		ChainID:      chainID,
+		MaxLag:       c.RPCMaxLag,
+		CompareReads: c.RPCCompareReads,
+	}
+}
+
 func LoadConfig(filepath string, ins interface{}) error {
 	data, err := files.ReadFile(filepath)
 	if err != nil {
@@ -116,14 +228,47 @@ func SaveConfig(c *Config) error {
 	type XConfig struct {
 		LevelDB string
 
-		PolyRPCUrl     string
-		PolyAccountDir string
+		LogSensitiveFields []string
 
-		EthereumRPCUrl          string
-		EthereumCrossChainAdmin string
+		MethodRetryTimes    int
+		MethodRetryInterval uint64
+		MethodTimeout       uint64
 
-		PaletteRPCUrl          string
+		RPCMaxLag       uint64
+		RPCCompareReads bool
+
+		PolyRPCUrl            rpcpool.Endpoints
+		PolyAccountDir        string
+		PolyAccountPwdSources map[string]string
+		PolyQuorum            int
+
+		EthereumRPCUrl          rpcpool.Endpoints
+		EthereumCrossChainAdmin string
+		EthereumConfirmations   uint64
+		EthereumTxTimeout       uint64
+		EthereumEscalateAfter   uint64
+		EthereumMaxFee          *big.Int
+		EthereumMaxPriorityFee  *big.Int
+		EthereumGasMargin       uint64
+		EthereumScanBatchSize   uint64
+
+		PaletteRPCUrl          rpcpool.Endpoints
 		PaletteCrossChainAdmin string
+		PaletteConfirmations   uint64
+		PaletteTxTimeout       uint64
+		PaletteEscalateAfter   uint64
+		PaletteChainID         uint64
+		PaletteGasPrice        *big.Int
+		PaletteGasMargin       uint64
+
+		// role separated key sources, roles without key source fall back to the cross chain admin
+		PaletteRoles  RoleKeys
+		EthereumRoles RoleKeys
+		PolyRoles     RoleKeys
+
+		MnemonicFile       string
+		MnemonicPwdSource  string
+		DerivationTemplate string
 
 		// palette side chain
 		PaletteSideChainID   uint64
@@ -146,6 +291,9 @@ func SaveConfig(c *Config) error {
 		EthereumPLTProxy      common.Address
 		EthereumNFTProxy      common.Address
 
+		EthereumPLTAssetBin        string
+		EthereumPLTAssetProxyOwned bool
+
 		// bind nft asset
 		PaletteNFTAsset  common.Address
 		EthereumNFTAsset common.Address
@@ -153,14 +301,43 @@ func SaveConfig(c *Config) error {
 
 	x := new(XConfig)
 	x.LevelDB = c.LevelDB
+	x.LogSensitiveFields = c.LogSensitiveFields
+	x.MethodRetryTimes = c.MethodRetryTimes
+	x.MethodRetryInterval = c.MethodRetryInterval
+	x.MethodTimeout = c.MethodTimeout
+	x.RPCMaxLag = c.RPCMaxLag
+	x.RPCCompareReads = c.RPCCompareReads
 	x.PolyRPCUrl = c.PolyRPCUrl
 	x.PolyAccountDir = c.PolyAccountDir
+	x.PolyAccountPwdSources = c.PolyAccountPwdSources
+	x.PolyQuorum = c.PolyQuorum
 
 	x.EthereumRPCUrl = c.EthereumRPCUrl
 	x.EthereumCrossChainAdmin = c.EthereumCrossChainAdmin
+	x.EthereumConfirmations = c.EthereumConfirmations
+	x.EthereumTxTimeout = c.EthereumTxTimeout
+	x.EthereumEscalateAfter = c.EthereumEscalateAfter
+	x.EthereumMaxFee = c.EthereumMaxFee
+	x.EthereumMaxPriorityFee = c.EthereumMaxPriorityFee
+	x.EthereumGasMargin = c.EthereumGasMargin
+	x.EthereumScanBatchSize = c.EthereumScanBatchSize
 
 	x.PaletteRPCUrl = c.PaletteRPCUrl
 	x.PaletteCrossChainAdmin = c.PaletteCrossChainAdmin
+	x.PaletteConfirmations = c.PaletteConfirmations
+	x.PaletteTxTimeout = c.PaletteTxTimeout
+	x.PaletteEscalateAfter = c.PaletteEscalateAfter
+	x.PaletteChainID = c.PaletteChainID
+	x.PaletteGasPrice = c.PaletteGasPrice
+	x.PaletteGasMargin = c.PaletteGasMargin
+
+	x.PaletteRoles = c.PaletteRoles
+	x.EthereumRoles = c.EthereumRoles
+	x.PolyRoles = c.PolyRoles
+
+	x.MnemonicFile = c.MnemonicFile
+	x.MnemonicPwdSource = c.MnemonicPwdSource
+	x.DerivationTemplate = c.DerivationTemplate
 
 	x.PaletteSideChainID = c.PaletteSideChainID
 	x.PaletteSideChainName = c.PaletteSideChainName
@@ -180,6 +357,8 @@ func SaveConfig(c *Config) error {
 	x.EthereumPLTAsset = c.EthereumPLTAsset
 	x.EthereumPLTProxy = c.EthereumPLTProxy
 	x.EthereumNFTProxy = c.EthereumNFTProxy
+	x.EthereumPLTAssetBin = c.EthereumPLTAssetBin
+	x.EthereumPLTAssetProxyOwned = c.EthereumPLTAssetProxyOwned
 
 	x.PaletteNFTAsset = c.PaletteNFTAsset
 	x.EthereumNFTAsset = c.PaletteNFTAsset
@@ -201,43 +380,130 @@ func (c *Config) LoadETHAdminAccount() (*ecdsa.PrivateKey, error) {
 	return getEthAccount(Conf.EthereumCrossChainAdmin, pwdSessionETH)
 }
 
-func (c *Config) LoadPolyAccountList() []*polysdk.Account {
-	list := make([]*polysdk.Account, 0)
+func (c *Config) LoadPolyAccountList() ([]*polysdk.Account, error) {
+	list, _, err := c.LoadPolyValidators()
+	return list, err
+}
 
-	//dir := c.PolyAccountDir
-	//fs, err := ioutil.ReadDir(dir)
-	//if err != nil {
-	//	panic(err)
-	//}
-	acc, err := c.LoadPolyAccount(c.PolyAccountDir)
+// LoadPolyValidators load every poly validator wallet in the poly approver dir, and returns the
+// decrypted accounts together with the quorum. error returned if the quorum can not be reached,
+// so that the caller fails before any poly transaction sent.
+func (c *Config) LoadPolyValidators() ([]*polysdk.Account, int, error) {
+	wallets, err := c.polyWallets()
 	if err != nil {
-		panic(err)
+		return nil, 0, err
+	}
+
+	total := len(wallets)
+	quorum := c.polyQuorum(total)
+	if total < quorum {
+		return nil, 0, fmt.Errorf("only %d poly validator wallets found, quorum %d", total, quorum)
+	}
+
+	list := make([]*polysdk.Account, 0, total)
+	for i, wallet := range wallets {
+		acc, err := c.LoadPolyAccount(wallet)
+		if err != nil {
+			log.Errorf("[%d/%d] load poly validator wallet %s failed, err: %v", i+1, total, wallet, err)
+			continue
+		}
+		log.Infof("[%d/%d] load poly validator %s from %s", i+1, total, acc.Address.ToBase58(), wallet)
+		list = append(list, acc)
+	}
+
+	if len(list) < quorum {
+		return nil, 0, fmt.Errorf("only %d of %d poly validators loaded, quorum %d not reached", len(list), total, quorum)
+	}
+	return list, quorum, nil
+}
+
+// polyWallets returns the poly validator wallet files in the poly approver dir, or the dir itself if it
+// is a file, error returned if there is no wallet.
+func (c *Config) polyWallets() ([]string, error) {
+	dir := c.PolyApproverDir()
+	fi, err := os.Stat(dir)
+	if err != nil {
+		return nil, fmt.Errorf("poly validators wallet path %s, err: %v", dir, err)
+	}
+
+	wallets := make([]string, 0)
+	if !fi.IsDir() {
+		wallets = append(wallets, dir)
+	} else {
+		fs, err := ioutil.ReadDir(dir)
+		if err != nil {
+			return nil, fmt.Errorf("read poly validators wallet dir %s, err: %v", dir, err)
+		}
+		for _, f := range fs {
+			if f.IsDir() || strings.HasPrefix(f.Name(), ".") {
+				continue
+			}
+			wallets = append(wallets, path.Join(dir, f.Name()))
+		}
+	}
+	if len(wallets) == 0 {
+		return nil, fmt.Errorf("no poly validator wallet found in %s", dir)
 	}
-	list = append(list, acc)
+	return wallets, nil
+}
 
-	return list
+func (c *Config) polyQuorum(total int) int {
+	if c.PolyQuorum > 0 {
+		return c.PolyQuorum
+	}
+	return total - (total-1)/3
 }
 
-func (c *Config) LoadPolyCurBookeeperBytes() []byte {
-	accs := c.LoadPolyAccountList()
-	keepers := []keypair.PublicKey{}
-	for _, v := range accs {
-		keepers = append(keepers, v.PublicKey)
+// LoadPolyCurBookeeperBytes assemble the public keys of every poly validator wallet in the poly approver
+// dir without decrypting, and fails if any wallet is unreadable, so that the keeper set is never partial.
+func (c *Config) LoadPolyCurBookeeperBytes() ([]byte, error) {
+	wallets, err := c.polyWallets()
+	if err != nil {
+		return nil, err
+	}
+	keepers := make([]keypair.PublicKey, 0, len(wallets))
+	for _, wallet := range wallets {
+		pub, err := polyWalletPublicKey(wallet)
+		if err != nil {
+			return nil, fmt.Errorf("read public key of poly validator wallet %s failed, err: %v", wallet, err)
+		}
+		keepers = append(keepers, pub)
 	}
 	sink, _ := poly.AssemblePubKeyList(keepers)
-	return sink.Bytes()
+	return sink.Bytes(), nil
+}
+
+func polyWalletPublicKey(filepath string) (keypair.PublicKey, error) {
+	wallet, err := polysdk.NewPolySdk().OpenWallet(filepath)
+	if err != nil {
+		return nil, fmt.Errorf("open wallet error: %v", err)
+	}
+	data, err := wallet.GetDefaultAccountData()
+	if err != nil {
+		return nil, err
+	}
+	enc, err := hex.DecodeString(data.PubKey)
+	if err != nil {
+		return nil, err
+	}
+	return keypair.DeserializePublicKey(enc)
 }
 
 func (c *Config) LoadPolyAccount(path string) (*polysdk.Account, error) {
 	polySDK := polysdk.NewPolySdk()
 
-	acc, err := getPolyAccountByPassword(polySDK, path)
+	acc, err := getPolyAccountByPassword(polySDK, path, c.polyPwdSource(path))
 	if err != nil {
 		return nil, fmt.Errorf("failed to get poly account, err: %s", err)
 	}
 	return acc, nil
 }
 
+func (c *Config) polyPwdSource(filepath string) string {
+	_, fn := path.Split(filepath)
+	return c.PolyAccountPwdSources[fn]
+}
+
 func (c *Config) StorePaletteECCD(addr common.Address) error {
 	c.PaletteECCD = addr
 	return SaveConfig(Conf)
@@ -303,13 +569,20 @@ func (c *Config) StoreEthereumPLTProxy(addr common.Address) error {
 	return SaveConfig(Conf)
 }
 
-func getPolyAccountByPassword(sdk *polysdk.PolySdk, path string) (
+func getPolyAccountByPassword(sdk *polysdk.PolySdk, path string, pwdSource string) (
 	*polysdk.Account, error) {
 	wallet, err := sdk.OpenWallet(path)
 	if err != nil {
 		return nil, fmt.Errorf("open wallet error: %v", err)
 	}
 
+	if pwdSource != "" {
+		pwd, err := readPwdSource(pwdSource)
+		if err != nil {
+			return nil, fmt.Errorf("read password of %s failed, err: %v", path, err)
+		}
+		return wallet.GetDefaultAccount([]byte(pwd))
+	}
 	return repeatPolyDecrypt(wallet, path)
 }
 
@@ -337,16 +610,24 @@ func getEthAccount(path string, typ pwdSessionType) (*ecdsa.PrivateKey, error) {
 const MaxPwdInputRetry int = 20
 
 func repeatPolyDecrypt(wallet *polysdk.Wallet, filepath string) (acc *polysdk.Account, err error) {
+	acc, _, err = repeatPolyDecryptPwd(wallet, filepath, pwdSessionPoly)
+	return
+}
+
+// repeatPolyDecryptPwd decrypt the default account and returns the password together.
+func repeatPolyDecryptPwd(wallet *polysdk.Wallet, filepath string, typ pwdSessionType) (acc *polysdk.Account, pwd []byte, err error) {
 	var (
 		existPwd string
 		curPwd   []byte
-		typ      = pwdSessionPoly
 	)
 
 	_, fn := path.Split(filepath)
-	if existPwd, err = getPwdSession(fn, typ); err == nil {
-		acc, err = wallet.GetDefaultAccount([]byte(existPwd))
-		return
+	if typ != pwdSessionNone {
+		if existPwd, err = getPwdSession(fn, typ); err == nil {
+			pwd = []byte(existPwd)
+			acc, err = wallet.GetDefaultAccount(pwd)
+			return
+		}
 	}
 
 	log.Infof("please input password for poly account %s", fn)
@@ -357,6 +638,7 @@ func repeatPolyDecrypt(wallet *polysdk.Wallet, filepath string) (acc *polysdk.Ac
 			continue
 		}
 		if acc, err = wallet.GetDefaultAccount(curPwd); err == nil {
+			pwd = curPwd
 			return
 		} else {
 			log.Infof("password invalid, err %s, try it again......", err.Error())
@@ -372,8 +654,10 @@ func repeatEthDecrypt(enc []byte, filepath string, pwd string, typ pwdSessionTyp
 		curPwd    string
 	)
 	_, fn := path.Split(filepath)
-	if existPwd, err = getPwdSession(fn, typ); err == nil {
-		return keystore.DecryptKey(enc, existPwd)
+	if typ != pwdSessionNone {
+		if existPwd, err = getPwdSession(fn, typ); err == nil {
+			return keystore.DecryptKey(enc, existPwd)
+		}
 	}
 
 	if key, err = keystore.DecryptKey(enc, pwd); err == nil {
@@ -404,6 +688,9 @@ func readWalletFile(path string) (enc []byte, err error) {
 }
 
 func setPwdSession(fn string, pwd string, typ pwdSessionType) error {
+	if typ == pwdSessionNone {
+		return nil
+	}
 	return dao.SavePwd(byte(typ), []byte(fn), []byte(pwd))
 }
 
@@ -0,0 +1,275 @@
+package config
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"encoding/hex"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/howeyc/gopass"
+	"github.com/ontio/ontology-crypto/ec"
+	"github.com/ontio/ontology-crypto/keypair"
+	"github.com/palettechain/deploy-tool/pkg/dao"
+	"github.com/palettechain/deploy-tool/pkg/log"
+	polysdk "github.com/polynetwork/poly-go-sdk"
+)
+
+// CreateEthKeystore generate a new ethereum key and save it as go-ethereum keystore file.
+func CreateEthKeystore(filepath string, pwd string) (common.Address, error) {
+	key, err := crypto.GenerateKey()
+	if err != nil {
+		return common.Address{}, err
+	}
+	return saveEthKeystore(filepath, key, pwd)
+}
+
+// ImportEthKeystore import the private key from key file, which is the hex key or keystore json
+// accepted by the config key source, or the hex key read from terminal if key file is empty. the
+// password of keystore json is never saved in session.
+func ImportEthKeystore(filepath string, keyfile string, pwd string) (common.Address, error) {
+	var (
+		key *ecdsa.PrivateKey
+		err error
+	)
+	if keyfile != "" {
+		key, err = getEthAccount(keyfile, pwdSessionNone)
+	} else {
+		key, err = readEthHexKey()
+	}
+	if err != nil {
+		return common.Address{}, err
+	}
+	return saveEthKeystore(filepath, key, pwd)
+}
+
+// ChangeEthKeystorePassword decrypt the keystore with the old password, and encrypt it with the new
+// password read from terminal. neither password is saved in session, and the stale sessions are cleared.
+func ChangeEthKeystorePassword(filepath string) (common.Address, error) {
+	enc, err := readWalletFile(filepath)
+	if err != nil {
+		return common.Address{}, err
+	}
+	key, err := repeatEthDecrypt(enc, filepath, "", pwdSessionNone)
+	if err != nil {
+		return common.Address{}, fmt.Errorf("failed to decrypt keyjson: [%v]", err)
+	}
+	newPwd, err := ReadNewPassword(filepath)
+	if err != nil {
+		return common.Address{}, err
+	}
+	keyjson, err := keystore.EncryptKey(key, newPwd, keystore.StandardScryptN, keystore.StandardScryptP)
+	if err != nil {
+		return common.Address{}, err
+	}
+	if err := writeWalletFile(filepath, keyjson); err != nil {
+		return common.Address{}, err
+	}
+
+	_, fn := path.Split(filepath)
+	clearPwdSession(fn, pwdSessionUnknown, pwdSessionETH, pwdSessionPLT)
+	return key.Address, nil
+}
+
+// ExportEthKeystore decrypt the keystore without password session, and write the hex private key into
+// the new file out.
+func ExportEthKeystore(filepath string, out string) (common.Address, error) {
+	key, err := getEthAccount(filepath, pwdSessionNone)
+	if err != nil {
+		return common.Address{}, err
+	}
+	if err := writeKeyFile(out, hex.EncodeToString(crypto.FromECDSA(key))); err != nil {
+		return common.Address{}, err
+	}
+	return crypto.PubkeyToAddress(key.PublicKey), nil
+}
+
+// EthKeystoreAddress returns the address of keystore or hex key file without decrypting.
+func EthKeystoreAddress(filepath string) (common.Address, error) {
+	return getEthAddress(filepath)
+}
+
+// CreatePolyWallet create poly wallet file with a new default setting account.
+func CreatePolyWallet(filepath string, pwd string) (string, error) {
+	wallet, err := polysdk.NewPolySdk().CreateWallet(filepath)
+	if err != nil {
+		return "", err
+	}
+	acc, err := wallet.NewDefaultSettingAccount([]byte(pwd))
+	if err != nil {
+		return "", err
+	}
+	if err := wallet.Save(); err != nil {
+		return "", err
+	}
+	return acc.Address.ToBase58(), nil
+}
+
+// ImportPolyWallet import the hex encoded P-256 private key read from terminal into a new poly wallet file.
+func ImportPolyWallet(filepath string, pwd string) (string, error) {
+	bz, err := readHexKey()
+	if err != nil {
+		return "", err
+	}
+	priv := &ec.PrivateKey{
+		Algorithm:  ec.ECDSA,
+		PrivateKey: ec.ConstructPrivateKey(bz, elliptic.P256()),
+	}
+	wif, err := keypair.Key2WIF(priv)
+	if err != nil {
+		return "", err
+	}
+
+	wallet, err := polysdk.NewPolySdk().CreateWallet(filepath)
+	if err != nil {
+		return "", err
+	}
+	acc, err := wallet.NewAccountFromWIF(wif, []byte(pwd))
+	if err != nil {
+		return "", err
+	}
+	if err := wallet.Save(); err != nil {
+		return "", err
+	}
+	return acc.Address.ToBase58(), nil
+}
+
+// ChangePolyWalletPassword change the password of the default account in poly wallet file, the new
+// password is read from terminal.
+func ChangePolyWalletPassword(filepath string) (string, error) {
+	wallet, err := polysdk.NewPolySdk().OpenWallet(filepath)
+	if err != nil {
+		return "", fmt.Errorf("open wallet error: %v", err)
+	}
+	acc, oldPwd, err := repeatPolyDecryptPwd(wallet, filepath, pwdSessionNone)
+	if err != nil {
+		return "", err
+	}
+	newPwd, err := ReadNewPassword(filepath)
+	if err != nil {
+		return "", err
+	}
+	addr := acc.Address.ToBase58()
+	if err := wallet.ChangeAccountPassword(addr, oldPwd, []byte(newPwd)); err != nil {
+		return "", err
+	}
+	if err := wallet.Save(); err != nil {
+		return "", err
+	}
+
+	_, fn := path.Split(filepath)
+	clearPwdSession(fn, pwdSessionPoly)
+	return addr, nil
+}
+
+// ExportPolyWallet decrypt the default account of poly wallet file without password session, and write
+// the hex P-256 private key into the new file out, which can be imported by `ImportPolyWallet`.
+func ExportPolyWallet(filepath string, out string) (string, error) {
+	wallet, err := polysdk.NewPolySdk().OpenWallet(filepath)
+	if err != nil {
+		return "", fmt.Errorf("open wallet error: %v", err)
+	}
+	acc, _, err := repeatPolyDecryptPwd(wallet, filepath, pwdSessionNone)
+	if err != nil {
+		return "", err
+	}
+	priv, ok := acc.PrivateKey.(*ec.PrivateKey)
+	if !ok || priv.Algorithm != ec.ECDSA || priv.Params().Name != elliptic.P256().Params().Name {
+		return "", fmt.Errorf("poly account %s is not P-256 ECDSA key", acc.Address.ToBase58())
+	}
+	if err := writeKeyFile(out, hex.EncodeToString(priv.D.FillBytes(make([]byte, 32)))); err != nil {
+		return "", err
+	}
+	return acc.Address.ToBase58(), nil
+}
+
+// PolyWalletAddress returns the default account address of poly wallet file without decrypting.
+func PolyWalletAddress(filepath string) (string, error) {
+	wallet, err := polysdk.NewPolySdk().OpenWallet(filepath)
+	if err != nil {
+		return "", fmt.Errorf("open wallet error: %v", err)
+	}
+	data, err := wallet.GetDefaultAccountData()
+	if err != nil {
+		return "", err
+	}
+	return data.Address, nil
+}
+
+func saveEthKeystore(filepath string, key *ecdsa.PrivateKey, pwd string) (common.Address, error) {
+	if _, err := os.Stat(filepath); err == nil {
+		return common.Address{}, fmt.Errorf("keystore %s already exist", filepath)
+	}
+
+	// import into the keystore directory, and rename the generated `UTC--...` file to the target path
+	dir, _ := path.Split(filepath)
+	if dir == "" {
+		dir = "."
+	}
+	ks := keystore.NewKeyStore(dir, keystore.StandardScryptN, keystore.StandardScryptP)
+	acc, err := ks.ImportECDSA(key, pwd)
+	if err != nil {
+		return common.Address{}, err
+	}
+	if err := os.Rename(acc.URL.Path, filepath); err != nil {
+		return common.Address{}, err
+	}
+	return acc.Address, nil
+}
+
+// writeKeyFile create the hex key file which is only readable by the owner, and never overwrite the
+// existing file.
+func writeKeyFile(filepath string, hexKey string) error {
+	file, err := os.OpenFile(filepath, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0600)
+	if err != nil {
+		return err
+	}
+	if _, err := file.WriteString(hexKey); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
+
+func writeWalletFile(filepath string, enc []byte) error {
+	tmp := filepath + ".tmp"
+	if err := ioutil.WriteFile(tmp, enc, 0600); err != nil {
+		return err
+	}
+	return os.Rename(tmp, filepath)
+}
+
+// clearPwdSession delete the password sessions of the wallet file, so that the following decryption
+// won't use the stale password, and the new password is not persisted.
+func clearPwdSession(fn string, types ...pwdSessionType) {
+	for _, typ := range types {
+		if _, err := getPwdSession(fn, typ); err == nil {
+			if err := dao.DeletePwd(byte(typ), []byte(fn)); err != nil {
+				log.Warnf("clear password session of %s failed, err: %v", fn, err)
+			}
+		}
+	}
+}
+
+func readEthHexKey() (*ecdsa.PrivateKey, error) {
+	bz, err := readHexKey()
+	if err != nil {
+		return nil, err
+	}
+	return crypto.ToECDSA(bz)
+}
+
+func readHexKey() ([]byte, error) {
+	log.Infof("please input hex private key")
+	enc, err := gopass.GetPasswd()
+	if err != nil {
+		return nil, err
+	}
+	return hex.DecodeString(strings.TrimPrefix(strings.TrimSpace(string(enc)), "0x"))
+}
@@ -0,0 +1,94 @@
+package config
+
+import (
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/howeyc/gopass"
+	"github.com/palettechain/deploy-tool/pkg/hdwallet"
+	"github.com/palettechain/deploy-tool/pkg/log"
+)
+
+// LoadHDDriver load the operator supplied mnemonic and derive accounts with the configured
+// derivation template, the template param will override the configuration if it is not empty.
+func (c *Config) LoadHDDriver(template string) (*hdwallet.Driver, error) {
+	mnemonic, err := c.LoadMnemonic()
+	if err != nil {
+		return nil, err
+	}
+	if template == "" {
+		template = c.DerivationTemplate
+	}
+	return hdwallet.NewDriver(mnemonic, template)
+}
+
+// LoadMnemonic decrypt mnemonic from the encrypted mnemonic file, the password is read from
+// password source, leveldb session or terminal. the mnemonic will be read from terminal directly
+// if mnemonic file not configured.
+func (c *Config) LoadMnemonic() (string, error) {
+	if c.MnemonicFile == "" {
+		return readMnemonic()
+	}
+
+	enc, err := readWalletFile(c.MnemonicFile)
+	if err != nil {
+		return "", err
+	}
+	if c.MnemonicPwdSource != "" {
+		pwd, err := readPwdSource(c.MnemonicPwdSource)
+		if err != nil {
+			return "", fmt.Errorf("read password of %s failed, err: %v", c.MnemonicFile, err)
+		}
+		return hdwallet.DecryptMnemonic(enc, pwd)
+	}
+	return repeatMnemonicDecrypt(enc, c.MnemonicFile)
+}
+
+func repeatMnemonicDecrypt(enc []byte, filepath string) (mnemonic string, err error) {
+	var (
+		existPwd string
+		curPwd   []byte
+		typ      = pwdSessionMnemonic
+	)
+
+	_, fn := path.Split(filepath)
+	if existPwd, err = getPwdSession(fn, typ); err == nil {
+		return hdwallet.DecryptMnemonic(enc, existPwd)
+	}
+
+	log.Infof("please input password for mnemonic file %s", fn)
+
+	for i := 0; i < MaxPwdInputRetry; i++ {
+		if curPwd, err = gopass.GetPasswd(); err != nil {
+			log.Infof("input error, try it again......")
+			continue
+		}
+		if mnemonic, err = hdwallet.DecryptMnemonic(enc, string(curPwd)); err == nil {
+			_ = setPwdSession(fn, string(curPwd), typ)
+			return
+		} else {
+			log.Infof("password invalid, err %s, try it again......", err.Error())
+		}
+	}
+	return
+}
+
+func readMnemonic() (string, error) {
+	log.Infof("please input mnemonic")
+
+	for i := 0; i < MaxPwdInputRetry; i++ {
+		bz, err := gopass.GetPasswd()
+		if err != nil {
+			log.Infof("input error, try it again......")
+			continue
+		}
+		mnemonic := strings.Join(strings.Fields(string(bz)), " ")
+		if mnemonic == "" {
+			log.Infof("mnemonic should not be empty, try it again......")
+			continue
+		}
+		return mnemonic, nil
+	}
+	return "", fmt.Errorf("input mnemonic failed")
+}
@@ -0,0 +1,68 @@
+package config
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strings"
+
+	"github.com/howeyc/gopass"
+	"github.com/palettechain/deploy-tool/pkg/log"
+)
+
+const (
+	pwdSourceEnv  = "env:"
+	pwdSourceFile = "file:"
+)
+
+// readPwdSource read password from password source, the source should be formatted as
+// `env:VARIABLE_NAME` or `file:/path/to/password/file`.
+func readPwdSource(src string) (string, error) {
+	switch {
+	case strings.HasPrefix(src, pwdSourceEnv):
+		name := strings.TrimPrefix(src, pwdSourceEnv)
+		pwd, ok := os.LookupEnv(name)
+		if !ok {
+			return "", fmt.Errorf("environment variable %s not set", name)
+		}
+		return pwd, nil
+
+	case strings.HasPrefix(src, pwdSourceFile):
+		bz, err := ioutil.ReadFile(strings.TrimPrefix(src, pwdSourceFile))
+		if err != nil {
+			return "", err
+		}
+		return strings.TrimRight(string(bz), "\r\n"), nil
+
+	default:
+		return "", fmt.Errorf("invalid password source, expect `env:` or `file:` prefix")
+	}
+}
+
+// ReadNewPassword read new password from terminal twice, and the two inputs should be the same.
+func ReadNewPassword(name string) (string, error) {
+	for i := 0; i < MaxPwdInputRetry; i++ {
+		log.Infof("please input new password for %s", name)
+		first, err := gopass.GetPasswd()
+		if err != nil {
+			log.Infof("input error, try it again......")
+			continue
+		}
+		if len(first) == 0 {
+			log.Infof("password should not be empty, try it again......")
+			continue
+		}
+		log.Infof("please repeat the password")
+		second, err := gopass.GetPasswd()
+		if err != nil {
+			log.Infof("input error, try it again......")
+			continue
+		}
+		if string(first) != string(second) {
+			log.Infof("passwords not match, try it again......")
+			continue
+		}
+		return string(first), nil
+	}
+	return "", fmt.Errorf("input password failed")
+}
@@ -0,0 +1,119 @@
+package config
+
+import (
+	"crypto/ecdsa"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// Role is the duty a key performs during deployment. deploy steps use a hot deployer key,
+// contract owner actions use the owner key, routine operations use the operator key and the funder
+// key pays native currency or PLT to test accounts.
+type Role string
+
+const (
+	RoleDeployer     Role = "deployer"
+	RoleOwner        Role = "owner"
+	RoleOperator     Role = "operator"
+	RolePolyApprover Role = "poly-approver"
+	RoleFunder       Role = "funder"
+)
+
+// RoleKeys maps role to key source, the key source is an hex private key file or keystore file
+// the same as `PaletteCrossChainAdmin`, poly approver source is the poly wallet directory.
+type RoleKeys map[Role]string
+
+func (r RoleKeys) source(role Role, fallback string) string {
+	if r == nil {
+		return fallback
+	}
+	if src, ok := r[role]; ok && src != "" {
+		return src
+	}
+	return fallback
+}
+
+func (r RoleKeys) check(chain string, allowed ...Role) error {
+	for role := range r {
+		valid := false
+		for _, v := range allowed {
+			if role == v {
+				valid = true
+				break
+			}
+		}
+		if !valid {
+			return fmt.Errorf("%s role %s not supported", chain, role)
+		}
+	}
+	return nil
+}
+
+func (c *Config) checkRoles() error {
+	if err := c.PaletteRoles.check("palette", RoleDeployer, RoleOwner, RoleOperator, RoleFunder); err != nil {
+		return err
+	}
+	if err := c.EthereumRoles.check("ethereum", RoleDeployer, RoleOwner, RoleOperator, RoleFunder); err != nil {
+		return err
+	}
+	return c.PolyRoles.check("poly", RolePolyApprover)
+}
+
+// LoadPLTAccount load palette private key for role, roles without key source use the cross chain admin.
+func (c *Config) LoadPLTAccount(role Role) (*ecdsa.PrivateKey, error) {
+	return getEthAccount(c.PaletteRoles.source(role, c.PaletteCrossChainAdmin), pwdSessionPLT)
+}
+
+// LoadETHAccount load ethereum private key for role, roles without key source use the cross chain admin.
+func (c *Config) LoadETHAccount(role Role) (*ecdsa.PrivateKey, error) {
+	return getEthAccount(c.EthereumRoles.source(role, c.EthereumCrossChainAdmin), pwdSessionETH)
+}
+
+// PaletteRoleAddress returns the address of palette role without decrypting the keystore.
+func (c *Config) PaletteRoleAddress(role Role) (common.Address, error) {
+	return getEthAddress(c.PaletteRoles.source(role, c.PaletteCrossChainAdmin))
+}
+
+// EthereumRoleAddress returns the address of ethereum role without decrypting the keystore.
+func (c *Config) EthereumRoleAddress(role Role) (common.Address, error) {
+	return getEthAddress(c.EthereumRoles.source(role, c.EthereumCrossChainAdmin))
+}
+
+// PolyApproverDir returns the poly validators wallet location used to approve and sign poly transactions.
+func (c *Config) PolyApproverDir() string {
+	return c.PolyRoles.source(RolePolyApprover, c.PolyAccountDir)
+}
+
+func getEthAddress(path string) (common.Address, error) {
+	enc, err := readWalletFile(path)
+	if err != nil {
+		return common.Address{}, err
+	}
+	if len(enc) <= 64 {
+		bz, err := hex.DecodeString(strings.TrimSpace(string(enc)))
+		if err != nil {
+			return common.Address{}, err
+		}
+		key, err := crypto.ToECDSA(bz)
+		if err != nil {
+			return common.Address{}, err
+		}
+		return crypto.PubkeyToAddress(key.PublicKey), nil
+	}
+
+	var keyjson struct {
+		Address string `json:"address"`
+	}
+	if err := json.Unmarshal(enc, &keyjson); err != nil {
+		return common.Address{}, fmt.Errorf("failed to unmarshal keyjson %s, err: %v", path, err)
+	}
+	if !common.IsHexAddress(keyjson.Address) {
+		return common.Address{}, fmt.Errorf("invalid keyjson address %s", keyjson.Address)
+	}
+	return common.HexToAddress(keyjson.Address), nil
+}
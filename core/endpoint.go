@@ -6,35 +6,74 @@ import (
 
 func Endpoint() {
 	// palette side chain register and init
-	frame.Tool.RegMethod("plt-register-sidechain", PLTRegisterSideChain)
-	frame.Tool.RegMethod("plt-approve-sidechain", PLTApproveRegisterSideChain)
-	frame.Tool.RegMethod("plt-sync-plt-genesis", PLTSyncPLTGenesis)
-	frame.Tool.RegMethod("plt-sync-poly-genesis", PLTSyncPolyGenesis)
+	frame.Tool.RegMethodE("plt-register-sidechain", PLTRegisterSideChain)
+	frame.Tool.RegMethodE("plt-approve-sidechain", PLTApproveRegisterSideChain)
+	frame.Tool.RegMethodE("plt-sync-plt-genesis", PLTSyncPLTGenesis)
+	frame.Tool.RegMethodE("plt-sync-poly-genesis", PLTSyncPolyGenesis)
 
 	// palette contract binding relationship
-	frame.Tool.RegMethod("plt-deploy-eccd", PLTDeployECCD)
-	frame.Tool.RegMethod("plt-deploy-eccm", PLTDeployECCM)
-	frame.Tool.RegMethod("plt-recover-eccm", PLTRecoverBookeeper)
-	frame.Tool.RegMethod("plt-deploy-ccmp", PLTDeployCCMP)
-	frame.Tool.RegMethod("plt-eccd-ownership", PLTTransferECCDOwnerShip)
-	frame.Tool.RegMethod("plt-eccm-ownership", PLTTransferECCMOwnerShip)
-	frame.Tool.RegMethod("plt-plt-ccmp", PLTSetCCMP)
-	frame.Tool.RegMethod("plt-bind-plt-proxy", PLTBindPLTProxy)
-	frame.Tool.RegMethod("plt-bind-plt-asset", PLTBindPLTAsset)
-	frame.Tool.RegMethod("plt-deploy-nft-proxy", PLTDeployNFTProxy)
-	frame.Tool.RegMethod("plt-bind-nft-proxy", PLTBindNFTProxy)
-	frame.Tool.RegMethod("plt-bind-nft-asset", PLTBindNFTAsset)
-	frame.Tool.RegMethod("plt-nft-ccmp", PLTSetNFTCCMP)
+	frame.Tool.RegMethodE("plt-deploy-eccd", PLTDeployECCD)
+	frame.Tool.RegMethodE("plt-deploy-eccm", PLTDeployECCM)
+	frame.Tool.RegMethodE("plt-recover-eccm", PLTRecoverBookeeper)
+	frame.Tool.RegMethodE("plt-deploy-ccmp", PLTDeployCCMP)
+	frame.Tool.RegMethodE("plt-eccd-ownership", PLTTransferECCDOwnerShip)
+	frame.Tool.RegMethodE("plt-eccm-ownership", PLTTransferECCMOwnerShip)
+	frame.Tool.RegMethodE("plt-plt-ccmp", PLTSetCCMP)
+	frame.Tool.RegMethodE("plt-bind-plt-proxy", PLTBindPLTProxy)
+	frame.Tool.RegMethodE("plt-bind-plt-asset", PLTBindPLTAsset)
+	frame.Tool.RegMethodE("plt-deploy-nft-proxy", PLTDeployNFTProxy)
+	frame.Tool.RegMethodE("plt-bind-nft-proxy", PLTBindNFTProxy)
+	frame.Tool.RegMethodE("plt-bind-nft-asset", PLTBindNFTAsset)
+	frame.Tool.RegMethodE("plt-nft-ccmp", PLTSetNFTCCMP)
 
 	// palette deploy wrap
-	frame.Tool.RegMethod("plt-deploy-plt-wrap", PLTDeployPLTWrap)
-	frame.Tool.RegMethod("plt-deploy-nft-wrap", PLTDeployNFTWrap)
-	frame.Tool.RegMethod("plt-deploy-nft-query", PLTDeployNFTQuery)
-	frame.Tool.RegMethod("plt-set-nft-wrap-proxy", PLTNFTWrapperSetLockProxy)
-
-	// ethereum bind proxy and asset
-	frame.Tool.RegMethod("eth-bind-plt-proxy", ETHBindPLTProxy)
-	frame.Tool.RegMethod("eth-bind-plt-asset", ETHBindPLTAsset)
-	frame.Tool.RegMethod("eth-bind-nft-proxy", ETHBindNFTProxy)
-	frame.Tool.RegMethod("eth-bind-nft-asset", ETHBindNFTAsset)
+	frame.Tool.RegMethodE("plt-deploy-plt-wrap", PLTDeployPLTWrap)
+	frame.Tool.RegMethodE("plt-deploy-nft-wrap", PLTDeployNFTWrap)
+	frame.Tool.RegMethodE("plt-deploy-nft-query", PLTDeployNFTQuery)
+	frame.Tool.RegMethodE("plt-set-nft-wrap-proxy", PLTNFTWrapperSetLockProxy)
+
+	// ethereum cross chain core contracts and poly genesis, retried on flaky node
+	frame.Tool.RegMethodE("eth-deploy-plt-asset", ETHDeployPLTAsset)
+	frame.Tool.RegMethodE("eth-deploy-plt-proxy", ETHDeployPLTProxy)
+	frame.Tool.RegMethodE("eth-deploy-nft-proxy", ETHDeployNFTProxy)
+	frame.Tool.RegMethodE("eth-deploy-eccd", ETHDeployECCD)
+	frame.Tool.RegMethodE("eth-deploy-eccm", ETHDeployECCM)
+	frame.Tool.RegMethodE("eth-deploy-ccmp", ETHDeployCCMP)
+	frame.Tool.RegMethodE("eth-eccd-ownership", ETHTransferECCDOwnerShip)
+	frame.Tool.RegMethodE("eth-eccm-ownership", ETHTransferECCMOwnerShip)
+	frame.Tool.RegMethodE("eth-plt-ccmp", ETHSetPLTCCMP)
+	frame.Tool.RegMethodE("eth-nft-ccmp", ETHSetNFTCCMP)
+	frame.Tool.RegMethodE("eth-plt-asset-ownership", ETHTransferPLTAssetOwnership)
+	frame.Tool.RegMethodE("eth-sync-poly-genesis", ETHSyncPolyGenesis)
+
+	// ethereum bind proxy and asset, retried on flaky node
+	frame.Tool.RegMethodE("eth-bind-plt-proxy", ETHBindPLTProxy)
+	frame.Tool.RegMethodE("eth-bind-plt-asset", ETHBindPLTAsset)
+	frame.Tool.RegMethodE("eth-bind-nft-proxy", ETHBindNFTProxy)
+	frame.Tool.RegMethodE("eth-bind-nft-asset", ETHBindNFTAsset)
+
+	// compare deployed contracts with the bundled bytecode
+	frame.Tool.RegMethodE("verify-bytecode", VerifyBytecode)
+
+	// hd wallet commands
+	frame.Tool.RegCommand("wallet", "derive", WalletDerive)
+	frame.Tool.RegCommand("wallet", "encrypt-mnemonic", WalletEncryptMnemonic)
+
+	// ethereum keystore and poly wallet commands
+	frame.Tool.RegCommand("keystore", "create", KeystoreCreate)
+	frame.Tool.RegCommand("keystore", "import", KeystoreImport)
+	frame.Tool.RegCommand("keystore", "export", KeystoreExport)
+	frame.Tool.RegCommand("keystore", "passwd", KeystorePasswd)
+	frame.Tool.RegCommand("keystore", "address", KeystoreAddress)
+
+	// stuck transaction replacement commands
+	frame.Tool.RegCommand("tx", "speedup", TxSpeedUp)
+	frame.Tool.RegCommand("tx", "cancel", TxCancel)
+
+	// ethereum cross chain events scanner
+	frame.Tool.RegCommand("events", "list", EventsList)
+
+	// fund test accounts from the funder key
+	frame.Tool.RegCommand("fund", "ethereum", FundEthereum)
+	frame.Tool.RegCommand("fund", "palette", FundPalette)
 }
@@ -19,53 +19,56 @@
 package core
 
 import (
+	"fmt"
+
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/contracts/native"
+	"github.com/ethereum/go-ethereum/contracts/native/utils"
 	"github.com/palettechain/deploy-tool/config"
+	"github.com/palettechain/deploy-tool/pkg/errs"
+	"github.com/palettechain/deploy-tool/pkg/eth"
+	"github.com/palettechain/deploy-tool/pkg/files"
 	"github.com/palettechain/deploy-tool/pkg/log"
 )
 
-func ETHBindPLTProxy() (succeed bool) {
-	cli, err := getEthereumCli()
+func ETHBindPLTProxy() error {
+	cli, err := getEthereumCli(config.RoleOwner)
 	if err != nil {
-		log.Errorf("get eth cross chain admin failed, err: %v", err)
-		return
+		return fmt.Errorf("get eth owner failed, err: %w", err)
 	}
 	localLockProxy := config.Conf.EthereumPLTProxy
 	targetLockProxy := common.HexToAddress(native.PLTContractAddress)
 	targetSideChainID := config.Conf.PaletteSideChainID
 
-	cur, _ := cli.GetBoundPLTProxy(localLockProxy, targetSideChainID)
+	cur, err := cli.GetBoundPLTProxy(localLockProxy, targetSideChainID)
+	if err != nil {
+		return fmt.Errorf("get bound PLT proxy failed, err: %w", err)
+	}
 	if cur == targetLockProxy {
-		log.Infof("PLT proxy %s already bound to %s", localLockProxy.Hex(), targetLockProxy.Hex())
-		return true
+		return errs.AlreadyDone("bind PLT proxy %s to %s", localLockProxy.Hex(), targetLockProxy.Hex())
 	}
 
 	hash, err := cli.BindPLTProxy(localLockProxy, targetLockProxy, targetSideChainID)
 	if err != nil {
-		log.Errorf("bind PLT proxy on ethereum failed, err: %s", err.Error())
-		return
+		return fmt.Errorf("bind PLT proxy on ethereum failed, err: %w", err)
 	}
 
 	actual, err := cli.GetBoundPLTProxy(localLockProxy, targetSideChainID)
 	if err != nil {
-		log.Error(err)
-		return
+		return err
 	}
 	if actual != targetLockProxy {
-		log.Errorf("proxy bind failed, expect %s, got %s", targetLockProxy.Hex(), actual.Hex())
-		return
+		return fmt.Errorf("proxy bind failed, expect %s, got %s", targetLockProxy.Hex(), actual.Hex())
 	}
 
 	log.Infof("bind PLT proxy %s to %s on ethereum success, hash %s", localLockProxy.Hex(), targetLockProxy.Hex(), hash.Hex())
-	return true
+	return nil
 }
 
-func ETHBindPLTAsset() (succeed bool) {
-	cli, err := getEthereumCli()
+func ETHBindPLTAsset() error {
+	cli, err := getEthereumCli(config.RoleOwner)
 	if err != nil {
-		log.Errorf("get eth cross chain admin failed, err: %v", err)
-		return
+		return fmt.Errorf("get eth owner failed, err: %w", err)
 	}
 
 	localLockProxy := config.Conf.EthereumPLTProxy
@@ -73,74 +76,70 @@ func ETHBindPLTAsset() (succeed bool) {
 	toAsset := common.HexToAddress(native.PLTContractAddress)
 	toChainId := config.Conf.PaletteSideChainID
 
-	cur, _ := cli.GetBoundPLTAsset(localLockProxy, fromAsset, toChainId)
+	cur, err := cli.GetBoundPLTAsset(localLockProxy, fromAsset, toChainId)
+	if err != nil {
+		return fmt.Errorf("get bound PLT asset failed, err: %w", err)
+	}
 	if cur == toAsset {
-		log.Infof("PLT asset %s already bound to %s", fromAsset.Hex(), toAsset.Hex())
-		return true
+		return errs.AlreadyDone("bind PLT asset %s to %s", fromAsset.Hex(), toAsset.Hex())
 	}
 
 	hash, err := cli.BindPLTAsset(localLockProxy, fromAsset, toAsset, toChainId)
 	if err != nil {
-		log.Errorf("bind PLT asset on ethereum failed, err: %s", err.Error())
-		return
+		return fmt.Errorf("bind PLT asset on ethereum failed, err: %w", err)
 	}
 
 	actual, err := cli.GetBoundPLTAsset(localLockProxy, fromAsset, toChainId)
 	if err != nil {
-		log.Error(err)
-		return
+		return err
 	}
 	if actual != toAsset {
-		log.Errorf("bind plt asset on ethereum failed, expect %s, got %s", toAsset.Hex(), actual.Hex())
-		return
+		return fmt.Errorf("bind plt asset on ethereum failed, expect %s, got %s", toAsset.Hex(), actual.Hex())
 	}
 
 	log.Infof("bind PLT asset %s to %s on ethereum success, hash %s", fromAsset.Hex(), toAsset.Hex(), hash.Hex())
-	return true
+	return nil
 }
 
-func ETHBindNFTProxy() (succeed bool) {
-	cli, err := getEthereumCli()
+func ETHBindNFTProxy() error {
+	cli, err := getEthereumCli(config.RoleOwner)
 	if err != nil {
-		log.Errorf("get eth cross chain admin failed, err: %v", err)
-		return
+		return fmt.Errorf("get eth owner failed, err: %w", err)
 	}
 
 	localLockProxy := config.Conf.EthereumNFTProxy
 	targetLockProxy := config.Conf.PaletteNFTProxy
 	targetSideChainID := config.Conf.PaletteSideChainID
 
-	cur, _ := cli.GetBoundNFTProxy(localLockProxy, targetSideChainID)
+	cur, err := cli.GetBoundNFTProxy(localLockProxy, targetSideChainID)
+	if err != nil {
+		return fmt.Errorf("get bound NFT proxy failed, err: %w", err)
+	}
 	if cur == targetLockProxy {
-		log.Infof("NFT proxy %s already bound to %s", localLockProxy.Hex(), targetLockProxy.Hex())
-		return true
+		return errs.AlreadyDone("bind NFT proxy %s to %s", localLockProxy.Hex(), targetLockProxy.Hex())
 	}
 
 	hash, err := cli.BindNFTProxy(localLockProxy, targetLockProxy, targetSideChainID)
 	if err != nil {
-		log.Errorf("bind NFT proxy on ethereum failed, err: %s", err.Error())
-		return
+		return fmt.Errorf("bind NFT proxy on ethereum failed, err: %w", err)
 	}
 
 	actual, err := cli.GetBoundNFTProxy(localLockProxy, targetSideChainID)
 	if err != nil {
-		log.Error(err)
-		return
+		return err
 	}
 	if actual != targetLockProxy {
-		log.Errorf("bind NFT proxy to ccmp failed, expect %s, got %s", targetLockProxy.Hex(), actual.Hex())
-		return
+		return fmt.Errorf("bind NFT proxy to ccmp failed, expect %s, got %s", targetLockProxy.Hex(), actual.Hex())
 	}
 
 	log.Infof("bind NFT proxy %s to %s on ethereum success, tx %s", localLockProxy.Hex(), targetLockProxy.Hex(), hash.Hex())
-	return true
+	return nil
 }
 
-func ETHBindNFTAsset() (succeed bool) {
-	cli, err := getEthereumCli()
+func ETHBindNFTAsset() error {
+	cli, err := getEthereumCli(config.RoleOwner)
 	if err != nil {
-		log.Errorf("get eth cross chain admin failed, err: %v", err)
-		return
+		return fmt.Errorf("get eth owner failed, err: %w", err)
 	}
 
 	proxy := config.Conf.EthereumNFTProxy
@@ -148,10 +147,12 @@ func ETHBindNFTAsset() (succeed bool) {
 	toAsset := config.Conf.PaletteNFTAsset
 	chainID := config.Conf.PaletteSideChainID
 
-	cur, _ := cli.GetBoundNFTAsset(proxy, fromAsset, chainID)
+	cur, err := cli.GetBoundNFTAsset(proxy, fromAsset, chainID)
+	if err != nil {
+		return fmt.Errorf("get bound NFT asset failed, err: %w", err)
+	}
 	if cur == toAsset {
-		log.Infof("NFT asset %s already bound to %s", fromAsset.Hex(), toAsset.Hex())
-		return true
+		return errs.AlreadyDone("bind NFT asset %s to %s", fromAsset.Hex(), toAsset.Hex())
 	}
 
 	hash, err := cli.BindNFTAsset(
@@ -161,20 +162,426 @@ func ETHBindNFTAsset() (succeed bool) {
 		chainID,
 	)
 	if err != nil {
-		log.Errorf("bind NFT asset on ethereum failed, err: %s", err.Error())
-		return
+		return fmt.Errorf("bind NFT asset on ethereum failed, err: %w", err)
 	}
 
 	actual, err := cli.GetBoundNFTAsset(proxy, fromAsset, chainID)
 	if err != nil {
-		log.Error(err)
-		return
+		return err
 	}
 	if actual != toAsset {
-		log.Errorf("bind NFT asset failed, expect %s, got %s", toAsset.Hex(), actual.Hex())
-		return
+		return fmt.Errorf("bind NFT asset failed, expect %s, got %s", toAsset.Hex(), actual.Hex())
 	}
 
 	log.Infof("bind NFT asset %s to %s on ethereum success, hash %s", fromAsset.Hex(), toAsset.Hex(), hash.Hex())
-	return true
+	return nil
+}
+
+// ethereum cross chain core contracts, mirror the palette ones:
+// eccd: 管理epoch
+// eccm: 管理跨链转账
+// ccmp: 记录eccm地址及升级等
+// the deploy steps are skipped if the contract in config has been deployed on ethereum.
+
+func ETHDeployECCD() error {
+	cli, err := getEthereumCli(config.RoleDeployer)
+	if err != nil {
+		return fmt.Errorf("get eth deployer failed, err: %w", err)
+	}
+
+	eccd := config.Conf.EthereumECCD
+	if done, err := ethDeployed(cli, "eccd", eccd, cli.TransferECCDOwnership, cli.ECCDOwnership); done || err != nil {
+		return err
+	}
+
+	eccd, err = ethDeploy(cli, "eccd", func(cli *eth.EthInvoker) (common.Address, error) {
+		return cli.DeployECCD()
+	}, config.Conf.StoreEthereumECCD)
+	if err != nil {
+		return err
+	}
+	if err := ethHandOverToOwner(cli, "eccd", eccd, cli.TransferECCDOwnership, cli.ECCDOwnership); err != nil {
+		return err
+	}
+
+	log.Infof("deploy eccd %s on ethereum success!", eccd.Hex())
+	return nil
+}
+
+func ETHDeployECCM() error {
+	cli, err := getEthereumCli(config.RoleDeployer)
+	if err != nil {
+		return fmt.Errorf("get eth deployer failed, err: %w", err)
+	}
+
+	eccm := config.Conf.EthereumECCM
+	if done, err := ethDeployed(cli, "eccm", eccm, cli.TransferECCMOwnership, cli.ECCMOwnership); done || err != nil {
+		return err
+	}
+
+	eccd := config.Conf.EthereumECCD
+	sideChainID := config.Conf.EthereumSideChainID
+	whiteList := []common.Address{
+		config.Conf.EthereumPLTProxy,
+		config.Conf.EthereumNFTProxy,
+	}
+	if err := requireAddresses(map[string]common.Address{
+		"EthereumECCD":     eccd,
+		"EthereumPLTProxy": config.Conf.EthereumPLTProxy,
+		"EthereumNFTProxy": config.Conf.EthereumNFTProxy,
+	}); err != nil {
+		return fmt.Errorf("deploy eccm on ethereum failed, err: %w", err)
+	}
+	keepers, err := config.Conf.LoadPolyCurBookeeperBytes()
+	if err != nil {
+		return fmt.Errorf("load poly bookeepers failed, err: %w", err)
+	}
+	eccm, err = ethDeploy(cli, "eccm", func(cli *eth.EthInvoker) (common.Address, error) {
+		return cli.DeployECCM(eccd, sideChainID, whiteList, keepers)
+	}, config.Conf.StoreEthereumECCM)
+	if err != nil {
+		return err
+	}
+	if err := ethHandOverToOwner(cli, "eccm", eccm, cli.TransferECCMOwnership, cli.ECCMOwnership); err != nil {
+		return err
+	}
+
+	log.Infof("deploy eccm %s on ethereum success!", eccm.Hex())
+	return nil
+}
+
+func ETHDeployCCMP() error {
+	cli, err := getEthereumCli(config.RoleDeployer)
+	if err != nil {
+		return fmt.Errorf("get eth deployer failed, err: %w", err)
+	}
+
+	ccmp := config.Conf.EthereumCCMP
+	if done, err := ethDeployed(cli, "ccmp", ccmp, cli.TransferCCMPOwnership, cli.CCMPOwnership); done || err != nil {
+		return err
+	}
+
+	eccm := config.Conf.EthereumECCM
+	if err := requireAddresses(map[string]common.Address{"EthereumECCM": eccm}); err != nil {
+		return fmt.Errorf("deploy ccmp on ethereum failed, err: %w", err)
+	}
+	ccmp, err = ethDeploy(cli, "ccmp", func(cli *eth.EthInvoker) (common.Address, error) {
+		return cli.DeployCCMP(eccm)
+	}, config.Conf.StoreEthereumCCMP)
+	if err != nil {
+		return err
+	}
+	if err := ethHandOverToOwner(cli, "ccmp", ccmp, cli.TransferCCMPOwnership, cli.CCMPOwnership); err != nil {
+		return err
+	}
+
+	log.Infof("deploy ccmp %s on ethereum success!", ccmp.Hex())
+	return nil
+}
+
+func ETHTransferECCDOwnerShip() error {
+	cli, err := getEthereumCli(config.RoleOwner)
+	if err != nil {
+		return fmt.Errorf("get eth owner failed, err: %w", err)
+	}
+
+	eccd := config.Conf.EthereumECCD
+	eccm := config.Conf.EthereumECCM
+
+	cur, err := cli.ECCDOwnership(eccd)
+	if err != nil {
+		return fmt.Errorf("get eccd owner failed, err: %w", err)
+	}
+	if cur == eccm {
+		return errs.AlreadyDone("transfer eccd %s to eccm %s", eccd.Hex(), eccm.Hex())
+	}
+
+	hash, err := cli.TransferECCDOwnership(eccd, eccm)
+	if err != nil {
+		return fmt.Errorf("transfer eccd ownership on ethereum failed, err: %w", err)
+	}
+	actual, err := cli.ECCDOwnership(eccd)
+	if err != nil {
+		return err
+	}
+	if actual != eccm {
+		return fmt.Errorf("eccd new owner %s != actual %s", eccm.Hex(), actual.Hex())
+	}
+
+	log.Infof("transfer eccd %s to eccm %s on ethereum success! hash %s", eccd.Hex(), eccm.Hex(), hash.Hex())
+	return nil
+}
+
+func ETHTransferECCMOwnerShip() error {
+	cli, err := getEthereumCli(config.RoleOwner)
+	if err != nil {
+		return fmt.Errorf("get eth owner failed, err: %w", err)
+	}
+
+	eccm := config.Conf.EthereumECCM
+	ccmp := config.Conf.EthereumCCMP
+
+	cur, err := cli.ECCMOwnership(eccm)
+	if err != nil {
+		return fmt.Errorf("get eccm owner failed, err: %w", err)
+	}
+	if cur == ccmp {
+		return errs.AlreadyDone("transfer eccm %s to ccmp %s", eccm.Hex(), ccmp.Hex())
+	}
+
+	hash, err := cli.TransferECCMOwnership(eccm, ccmp)
+	if err != nil {
+		return fmt.Errorf("transfer eccm ownership on ethereum failed, err: %w", err)
+	}
+	actual, err := cli.ECCMOwnership(eccm)
+	if err != nil {
+		return err
+	}
+	if actual != ccmp {
+		return fmt.Errorf("eccm new owner %s != actual %s", ccmp.Hex(), actual.Hex())
+	}
+
+	log.Infof("transfer eccm %s to ccmp %s on ethereum success! hash %s", eccm.Hex(), ccmp.Hex(), hash.Hex())
+	return nil
+}
+
+// ETHSyncPolyGenesis init the ethereum eccm with poly genesis header and bookeepers, it is skipped if
+// the eccd has recorded the poly consensus public keys already.
+func ETHSyncPolyGenesis() error {
+	cli, err := getEthereumCli(config.RoleOperator)
+	if err != nil {
+		return fmt.Errorf("get eth operator failed, err: %w", err)
+	}
+
+	eccd := config.Conf.EthereumECCD
+	eccm := config.Conf.EthereumECCM
+
+	keys, err := cli.ECCDCurEpochPubKeys(eccd)
+	if err != nil {
+		return fmt.Errorf("get eccd current epoch public keys failed, err: %w", err)
+	}
+	if len(keys) > 0 {
+		return errs.AlreadyDone("sync poly genesis header to ethereum eccm %s", eccm.Hex())
+	}
+
+	headerEnc, bookeepersEnc, height, err := polyGenesis()
+	if err != nil {
+		return err
+	}
+	hash, err := cli.InitGenesisBlock(eccm, headerEnc, bookeepersEnc)
+	if err != nil {
+		return fmt.Errorf("failed to initGenesisBlock on ethereum, err: %w", err)
+	}
+
+	log.Infof("sync poly genesis header to ethereum success, txhash %s, block number %d", hash.Hex(), height)
+	return nil
+}
+
+func ETHDeployPLTProxy() error {
+	cli, err := getEthereumCli(config.RoleDeployer)
+	if err != nil {
+		return fmt.Errorf("get eth deployer failed, err: %w", err)
+	}
+
+	proxy := config.Conf.EthereumPLTProxy
+	if done, err := ethDeployed(cli, "PLT proxy", proxy, cli.TransferPLTProxyOwnership, cli.PLTProxyOwnership); done || err != nil {
+		return err
+	}
+
+	proxy, err = ethDeploy(cli, "PLT proxy", func(cli *eth.EthInvoker) (common.Address, error) {
+		return cli.DeployPLTLockProxy()
+	}, config.Conf.StoreEthereumPLTProxy)
+	if err != nil {
+		return err
+	}
+	if err := ethHandOverToOwner(cli, "PLT proxy", proxy, cli.TransferPLTProxyOwnership, cli.PLTProxyOwnership); err != nil {
+		return err
+	}
+
+	log.Infof("deploy PLT proxy %s on ethereum success!", proxy.Hex())
+	return nil
+}
+
+func ETHDeployNFTProxy() error {
+	cli, err := getEthereumCli(config.RoleDeployer)
+	if err != nil {
+		return fmt.Errorf("get eth deployer failed, err: %w", err)
+	}
+
+	proxy := config.Conf.EthereumNFTProxy
+	if done, err := ethDeployed(cli, "NFT proxy", proxy, cli.TransferNFTProxyOwnership, cli.NFTProxyOwnership); done || err != nil {
+		return err
+	}
+
+	proxy, err = ethDeploy(cli, "NFT proxy", func(cli *eth.EthInvoker) (common.Address, error) {
+		return cli.DeployNFTLockProxy()
+	}, config.Conf.StoreEthereumNFTProxy)
+	if err != nil {
+		return err
+	}
+	if err := ethHandOverToOwner(cli, "NFT proxy", proxy, cli.TransferNFTProxyOwnership, cli.NFTProxyOwnership); err != nil {
+		return err
+	}
+
+	log.Infof("deploy NFT proxy %s on ethereum success!", proxy.Hex())
+	return nil
+}
+
+func ETHSetPLTCCMP() error {
+	cli, err := getEthereumCli(config.RoleOwner)
+	if err != nil {
+		return fmt.Errorf("get eth owner failed, err: %w", err)
+	}
+
+	proxy := config.Conf.EthereumPLTProxy
+	ccmp := config.Conf.EthereumCCMP
+
+	cur, err := cli.GetPLTCCMP(proxy)
+	if err != nil {
+		return fmt.Errorf("get PLT proxy ccmp failed, err: %w", err)
+	}
+	if cur == ccmp {
+		return errs.AlreadyDone("set PLT proxy %s ccmp %s", proxy.Hex(), ccmp.Hex())
+	}
+
+	hash, err := cli.SetPLTCCMP(proxy, ccmp)
+	if err != nil {
+		return fmt.Errorf("set PLT proxy ccmp on ethereum failed, err: %w", err)
+	}
+	actual, err := cli.GetPLTCCMP(proxy)
+	if err != nil {
+		return err
+	}
+	if actual != ccmp {
+		return fmt.Errorf("set PLT proxy ccmp failed, expect %s, got %s", ccmp.Hex(), actual.Hex())
+	}
+
+	log.Infof("set PLT proxy %s ccmp %s on ethereum success, hash %s", proxy.Hex(), ccmp.Hex(), hash.Hex())
+	return nil
+}
+
+func ETHSetNFTCCMP() error {
+	cli, err := getEthereumCli(config.RoleOwner)
+	if err != nil {
+		return fmt.Errorf("get eth owner failed, err: %w", err)
+	}
+
+	proxy := config.Conf.EthereumNFTProxy
+	ccmp := config.Conf.EthereumCCMP
+
+	cur, err := cli.GetNFTCCMP(proxy)
+	if err != nil {
+		return fmt.Errorf("get NFT proxy ccmp failed, err: %w", err)
+	}
+	if cur == ccmp {
+		return errs.AlreadyDone("set NFT proxy %s ccmp %s", proxy.Hex(), ccmp.Hex())
+	}
+
+	hash, err := cli.SetNFTCCMP(proxy, ccmp)
+	if err != nil {
+		return fmt.Errorf("set NFT proxy ccmp on ethereum failed, err: %w", err)
+	}
+	actual, err := cli.GetNFTCCMP(proxy)
+	if err != nil {
+		return err
+	}
+	if actual != ccmp {
+		return fmt.Errorf("set NFT proxy ccmp failed, expect %s, got %s", ccmp.Hex(), actual.Hex())
+	}
+
+	log.Infof("set NFT proxy %s ccmp %s on ethereum success, hash %s", proxy.Hex(), ccmp.Hex(), hash.Hex())
+	return nil
+}
+
+func ETHDeployPLTAsset() error {
+	cli, err := getEthereumCli(config.RoleDeployer)
+	if err != nil {
+		return fmt.Errorf("get eth deployer failed, err: %w", err)
+	}
+
+	asset := config.Conf.EthereumPLTAsset
+	if asset != utils.EmptyAddress {
+		deployed, err := cli.HasCode(asset)
+		if err != nil {
+			return err
+		}
+		if deployed {
+			if err := ethHandOverPLTAsset(cli, asset); err != nil {
+				return err
+			}
+			return errs.AlreadyDone("deploy PLT asset %s on ethereum", asset.Hex())
+		}
+	}
+
+	asset, err = ethDeploy(cli, "PLT asset", func(cli *eth.EthInvoker) (common.Address, error) {
+		if config.Conf.EthereumPLTAssetBin == "" {
+			return utils.EmptyAddress, fmt.Errorf("EthereumPLTAssetBin is not configured")
+		}
+		artifact, err := files.ReadFile(config.Conf.EthereumPLTAssetBin)
+		if err != nil {
+			return utils.EmptyAddress, err
+		}
+		bin, err := eth.ParseContractBin(artifact)
+		if err != nil {
+			return utils.EmptyAddress, fmt.Errorf("read PLT asset artifact %s failed, err: %v", config.Conf.EthereumPLTAssetBin, err)
+		}
+		return cli.DeployPLTAsset(bin)
+	}, config.Conf.StoreEthereumPLTAsset)
+	if err != nil {
+		return err
+	}
+	if err := ethHandOverPLTAsset(cli, asset); err != nil {
+		return err
+	}
+
+	log.Infof("deploy PLT asset %s on ethereum success!", asset.Hex())
+	return nil
+}
+
+// ETHTransferPLTAssetOwnership hand over the PLT asset ownership from the owner role to the PLT lock proxy
+// if `EthereumPLTAssetProxyOwned` is true, the lock proxy can not accept the ownership, so it fails before
+// sending tx if the asset transfers ownership in two steps.
+func ETHTransferPLTAssetOwnership() error {
+	asset := config.Conf.EthereumPLTAsset
+	proxy := config.Conf.EthereumPLTProxy
+	if !config.Conf.EthereumPLTAssetProxyOwned {
+		log.Infof("PLT asset %s stays with the owner role as EthereumPLTAssetProxyOwned is false, skip", asset.Hex())
+		return nil
+	}
+
+	cli, err := getEthereumCli(config.RoleOwner)
+	if err != nil {
+		return fmt.Errorf("get eth owner failed, err: %w", err)
+	}
+
+	cur, err := cli.PLTAssetOwnership(asset)
+	if err != nil {
+		return fmt.Errorf("get PLT asset owner failed, err: %w", err)
+	}
+	if cur == proxy {
+		return errs.AlreadyDone("transfer PLT asset %s ownership to proxy %s", asset.Hex(), proxy.Hex())
+	}
+	twoStep, err := pltAssetTwoStep(cli, asset)
+	if err != nil {
+		return err
+	}
+	if twoStep {
+		return fmt.Errorf("PLT asset %s ownership should be accepted by the new owner, which can not be done by proxy %s",
+			asset.Hex(), proxy.Hex())
+	}
+
+	hash, err := cli.TransferPLTAssetOwnership(asset, proxy)
+	if err != nil {
+		return fmt.Errorf("transfer PLT asset ownership on ethereum failed, err: %w", err)
+	}
+	actual, err := cli.PLTAssetOwnership(asset)
+	if err != nil {
+		return err
+	}
+	if actual != proxy {
+		return fmt.Errorf("transfer PLT asset ownership failed, expect %s, got %s", proxy.Hex(), actual.Hex())
+	}
+
+	log.Infof("transfer PLT asset %s ownership to proxy %s on ethereum success, hash %s", asset.Hex(), proxy.Hex(), hash.Hex())
+	return nil
 }
@@ -0,0 +1,124 @@
+package core
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/palettechain/deploy-tool/config"
+	"github.com/palettechain/deploy-tool/pkg/dao"
+	"github.com/palettechain/deploy-tool/pkg/eth"
+	"github.com/palettechain/deploy-tool/pkg/frame"
+	"github.com/palettechain/deploy-tool/pkg/log"
+	"github.com/palettechain/deploy-tool/pkg/scan"
+)
+
+// EventsList lists the cross chain events of ethereum ECCM and lock proxies over blocks, filtered by
+// address or asset, e.g: `deploy-tool -config=config.json events list --from 13000000 --address 0x...`.
+// the last scanned block of the filter is saved in leveldb, and the scan is resumed after it if `--from`
+// is not set.
+func EventsList(args []string) (succeed bool) {
+	fs := flag.NewFlagSet("events list", flag.ContinueOnError)
+	from := fs.Int64("from", -1, "start block, default is the next block of checkpoint")
+	to := fs.Uint64("to", 0, "end block, default is the latest block with enough confirmations")
+	address := fs.String("address", "", "sender, source or target address of events")
+	asset := fs.String("asset", "", "ethereum or target chain asset of events")
+	batch := fs.Uint64("batch", config.Conf.EthereumScanBatchSize, "number of blocks in one request, default is 1000")
+	confirmations := fs.Uint64("confirmations", scan.DefaultConfirmations, "number of confirmations before the block saved as checkpoint")
+	jsonOut := fs.Bool("json", false, "print events as json")
+	if err := fs.Parse(args); err != nil {
+		log.Errorf("%v", err)
+		return
+	}
+	addr, err := parseOptionalAddress("address", *address)
+	if err != nil {
+		log.Errorf("%v", err)
+		return
+	}
+	assetAddr, err := parseOptionalAddress("asset", *asset)
+	if err != nil {
+		log.Errorf("%v", err)
+		return
+	}
+
+	tools, err := eth.NewEthToolsPool(config.EthereumPool)
+	if err != nil {
+		log.Errorf("dial ethereum failed, err: %v", err)
+		return
+	}
+	contracts := make([]common.Address, 0, 3)
+	for _, contract := range []common.Address{config.Conf.EthereumECCM, config.Conf.EthereumPLTProxy, config.Conf.EthereumNFTProxy} {
+		if contract != (common.Address{}) {
+			contracts = append(contracts, contract)
+		}
+	}
+	if len(contracts) == 0 {
+		log.Errorf("EthereumECCM and lock proxies are not configured")
+		return
+	}
+	name := fmt.Sprintf("ethereum-events-%s-%s-%s", config.Conf.EthereumECCM.Hex(), addr.Hex(), assetAddr.Hex())
+	scanner := tools.NewEventScanner(contracts...).SetBatchSize(*batch).SetConfirmations(*confirmations).
+		SetCheckpoint(dao.NewCheckpoint(name))
+
+	ctx := frame.Tool.Context()
+	start := uint64(*from)
+	if *from < 0 {
+		next, ok, err := scanner.Resume()
+		if err != nil {
+			log.Errorf("%v", err)
+			return
+		}
+		if !ok {
+			log.Errorf("no checkpoint of the filter, --from is required")
+			return
+		}
+		start = next
+	}
+	end := *to
+	if end == 0 {
+		if end, err = scanner.Head(ctx); err != nil {
+			log.Errorf("get ethereum head failed, err: %v", err)
+			return
+		}
+	}
+
+	log.Infof("scan ethereum cross chain events in blocks [%d, %d], address %s, asset %s", start, end, addr.Hex(), assetAddr.Hex())
+	matched := 0
+	err = scanner.Scan(ctx, start, end, func(logs []types.Log) error {
+		for _, ev := range eth.CrossChainEvents(logs) {
+			if !ev.Match(addr, assetAddr) {
+				continue
+			}
+			matched++
+			if *jsonOut {
+				enc, err := json.Marshal(ev)
+				if err != nil {
+					return err
+				}
+				log.Infof("%s", enc)
+			} else {
+				log.Infof("%s", ev)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		log.Errorf("scan ethereum cross chain events failed, err: %v", err)
+		return
+	}
+	log.Infof("%d cross chain events found in blocks [%d, %d]", matched, start, end)
+	return true
+}
+
+// parseOptionalAddress returns the empty address if the flag is not set.
+func parseOptionalAddress(name, value string) (common.Address, error) {
+	if value == "" {
+		return common.Address{}, nil
+	}
+	if !common.IsHexAddress(value) {
+		return common.Address{}, fmt.Errorf("invalid %s %s", name, value)
+	}
+	return common.HexToAddress(value), nil
+}
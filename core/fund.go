@@ -0,0 +1,124 @@
+package core
+
+import (
+	"flag"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/palettechain/deploy-tool/config"
+	"github.com/palettechain/deploy-tool/pkg/log"
+	"github.com/palettechain/deploy-tool/pkg/sdk"
+)
+
+// fundFlags parsed from the fund commands, the targets are the addresses after the flags and the hd
+// wallet accounts in [derive-from, derive-from+derive-count).
+type fundFlags struct {
+	fs          *flag.FlagSet
+	amount      *string
+	role        *string
+	deriveFrom  *int
+	deriveCount *int
+	template    *string
+}
+
+func newFundFlags(name string) *fundFlags {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	return &fundFlags{
+		fs:          fs,
+		amount:      fs.String("amount", "", "amount in wei paid to every address"),
+		role:        fs.String("role", string(config.RoleFunder), "role of the key which pays the amount"),
+		deriveFrom:  fs.Int("derive-from", 0, "index of the first hd wallet account to fund"),
+		deriveCount: fs.Int("derive-count", 0, "number of hd wallet accounts to fund"),
+		template:    fs.String("template", "", "derivation path template with one `%d`, default is config DerivationTemplate"),
+	}
+}
+
+// parse returns the amount and the targets.
+func (f *fundFlags) parse(args []string) (*big.Int, []common.Address, error) {
+	if err := f.fs.Parse(args); err != nil {
+		return nil, nil, err
+	}
+	amount, ok := new(big.Int).SetString(*f.amount, 10)
+	if !ok || amount.Sign() <= 0 {
+		return nil, nil, fmt.Errorf("invalid amount %q", *f.amount)
+	}
+
+	targets := make([]common.Address, 0, f.fs.NArg()+*f.deriveCount)
+	for _, arg := range f.fs.Args() {
+		if !common.IsHexAddress(arg) {
+			return nil, nil, fmt.Errorf("invalid address %s", arg)
+		}
+		targets = append(targets, common.HexToAddress(arg))
+	}
+	if *f.deriveFrom < 0 || *f.deriveCount < 0 {
+		return nil, nil, fmt.Errorf("invalid derive range, from %d count %d", *f.deriveFrom, *f.deriveCount)
+	}
+	if *f.deriveCount > 0 {
+		driver, err := config.Conf.LoadHDDriver(*f.template)
+		if err != nil {
+			return nil, nil, fmt.Errorf("load hd wallet failed, err: %v", err)
+		}
+		for i := *f.deriveFrom; i < *f.deriveFrom+*f.deriveCount; i++ {
+			acc, err := driver.Drive(i)
+			if err != nil {
+				return nil, nil, fmt.Errorf("derive account %d failed, err: %v", i, err)
+			}
+			targets = append(targets, acc.Address)
+		}
+	}
+	if len(targets) == 0 {
+		return nil, nil, fmt.Errorf("no address to fund")
+	}
+	return amount, targets, nil
+}
+
+// FundEthereum transfer ether from the funder key to the addresses and hd wallet accounts, e.g:
+// `deploy-tool -config=config.json fund ethereum --amount 1000000000000000000 --derive-count 10 0x...`
+func FundEthereum(args []string) (succeed bool) {
+	f := newFundFlags("fund ethereum")
+	amount, targets, err := f.parse(args)
+	if err != nil {
+		log.Errorf("%v", err)
+		return
+	}
+
+	cli, err := getEthereumCli(config.Role(*f.role))
+	if err != nil {
+		log.Errorf("get ethereum %s client failed, err: %v", *f.role, err)
+		return
+	}
+	if _, err := cli.Fund(targets, amount); err != nil {
+		log.Errorf("fund on ethereum failed, err: %v", err)
+		return
+	}
+
+	log.Infof("fund %d addresses %s wei on ethereum success!", len(targets), amount)
+	return true
+}
+
+// FundPalette pay native currency or PLT from the funder key to the addresses and hd wallet accounts,
+// `--method mint` mints PLT and requires the funder to be the PLT owner, e.g:
+// `deploy-tool -config=config.json fund palette --amount 1000000000000000000 --method transfer 0x...`
+func FundPalette(args []string) (succeed bool) {
+	f := newFundFlags("fund palette")
+	method := f.fs.String("method", sdk.FundNative, "fund method, one of native, transfer and mint")
+	amount, targets, err := f.parse(args)
+	if err != nil {
+		log.Errorf("%v", err)
+		return
+	}
+
+	cli, err := getPaletteCli(config.Role(*f.role))
+	if err != nil {
+		log.Errorf("get palette %s client failed, err: %v", *f.role, err)
+		return
+	}
+	if _, err := cli.Fund(targets, amount, *method); err != nil {
+		log.Errorf("fund on palette failed, err: %v", err)
+		return
+	}
+
+	log.Infof("fund %d addresses %s wei by %s on palette success!", len(targets), amount, *method)
+	return true
+}
@@ -0,0 +1,187 @@
+package core
+
+import (
+	"flag"
+
+	"github.com/palettechain/deploy-tool/config"
+	"github.com/palettechain/deploy-tool/pkg/log"
+)
+
+type keystoreFlags struct {
+	file   string
+	key    string
+	out    string
+	isPoly bool
+}
+
+// parseKeystoreFlags parse the common flags of keystore commands, the `--key` flag only used by import,
+// and the `--out` flag only used by export.
+func parseKeystoreFlags(name string, args []string, withKey bool) (*keystoreFlags, bool) {
+	f := new(keystoreFlags)
+	fs := flag.NewFlagSet("keystore "+name, flag.ContinueOnError)
+	fs.StringVar(&f.file, "file", "", "ethereum keystore or poly wallet file path")
+	fs.BoolVar(&f.isPoly, "poly", false, "poly wallet format instead of ethereum keystore")
+	if withKey {
+		fs.StringVar(&f.key, "key", "", "hex key or keystore file to import, read hex key from terminal if empty. ethereum only")
+	}
+	if name == "export" {
+		fs.StringVar(&f.out, "out", "", "hex key file to export, which must not exist")
+	}
+	if err := fs.Parse(args); err != nil {
+		return nil, false
+	}
+	if f.file == "" {
+		log.Errorf("keystore file path is empty")
+		return nil, false
+	}
+	if name == "export" && f.out == "" {
+		log.Errorf("export file path is empty")
+		return nil, false
+	}
+	return f, true
+}
+
+// KeystoreCreate generate a new key and save it as ethereum keystore or poly wallet file, e.g:
+// `deploy-tool -config=config.json keystore create --file owner.json [--poly]`
+func KeystoreCreate(args []string) (succeed bool) {
+	f, ok := parseKeystoreFlags("create", args, false)
+	if !ok {
+		return
+	}
+	pwd, err := config.ReadNewPassword(f.file)
+	if err != nil {
+		log.Errorf("read password failed, err: %v", err)
+		return
+	}
+
+	if f.isPoly {
+		addr, err := config.CreatePolyWallet(f.file, pwd)
+		if err != nil {
+			log.Errorf("create poly wallet %s failed, err: %v", f.file, err)
+			return
+		}
+		log.Infof("create poly wallet %s, address %s", f.file, addr)
+	} else {
+		addr, err := config.CreateEthKeystore(f.file, pwd)
+		if err != nil {
+			log.Errorf("create keystore %s failed, err: %v", f.file, err)
+			return
+		}
+		log.Infof("create keystore %s, address %s", f.file, addr.Hex())
+	}
+	return true
+}
+
+// KeystoreImport import hex private key into ethereum keystore or poly wallet file, e.g:
+// `deploy-tool -config=config.json keystore import --file owner.json [--key hexkey.txt] [--poly]`
+func KeystoreImport(args []string) (succeed bool) {
+	f, ok := parseKeystoreFlags("import", args, true)
+	if !ok {
+		return
+	}
+	if f.isPoly && f.key != "" {
+		log.Errorf("poly wallet only import hex key from terminal")
+		return
+	}
+	pwd, err := config.ReadNewPassword(f.file)
+	if err != nil {
+		log.Errorf("read password failed, err: %v", err)
+		return
+	}
+
+	if f.isPoly {
+		addr, err := config.ImportPolyWallet(f.file, pwd)
+		if err != nil {
+			log.Errorf("import poly wallet %s failed, err: %v", f.file, err)
+			return
+		}
+		log.Infof("import poly wallet %s, address %s", f.file, addr)
+	} else {
+		addr, err := config.ImportEthKeystore(f.file, f.key, pwd)
+		if err != nil {
+			log.Errorf("import keystore %s failed, err: %v", f.file, err)
+			return
+		}
+		log.Infof("import keystore %s, address %s", f.file, addr.Hex())
+	}
+	return true
+}
+
+// KeystoreExport decrypt ethereum keystore or poly wallet file, and write the hex private key into a new
+// file which is only readable by the owner, the key is never printed, e.g:
+// `deploy-tool -config=config.json keystore export --file owner.json --out hexkey.txt [--poly]`
+func KeystoreExport(args []string) (succeed bool) {
+	f, ok := parseKeystoreFlags("export", args, false)
+	if !ok {
+		return
+	}
+
+	if f.isPoly {
+		addr, err := config.ExportPolyWallet(f.file, f.out)
+		if err != nil {
+			log.Errorf("export poly wallet %s failed, err: %v", f.file, err)
+			return
+		}
+		log.Infof("export poly wallet %s to %s, address %s", f.file, f.out, addr)
+	} else {
+		addr, err := config.ExportEthKeystore(f.file, f.out)
+		if err != nil {
+			log.Errorf("export keystore %s failed, err: %v", f.file, err)
+			return
+		}
+		log.Infof("export keystore %s to %s, address %s", f.file, f.out, addr.Hex())
+	}
+	return true
+}
+
+// KeystorePasswd change the password of ethereum keystore or poly wallet file, e.g:
+// `deploy-tool -config=config.json keystore passwd --file owner.json [--poly]`
+func KeystorePasswd(args []string) (succeed bool) {
+	f, ok := parseKeystoreFlags("passwd", args, false)
+	if !ok {
+		return
+	}
+
+	if f.isPoly {
+		addr, err := config.ChangePolyWalletPassword(f.file)
+		if err != nil {
+			log.Errorf("change poly wallet %s password failed, err: %v", f.file, err)
+			return
+		}
+		log.Infof("change poly wallet %s password, address %s", f.file, addr)
+	} else {
+		addr, err := config.ChangeEthKeystorePassword(f.file)
+		if err != nil {
+			log.Errorf("change keystore %s password failed, err: %v", f.file, err)
+			return
+		}
+		log.Infof("change keystore %s password, address %s", f.file, addr.Hex())
+	}
+	return true
+}
+
+// KeystoreAddress print the address of ethereum keystore or poly wallet file without decrypting, e.g:
+// `deploy-tool -config=config.json keystore address --file owner.json [--poly]`
+func KeystoreAddress(args []string) (succeed bool) {
+	f, ok := parseKeystoreFlags("address", args, false)
+	if !ok {
+		return
+	}
+
+	if f.isPoly {
+		addr, err := config.PolyWalletAddress(f.file)
+		if err != nil {
+			log.Errorf("read poly wallet %s address failed, err: %v", f.file, err)
+			return
+		}
+		log.Infof("poly wallet %s address %s", f.file, addr)
+	} else {
+		addr, err := config.EthKeystoreAddress(f.file)
+		if err != nil {
+			log.Errorf("read keystore %s address failed, err: %v", f.file, err)
+			return
+		}
+		log.Infof("keystore %s address %s", f.file, addr.Hex())
+	}
+	return true
+}
@@ -19,13 +19,14 @@
 package core
 
 import (
+	"github.com/palettechain/deploy-tool/config"
 	"github.com/palettechain/deploy-tool/pkg/log"
 )
 
 func NFTDeploy() (succeed bool) {
-	cli, err := getPaletteCli()
+	cli, err := getPaletteCli(config.RoleDeployer)
 	if err != nil {
-		log.Errorf("get palette cross chain admin client failed")
+		log.Errorf("get palette deployer client failed, err: %v", err)
 		return
 	}
 
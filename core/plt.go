@@ -1,14 +1,16 @@
 package core
 
 import (
-	"bytes"
+	"fmt"
 	"math/big"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/contracts/native"
 	"github.com/ethereum/go-ethereum/contracts/native/utils"
 	"github.com/palettechain/deploy-tool/config"
+	"github.com/palettechain/deploy-tool/pkg/errs"
 	"github.com/palettechain/deploy-tool/pkg/log"
+	"github.com/palettechain/deploy-tool/pkg/sdk"
 )
 
 // 在palette合约部署成功后由三本合约:
@@ -20,35 +22,42 @@ import (
 // 1. 执行palette eccm合约的verifyProofAndExecuteTx，这个方法会进入到palette native PLT合约的unlock方法
 // 2. palette native PLT unlock 取出ccmp地址，并进入该合约查询eccm地址，比较从relayer过来的eccm地址与该地址是否匹配
 // 3. 进入unlock资金逻辑
+// the deploy steps are skipped if the contract in config has been deployed on palette.
 
-func PLTDeployECCD() (succeed bool) {
-	cli, err := getPaletteCli()
+func PLTDeployECCD() error {
+	cli, err := getPaletteCli(config.RoleDeployer)
 	if err != nil {
-		log.Errorf("get palette cross chain admin client failed")
-		return
+		return fmt.Errorf("get palette deployer failed, err: %w", err)
 	}
 
-	eccd, err := cli.DeployECCD()
-	if err != nil {
-		log.Errorf("deploy eccd on palette failed, err: %s", err.Error())
-		return
+	eccd := config.Conf.PaletteECCD
+	if done, err := pltDeployed(cli, "eccd", eccd, cli.ECCDTransferOwnerShip, cli.ECCDOwnership); done || err != nil {
+		return err
 	}
 
-	if err := config.Conf.StorePaletteECCD(eccd); err != nil {
-		log.Errorf("store palette eccd err: %v", err)
-		return
+	eccd, err = pltDeploy(cli, "eccd", func(cli *sdk.Client) (common.Address, error) {
+		return cli.DeployECCD()
+	}, config.Conf.StorePaletteECCD)
+	if err != nil {
+		return err
+	}
+	if err := pltHandOverToOwner(cli, "eccd", eccd, cli.ECCDTransferOwnerShip, cli.ECCDOwnership); err != nil {
+		return err
 	}
 
 	log.Infof("deploy eccd %s on palette success!", eccd.Hex())
-
-	return true
+	return nil
 }
 
-func PLTDeployECCM() (succeed bool) {
-	cli, err := getPaletteCli()
+func PLTDeployECCM() error {
+	cli, err := getPaletteCli(config.RoleDeployer)
 	if err != nil {
-		log.Errorf("get palette cross chain admin client failed")
-		return
+		return fmt.Errorf("get palette deployer failed, err: %w", err)
+	}
+
+	eccm := config.Conf.PaletteECCM
+	if done, err := pltDeployed(cli, "eccm", eccm, cli.ECCMTransferOwnerShip, cli.ECCMOwnership); done || err != nil {
+		return err
 	}
 
 	eccd := config.Conf.PaletteECCD
@@ -57,345 +66,342 @@ func PLTDeployECCM() (succeed bool) {
 		common.HexToAddress(native.PLTContractAddress),
 		config.Conf.PaletteNFTProxy,
 	}
-	keepers := config.Conf.LoadPolyCurBookeeperBytes()
-	eccm, err := cli.DeployECCM(eccd, sideChainID, whiteList, keepers)
+	if err := requireAddresses(map[string]common.Address{"PaletteECCD": eccd}); err != nil {
+		return fmt.Errorf("deploy eccm on palette failed, err: %w", err)
+	}
+	keepers, err := config.Conf.LoadPolyCurBookeeperBytes()
 	if err != nil {
-		log.Errorf("deploy eccm on palette failed, err: %s", err.Error())
-		return
+		return fmt.Errorf("load poly bookeepers failed, err: %w", err)
 	}
-
-	if err := config.Conf.StorePaletteECCM(eccm); err != nil {
-		log.Errorf("store palette eccm err: %v", err)
-		return
+	eccm, err = pltDeploy(cli, "eccm", func(cli *sdk.Client) (common.Address, error) {
+		return cli.DeployECCM(eccd, sideChainID, whiteList, keepers)
+	}, config.Conf.StorePaletteECCM)
+	if err != nil {
+		return err
+	}
+	if err := pltHandOverToOwner(cli, "eccm", eccm, cli.ECCMTransferOwnerShip, cli.ECCMOwnership); err != nil {
+		return err
 	}
 
 	log.Infof("deploy eccm %s on palette success!", eccm.Hex())
-
-	return true
+	return nil
 }
 
-func PLTRecoverBookeeper() (succeed bool) {
-	cli, err := getPaletteCli()
+func PLTRecoverBookeeper() error {
+	cli, err := getPaletteCli(config.RoleOwner)
 	if err != nil {
-		log.Errorf("get palette cross chain admin client failed")
-		return
+		return fmt.Errorf("get palette owner failed, err: %w", err)
 	}
 
 	eccm := config.Conf.PaletteECCM
-	keepers := config.Conf.LoadPolyCurBookeeperBytes()
+	keepers, err := config.Conf.LoadPolyCurBookeeperBytes()
+	if err != nil {
+		return fmt.Errorf("load poly bookeepers failed, err: %w", err)
+	}
 	if _, err := cli.RecoverECCM(eccm, keepers); err != nil {
-		log.Errorf("recover eccm on palette failed, err: %s", err.Error())
-		return
+		return fmt.Errorf("recover eccm on palette failed, err: %w", err)
 	}
 
 	log.Info("recover eccm bookeepers success")
-	return true
+	return nil
 }
 
-func PLTDeployCCMP() (succeed bool) {
-	cli, err := getPaletteCli()
+func PLTDeployCCMP() error {
+	cli, err := getPaletteCli(config.RoleDeployer)
 	if err != nil {
-		log.Errorf("get palette cross chain admin client failed")
-		return
+		return fmt.Errorf("get palette deployer failed, err: %w", err)
+	}
+
+	ccmp := config.Conf.PaletteCCMP
+	if done, err := pltDeployed(cli, "ccmp", ccmp, cli.CCMPTransferOwnerShip, cli.CCMPOwnership); done || err != nil {
+		return err
 	}
 
 	eccm := config.Conf.PaletteECCM
-	ccmp, err := cli.DeployCCMP(eccm)
+	if err := requireAddresses(map[string]common.Address{"PaletteECCM": eccm}); err != nil {
+		return fmt.Errorf("deploy ccmp on palette failed, err: %w", err)
+	}
+	ccmp, err = pltDeploy(cli, "ccmp", func(cli *sdk.Client) (common.Address, error) {
+		return cli.DeployCCMP(eccm)
+	}, config.Conf.StorePaletteCCMP)
 	if err != nil {
-		log.Errorf("deploy ccmp on palette failed, err: %s", err.Error())
-		return
+		return err
 	}
-
-	if err := config.Conf.StorePaletteCCMP(ccmp); err != nil {
-		log.Errorf("store palette ccmp err: %v", err)
-		return
+	if err := pltHandOverToOwner(cli, "ccmp", ccmp, cli.CCMPTransferOwnerShip, cli.CCMPOwnership); err != nil {
+		return err
 	}
 
 	log.Infof("deploy ccmp %s on palette success!", ccmp.Hex())
-
-	return true
+	return nil
 }
 
-func PLTTransferECCDOwnerShip() (succeed bool) {
-	cli, err := getPaletteCli()
+func PLTTransferECCDOwnerShip() error {
+	cli, err := getPaletteCli(config.RoleOwner)
 	if err != nil {
-		log.Errorf("get palette cross chain admin client failed")
-		return
+		return fmt.Errorf("get palette owner failed, err: %w", err)
 	}
 
 	eccd := config.Conf.PaletteECCD
 	eccm := config.Conf.PaletteECCM
 
-	cur, _ := cli.ECCDOwnership(eccd)
-	if bytes.Equal(eccm.Bytes(), cur.Bytes()) {
-		log.Infof("eccd %s owner is %s already", eccd.Hex(), eccm.Hex())
-		return true
+	cur, err := cli.ECCDOwnership(eccd)
+	if err != nil {
+		return fmt.Errorf("get eccd owner failed, err: %w", err)
+	}
+	if cur == eccm {
+		return errs.AlreadyDone("transfer eccd %s to eccm %s", eccd.Hex(), eccm.Hex())
 	}
 
 	hash, err := cli.ECCDTransferOwnerShip(eccd, eccm)
 	if err != nil {
-		log.Error(err)
-		return
+		return fmt.Errorf("transfer eccd ownership on palette failed, err: %w", err)
 	}
 	actual, err := cli.ECCDOwnership(eccd)
 	if err != nil {
-		log.Error(err)
-		return
+		return err
 	}
-	if !bytes.Equal(eccm.Bytes(), actual.Bytes()) {
-		log.Error("new owner %s != acutal %s", eccm.Hex(), actual.Hex())
-		return
+	if actual != eccm {
+		return fmt.Errorf("eccd new owner %s != actual %s", eccm.Hex(), actual.Hex())
 	}
-	log.Infof("transfer eccd %s to eccm %s success! hash %s", eccd.Hex(), eccm.Hex(), hash.Hex())
 
-	return true
+	log.Infof("transfer eccd %s to eccm %s success! hash %s", eccd.Hex(), eccm.Hex(), hash.Hex())
+	return nil
 }
 
-func PLTTransferECCMOwnerShip() (succeed bool) {
-	cli, err := getPaletteCli()
+func PLTTransferECCMOwnerShip() error {
+	cli, err := getPaletteCli(config.RoleOwner)
 	if err != nil {
-		log.Errorf("get palette cross chain admin client failed")
-		return
+		return fmt.Errorf("get palette owner failed, err: %w", err)
 	}
 
 	eccm := config.Conf.PaletteECCM
 	ccmp := config.Conf.PaletteCCMP
 
-	cur, _ := cli.ECCMOwnership(eccm)
-	if bytes.Equal(ccmp.Bytes(), cur.Bytes()) {
-		log.Infof("eccm %s owner is %s already", eccm.Hex(), ccmp.Hex())
-		return true
+	cur, err := cli.ECCMOwnership(eccm)
+	if err != nil {
+		return fmt.Errorf("get eccm owner failed, err: %w", err)
+	}
+	if cur == ccmp {
+		return errs.AlreadyDone("transfer eccm %s to ccmp %s", eccm.Hex(), ccmp.Hex())
 	}
 
 	hash, err := cli.ECCMTransferOwnerShip(eccm, ccmp)
 	if err != nil {
-		log.Error(err)
-		return
+		return fmt.Errorf("transfer eccm ownership on palette failed, err: %w", err)
 	}
 	actual, err := cli.ECCMOwnership(eccm)
 	if err != nil {
-		log.Error(err)
-		return
+		return err
 	}
-	if !bytes.Equal(ccmp.Bytes(), actual.Bytes()) {
-		log.Error("new owner %s != acutal %s", ccmp.Hex(), actual.Hex())
-		return
+	if actual != ccmp {
+		return fmt.Errorf("eccm new owner %s != actual %s", ccmp.Hex(), actual.Hex())
 	}
-	log.Infof("transfer eccm %s to ccmp %s success! hash %s", eccm.Hex(), ccmp.Hex(), hash.Hex())
 
-	return true
+	log.Infof("transfer eccm %s to ccmp %s success! hash %s", eccm.Hex(), ccmp.Hex(), hash.Hex())
+	return nil
 }
 
-func PLTSetCCMP() (succeed bool) {
-	cli, err := getPaletteCli()
+func PLTSetCCMP() error {
+	cli, err := getPaletteCli(config.RoleOwner)
 	if err != nil {
-		log.Errorf("get palette cross chain admin client failed")
-		return
+		return fmt.Errorf("get palette owner failed, err: %w", err)
 	}
 
 	ccmp := config.Conf.PaletteCCMP
-	cur, _ := cli.GetPLTCCMP("latest")
+	cur, err := cli.GetPLTCCMP("latest")
+	if err != nil {
+		return fmt.Errorf("get PLT ccmp failed, err: %w", err)
+	}
 	if cur == ccmp {
-		log.Infof("PLT proxy already managed by %s", ccmp.Hex())
-		return true
+		return errs.AlreadyDone("set PLT ccmp %s", ccmp.Hex())
 	}
 
 	hash, err := cli.SetPLTCCMP(ccmp)
 	if err != nil {
-		log.Errorf("PLT set proxy ccmp failed, err: %v", err)
-		return
+		return fmt.Errorf("PLT set proxy ccmp failed, err: %w", err)
 	}
 
 	actual, err := cli.GetPLTCCMP("latest")
 	if err != nil {
-		log.Error(err)
-		return
+		return err
 	}
 	if actual != ccmp {
-		log.Errorf("set proxy manager failed, expect %s != actual %s", ccmp.Hex(), actual.Hex())
-		return
+		return fmt.Errorf("set proxy manager failed, expect %s != actual %s", ccmp.Hex(), actual.Hex())
 	}
 
 	log.Infof("set PLT ccmp success! hash %s", hash.Hex())
-	return true
+	return nil
 }
 
 // 在palette native合约上记录以太坊localProxy地址,
 // 这里我们将实现palette->poly->palette的循环，不走ethereum，那么proxy就直接是plt地址，
 // asset的地址也是palette plt地址
-func PLTBindPLTProxy() (succeed bool) {
-	cli, err := getPaletteCli()
+func PLTBindPLTProxy() error {
+	cli, err := getPaletteCli(config.RoleOwner)
 	if err != nil {
-		log.Errorf("get palette cross chain admin client failed")
-		return
+		return fmt.Errorf("get palette owner failed, err: %w", err)
 	}
 
 	proxy := config.Conf.EthereumPLTProxy
 	sideChainID := config.Conf.EthereumSideChainID
 
-	cur, _ := cli.GetBindPLTProxy(sideChainID, "latest")
+	cur, err := cli.GetBindPLTProxy(sideChainID, "latest")
+	if err != nil {
+		return fmt.Errorf("get bound PLT proxy failed, err: %w", err)
+	}
 	if cur == proxy {
-		log.Infof("PLT proxy already bound to by %s", proxy.Hex())
-		return true
+		return errs.AlreadyDone("bind PLT proxy to %s", proxy.Hex())
 	}
 
 	hash, err := cli.BindPLTProxy(sideChainID, proxy)
 	if err != nil {
-		log.Error(err)
-		return
+		return fmt.Errorf("bind PLT proxy on palette failed, err: %w", err)
 	}
 
 	actual, err := cli.GetBindPLTProxy(sideChainID, "latest")
 	if err != nil {
-		log.Error(err)
-		return
+		return err
 	}
 	if actual != proxy {
-		log.Errorf("bind PLT proxy failed, expect  %s != actual %s", proxy.Hex(), actual.Hex())
-		return
+		return fmt.Errorf("bind PLT proxy failed, expect %s != actual %s", proxy.Hex(), actual.Hex())
 	}
 
 	log.Infof("bind PLT proxy to %s on palette success! hash %s", proxy.Hex(), hash.Hex())
-	return true
+	return nil
 }
 
 // 在palette native合约上记录以太坊erc20资产地址
-func PLTBindPLTAsset() (succeed bool) {
-	cli, err := getPaletteCli()
+func PLTBindPLTAsset() error {
+	cli, err := getPaletteCli(config.RoleOwner)
 	if err != nil {
-		log.Errorf("get palette cross chain admin client failed")
-		return
+		return fmt.Errorf("get palette owner failed, err: %w", err)
 	}
 
 	asset := config.Conf.EthereumPLTAsset
 	sideChainID := config.Conf.EthereumSideChainID
 
-	cur, _ := cli.GetBindPLTAsset(sideChainID, "latest")
+	cur, err := cli.GetBindPLTAsset(sideChainID, "latest")
+	if err != nil {
+		return fmt.Errorf("get bound PLT asset failed, err: %w", err)
+	}
 	if cur == asset {
-		log.Infof("PLT asset already bound to by %s", asset.Hex())
-		return true
+		return errs.AlreadyDone("bind PLT asset to %s", asset.Hex())
 	}
 
 	hash, err := cli.BindPLTAsset(sideChainID, asset)
 	if err != nil {
-		log.Error(err)
-		return
+		return fmt.Errorf("bind PLT asset on palette failed, err: %w", err)
 	}
 
 	actual, err := cli.GetBindPLTAsset(sideChainID, "latest")
 	if err != nil {
-		log.Error(err)
-		return
+		return err
 	}
 	if actual != asset {
-		log.Errorf("bind PLT asset err, expect %s != actual %s", asset.Hex(), actual.Hex())
-		return
+		return fmt.Errorf("bind PLT asset err, expect %s != actual %s", asset.Hex(), actual.Hex())
 	}
 
 	log.Infof("bind PLT asset to %s on palette success! hash %s", asset.Hex(), hash.Hex())
-	return true
+	return nil
 }
 
-func PLTDeployNFTProxy() (succeed bool) {
-	cli, err := getPaletteCli()
+func PLTDeployNFTProxy() error {
+	cli, err := getPaletteCli(config.RoleDeployer)
 	if err != nil {
-		log.Errorf("get palette cross chain admin client failed")
-		return
+		return fmt.Errorf("get palette deployer failed, err: %w", err)
 	}
 
-	proxy, err := cli.DeployNFTProxy()
-	if err != nil {
-		log.Errorf("deploy NFT proxy on palette failed, err: %s", err.Error())
-		return
+	proxy := config.Conf.PaletteNFTProxy
+	if done, err := pltDeployed(cli, "nft proxy", proxy, cli.TransferNFTProxyOwnership, cli.NFTProxyOwnership); done || err != nil {
+		return err
 	}
 
-	if err := config.Conf.StorePaletteNFTProxy(proxy); err != nil {
-		log.Errorf("store palette nft proxy err: %v", err)
-		return
+	proxy, err = pltDeploy(cli, "nft proxy", func(cli *sdk.Client) (common.Address, error) {
+		return cli.DeployNFTProxy()
+	}, config.Conf.StorePaletteNFTProxy)
+	if err != nil {
+		return err
+	}
+	if err := pltHandOverToOwner(cli, "nft proxy", proxy, cli.TransferNFTProxyOwnership, cli.NFTProxyOwnership); err != nil {
+		return err
 	}
 
 	log.Infof("deploy NFT proxy %s on palette success!", proxy.Hex())
-
-	return true
+	return nil
 }
 
-func PLTBindNFTProxy() (succeed bool) {
-	cli, err := getPaletteCli()
+func PLTBindNFTProxy() error {
+	cli, err := getPaletteCli(config.RoleOwner)
 	if err != nil {
-		log.Errorf("get palette cross chain admin client failed")
-		return
+		return fmt.Errorf("get palette owner failed, err: %w", err)
 	}
 
 	localLockproxy := config.Conf.PaletteNFTProxy
 	targetLockProxy := config.Conf.EthereumNFTProxy
 	targetSideChainID := config.Conf.EthereumSideChainID
 
-	cur, _ := cli.GetBoundNFTProxy(localLockproxy, targetSideChainID)
+	cur, err := cli.GetBoundNFTProxy(localLockproxy, targetSideChainID)
+	if err != nil {
+		return fmt.Errorf("get bound NFT proxy failed, err: %w", err)
+	}
 	if cur == targetLockProxy {
-		log.Infof("NFT proxy %s already bound to by %s", localLockproxy.Hex(), targetLockProxy.Hex())
-		return true
+		return errs.AlreadyDone("bind NFT proxy %s to %s", localLockproxy.Hex(), targetLockProxy.Hex())
 	}
 
 	hash, err := cli.BindNFTProxy(localLockproxy, targetLockProxy, targetSideChainID)
 	if err != nil {
-		log.Errorf("bind NFT proxy on palette failed, err: %s", err.Error())
-		return
+		return fmt.Errorf("bind NFT proxy on palette failed, err: %w", err)
 	}
 
 	actual, err := cli.GetBoundNFTProxy(localLockproxy, targetSideChainID)
 	if err != nil {
-		log.Error(err)
-		return
+		return err
 	}
-	if !bytes.Equal(targetLockProxy.Bytes(), actual.Bytes()) {
-		log.Errorf("asset err, expect %s != actual %s", targetLockProxy.Hex(), actual.Hex())
-		return
+	if actual != targetLockProxy {
+		return fmt.Errorf("bind NFT proxy failed, expect %s != actual %s", targetLockProxy.Hex(), actual.Hex())
 	}
 
 	log.Infof("bind NFT proxy %s to %s on palette success! hash %s", localLockproxy.Hex(), targetLockProxy.Hex(), hash.Hex())
-	return true
+	return nil
 }
 
-func PLTSetNFTCCMP() (succeed bool) {
-	cli, err := getPaletteCli()
+func PLTSetNFTCCMP() error {
+	cli, err := getPaletteCli(config.RoleOwner)
 	if err != nil {
-		log.Errorf("get palette cross chain admin client failed")
-		return
+		return fmt.Errorf("get palette owner failed, err: %w", err)
 	}
 
 	proxy := config.Conf.PaletteNFTProxy
 	ccmp := config.Conf.PaletteCCMP
 
-	cur, _ := cli.GetNFTCCMP(proxy)
-	if bytes.Equal(ccmp.Bytes(), cur.Bytes()) {
-		log.Infof("NFT proxy %s already managed by %s", proxy.Hex(), ccmp.Hex())
-		return true
+	cur, err := cli.GetNFTCCMP(proxy)
+	if err != nil {
+		return fmt.Errorf("get NFT proxy ccmp failed, err: %w", err)
+	}
+	if cur == ccmp {
+		return errs.AlreadyDone("set NFT proxy %s ccmp %s", proxy.Hex(), ccmp.Hex())
 	}
 
 	hash, err := cli.SetNFTCCMP(proxy, ccmp)
 	if err != nil {
-		log.Errorf("set ccmp on palette failed, err: %s", err.Error())
-		return
+		return fmt.Errorf("set ccmp on palette failed, err: %w", err)
 	}
 
 	actual, err := cli.GetNFTCCMP(proxy)
 	if err != nil {
-		log.Error(err)
-		return
+		return err
 	}
-	if !bytes.Equal(ccmp.Bytes(), actual.Bytes()) {
-		log.Errorf("asset err, expect %s, actual %s", ccmp.Hex(), actual.Hex())
-		return
+	if actual != ccmp {
+		return fmt.Errorf("set NFT proxy ccmp failed, expect %s, actual %s", ccmp.Hex(), actual.Hex())
 	}
 	log.Infof("set NFT proxy manager %s for nft proxy %s on palette success! hash %s", actual.Hex(), proxy.Hex(), hash.Hex())
-	return true
+	return nil
 }
 
-func PLTBindNFTAsset() (succeed bool) {
-	cli, err := getPaletteCli()
+func PLTBindNFTAsset() error {
+	cli, err := getPaletteCli(config.RoleOwner)
 	if err != nil {
-		log.Errorf("get palette cross chain admin client failed")
-		return
+		return fmt.Errorf("get palette owner failed, err: %w", err)
 	}
 
 	proxy := config.Conf.PaletteNFTProxy
@@ -403,14 +409,15 @@ func PLTBindNFTAsset() (succeed bool) {
 	toAsset := config.Conf.EthereumNFTAsset
 	targetSideChainID := config.Conf.EthereumSideChainID
 
-	curAddr, _ := cli.GetBoundNFTAsset(proxy, fromAsset, targetSideChainID)
-	if curAddr != utils.EmptyAddress {
-		if curAddr == toAsset {
-			log.Infof("ethereum NFT asset %s bound already", toAsset.Hex())
-			return
-		} else {
-			log.Infof("ethereum NFT asset %s bound != asset %s", curAddr.Hex(), toAsset.Hex())
-		}
+	cur, err := cli.GetBoundNFTAsset(proxy, fromAsset, targetSideChainID)
+	if err != nil {
+		return fmt.Errorf("get bound NFT asset failed, err: %w", err)
+	}
+	if cur == toAsset {
+		return errs.AlreadyDone("bind NFT asset %s to %s", fromAsset.Hex(), toAsset.Hex())
+	}
+	if cur != utils.EmptyAddress {
+		log.Infof("ethereum NFT asset %s bound != asset %s", cur.Hex(), toAsset.Hex())
 	}
 
 	hash, err := cli.BindNFTAsset(
@@ -420,123 +427,134 @@ func PLTBindNFTAsset() (succeed bool) {
 		targetSideChainID,
 	)
 	if err != nil {
-		log.Errorf("bind NFT proxy on palette failed, err: %s", err.Error())
-		return
+		return fmt.Errorf("bind NFT asset on palette failed, err: %w", err)
 	}
 
 	actual, err := cli.GetBoundNFTAsset(proxy, fromAsset, targetSideChainID)
 	if err != nil {
-		log.Error(err)
-		return
+		return err
 	}
 	if actual != toAsset {
-		log.Errorf("asset err, expect %s, actual %s", toAsset.Hex(), actual.Hex())
-		return
+		return fmt.Errorf("bind NFT asset failed, expect %s, actual %s", toAsset.Hex(), actual.Hex())
 	}
 
 	log.Infof("bind NFT asset %s to %s on palette success, hash %s", fromAsset.Hex(), toAsset.Hex(), hash.Hex())
-	return true
+	return nil
 }
 
-func PLTDeployPLTWrap() (succeed bool) {
-	cli, err := getPaletteCli()
+func PLTDeployPLTWrap() error {
+	cli, err := getPaletteCli(config.RoleDeployer)
 	if err != nil {
-		log.Errorf("get palette cross chain admin client failed")
-		return
+		return fmt.Errorf("get palette deployer failed, err: %w", err)
+	}
+
+	wrapper := config.Conf.PalettePLTWrapper
+	if done, err := pltDeployed(cli, "plt wrap", wrapper, nil, nil); done || err != nil {
+		return err
 	}
 
 	proxy := common.HexToAddress(native.PLTContractAddress)
 	chainId := new(big.Int).SetUint64(config.Conf.PaletteSideChainID)
-
-	contractAddr, err := cli.DeployPalettePLTWrapper(cli.Address(), proxy, chainId)
+	owner, err := config.Conf.PaletteRoleAddress(config.RoleOwner)
 	if err != nil {
-		log.Errorf("deploy plt wrap on palette failed, err: %s", err.Error())
-		return
+		return fmt.Errorf("get palette owner address failed, err: %w", err)
 	}
-
-	if err := config.Conf.StorePalettePLTWrapper(contractAddr); err != nil {
-		log.Errorf("store plt wrap failed, err: %v", err)
-		return
+	wrapper, err = pltDeploy(cli, "plt wrap", func(cli *sdk.Client) (common.Address, error) {
+		return cli.DeployPalettePLTWrapper(owner, proxy, chainId)
+	}, config.Conf.StorePalettePLTWrapper)
+	if err != nil {
+		return err
 	}
 
-	log.Infof("deploy plt wrap %s on palette success!", contractAddr.Hex())
-	return true
+	log.Infof("deploy plt wrap %s on palette success!", wrapper.Hex())
+	return nil
 }
 
-func PLTDeployNFTWrap() (succeed bool) {
-	cli, err := getPaletteCli()
+func PLTDeployNFTWrap() error {
+	cli, err := getPaletteCli(config.RoleDeployer)
 	if err != nil {
-		log.Errorf("get palette cross chain admin client failed")
-		return
+		return fmt.Errorf("get palette deployer failed, err: %w", err)
+	}
+
+	wrapper := config.Conf.PaletteNFTWrapper
+	if done, err := pltDeployed(cli, "nft wrap", wrapper, nil, nil); done || err != nil {
+		return err
 	}
 
 	chainId := new(big.Int).SetUint64(config.Conf.PaletteSideChainID)
 	feeToken := common.HexToAddress(native.PLTContractAddress)
-	contractAddr, err := cli.DeployPaletteNFTWrapper(cli.Address(), feeToken, chainId)
+	owner, err := config.Conf.PaletteRoleAddress(config.RoleOwner)
 	if err != nil {
-		log.Errorf("deploy nft wrap on palette failed, err: %s", err.Error())
-		return
+		return fmt.Errorf("get palette owner address failed, err: %w", err)
 	}
-
-	if err := config.Conf.StorePaletteNFTWrapper(contractAddr); err != nil {
-		log.Errorf("store nft wrap failed, err: %v", err)
-		return
+	wrapper, err = pltDeploy(cli, "nft wrap", func(cli *sdk.Client) (common.Address, error) {
+		return cli.DeployPaletteNFTWrapper(owner, feeToken, chainId)
+	}, config.Conf.StorePaletteNFTWrapper)
+	if err != nil {
+		return err
 	}
 
-	log.Infof("deploy nft wrap %s on palette success!", contractAddr.Hex())
-	return true
+	log.Infof("deploy nft wrap %s on palette success!", wrapper.Hex())
+	return nil
 }
 
-func PLTDeployNFTQuery() (succeed bool) {
-	cli, err := getPaletteCli()
+func PLTDeployNFTQuery() error {
+	cli, err := getPaletteCli(config.RoleDeployer)
 	if err != nil {
-		log.Errorf("get palette cross chain admin client failed")
-		return
+		return fmt.Errorf("get palette deployer failed, err: %w", err)
+	}
+
+	query := config.Conf.PaletteNFTQuery
+	if done, err := pltDeployed(cli, "nft query", query, nil, nil); done || err != nil {
+		return err
 	}
 
 	var limit uint64 = 36
-	contractAddr, err := cli.DeployPaletteNFTQuery(cli.Address(), limit)
+	owner, err := config.Conf.PaletteRoleAddress(config.RoleOwner)
 	if err != nil {
-		log.Errorf("deploy nft query on palette failed, err: %s", err.Error())
-		return
+		return fmt.Errorf("get palette owner address failed, err: %w", err)
 	}
-
-	if err := config.Conf.StorePaletteNFTQuery(contractAddr); err != nil {
-		log.Errorf("store nft query failed, err: %v", err)
-		return
+	query, err = pltDeploy(cli, "nft query", func(cli *sdk.Client) (common.Address, error) {
+		return cli.DeployPaletteNFTQuery(owner, limit)
+	}, config.Conf.StorePaletteNFTQuery)
+	if err != nil {
+		return err
 	}
 
-	log.Infof("deploy nft query %s on palette success!", contractAddr.Hex())
-	return true
+	log.Infof("deploy nft query %s on palette success!", query.Hex())
+	return nil
 }
 
-func PLTNFTWrapperSetLockProxy() (succeed bool) {
-	cli, err := getPaletteCli()
+func PLTNFTWrapperSetLockProxy() error {
+	cli, err := getPaletteCli(config.RoleOwner)
 	if err != nil {
-		log.Errorf("get palette cross chain admin client failed")
-		return
+		return fmt.Errorf("get palette owner failed, err: %w", err)
 	}
 
 	wrapAddr := config.Conf.PaletteNFTWrapper
 	targetLockProxy := config.Conf.PaletteNFTProxy
 
-	cur, _ := cli.GetPaletteNFTWrapLockProxy(wrapAddr)
+	cur, err := cli.GetPaletteNFTWrapLockProxy(wrapAddr)
+	if err != nil {
+		return fmt.Errorf("get nft wrapper proxy failed, err: %w", err)
+	}
 	if cur == targetLockProxy {
-		log.Infof("nft wrapper proxy %s already settled", targetLockProxy.Hex())
-		return true
+		return errs.AlreadyDone("set nft wrapper %s proxy %s", wrapAddr.Hex(), targetLockProxy.Hex())
 	}
 
-	if _, err := cli.PaletteNFTWrapSetLockProxy(wrapAddr, targetLockProxy); err != nil {
-		log.Errorf("nft wrapper set lock proxy failed, err: %v", err)
-		return false
+	hash, err := cli.PaletteNFTWrapSetLockProxy(wrapAddr, targetLockProxy)
+	if err != nil {
+		return fmt.Errorf("nft wrapper set lock proxy failed, err: %w", err)
 	}
 
-	got, _ := cli.GetPaletteNFTWrapLockProxy(wrapAddr)
-	if got != targetLockProxy {
-		log.Infof("nft wrapper proxy set failed, expect %s, got %s", targetLockProxy.Hex(), got.Hex())
-		return true
+	actual, err := cli.GetPaletteNFTWrapLockProxy(wrapAddr)
+	if err != nil {
+		return err
+	}
+	if actual != targetLockProxy {
+		return fmt.Errorf("nft wrapper proxy set failed, expect %s, got %s", targetLockProxy.Hex(), actual.Hex())
 	}
 
-	log.Infof("nft wrap set lock proxy %s on palette success!", targetLockProxy.Hex())
-	return true
+	log.Infof("nft wrap set lock proxy %s on palette success! hash %s", targetLockProxy.Hex(), hash.Hex())
+	return nil
 }
@@ -19,57 +19,51 @@
 package core
 
 import (
+	"fmt"
+
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/palettechain/deploy-tool/config"
+	"github.com/palettechain/deploy-tool/pkg/errs"
+	"github.com/palettechain/deploy-tool/pkg/frame"
 	"github.com/palettechain/deploy-tool/pkg/log"
 	"github.com/palettechain/deploy-tool/pkg/poly"
 	"github.com/palettechain/deploy-tool/pkg/sdk"
 	polyutils "github.com/polynetwork/poly/native/service/utils"
 )
 
-func PLTRegisterSideChain() (succeed bool) {
-	polyRPC := config.Conf.PolyRPCUrl
-	polyValidators := config.Conf.LoadPolyAccountList()
-	polyCli, err := poly.NewPolyClient(polyRPC, polyValidators)
+func PLTRegisterSideChain() error {
+	polyCli, err := getPolyCli()
 	if err != nil {
-		log.Errorf("failed to generate poly client, err: %s", err)
-		return
-	} else {
-		log.Infof("generate poly client success!")
+		return fmt.Errorf("failed to generate poly client, err: %w", err)
 	}
+	log.Infof("generate poly client success!")
 
 	crossChainID := config.Conf.PaletteSideChainID
 	eccd := config.Conf.PaletteECCD
 	router := polyutils.QUORUM_ROUTER
 	name := config.Conf.PaletteSideChainName
 	if err := polyCli.RegisterSideChain(crossChainID, eccd, router, name); err != nil {
-		log.Errorf("failed to register side chain, err: %s", err)
-		return
+		return fmt.Errorf("failed to register side chain, err: %w", err)
 	}
 
 	log.Infof("register side chain %d eccd %s success", crossChainID, eccd.Hex())
-	return true
+	return nil
 }
 
-func PLTApproveRegisterSideChain() (succeed bool) {
-	polyRPC := config.Conf.PolyRPCUrl
-	polyValidators := config.Conf.LoadPolyAccountList()
-	polyCli, err := poly.NewPolyClient(polyRPC, polyValidators)
+func PLTApproveRegisterSideChain() error {
+	polyCli, err := getPolyCli()
 	if err != nil {
-		log.Errorf("failed to generate poly client, err: %s", err)
-		return
-	} else {
-		log.Infof("generate poly client success!")
+		return fmt.Errorf("failed to generate poly client, err: %w", err)
 	}
+	log.Infof("generate poly client success!")
 
 	crossChainID := config.Conf.PaletteSideChainID
 	if err := polyCli.ApproveRegisterSideChain(crossChainID); err != nil {
-		log.Errorf("failed to approve register side chain, err: %s", err)
-		return
+		return fmt.Errorf("failed to approve register side chain, err: %w", err)
 	}
 
 	log.Infof("approve register side chain %d success", crossChainID)
-	return true
+	return nil
 }
 
 // 同步palette区块头到poly链上
@@ -79,91 +73,93 @@ func PLTApproveRegisterSideChain() (succeed bool) {
 //	  这笔交易发出后等待poly当前块高超过交易块高, 作为落账的判断条件
 // 4. 获取poly当前块高作为写入palette管理合约的genesis块高，获取对应的block，将block header及block book keeper
 //    序列化，提交到palette管理合约
-func PLTSyncPLTGenesis() (succeed bool) {
+func PLTSyncPLTGenesis() error {
 	// 1. prepare
-	polyRPC := config.Conf.PolyRPCUrl
-	polyValidators := config.Conf.LoadPolyAccountList()
-	polyCli, err := poly.NewPolyClient(polyRPC, polyValidators)
+	polyCli, err := getPolyCli()
 	if err != nil {
-		log.Errorf("failed to generate poly client, err: %s", err)
-		return
-	} else {
-		log.Infof("generate poly client success!")
+		return fmt.Errorf("failed to generate poly client, err: %w", err)
 	}
+	log.Infof("generate poly client success!")
 
 	// 2. get palette current block header
 	logsplit()
-	cli := sdk.NewSender(config.Conf.PaletteRPCUrl, nil)
-	//cli, err := getPaletteCli()
-	//if err != nil {
-	//	log.Errorf("get palette cross chain admin client failed")
-	//	return
-	//}
+	cli, err := sdk.NewSenderPool(config.PalettePool, nil)
+	if err != nil {
+		return fmt.Errorf("failed to dial palette node, err: %w", err)
+	}
+	cli = cli.WithContext(frame.Tool.Context())
 	curr, hdr, err := cli.GetCurrentBlockHeader()
 	if err != nil {
-		log.Errorf("failed to get block header, err: %s", err)
-		return
+		return fmt.Errorf("failed to get block header, err: %w", err)
 	}
 	pltHeaderEnc, err := hdr.MarshalJSON()
 	if err != nil {
-		log.Errorf("marshal header failed, err: %s", err)
-		return
+		return fmt.Errorf("marshal header failed, err: %w", err)
 	}
 	log.Infof("get palette block header with current height %d, header %s", curr, hexutil.Encode(pltHeaderEnc))
 
 	logsplit()
 	crossChainID := config.Conf.PaletteSideChainID
 	if err := polyCli.SyncGenesisBlock(crossChainID, pltHeaderEnc); err != nil {
-		log.Errorf("SyncEthGenesisHeader failed: %v", err)
-		return
+		return fmt.Errorf("SyncEthGenesisHeader failed, err: %w", err)
 	}
 	log.Infof("sync palette genesis header to poly success, txhash %s, block number %d",
 		hdr.Hash().Hex(), hdr.Number.Uint64())
 
-	return true
+	return nil
 }
 
-// 同步poly区块头到palette
-func PLTSyncPolyGenesis() (succeed bool) {
-	polyRPC := config.Conf.PolyRPCUrl
-	polyCli, err := poly.NewPolyClient(polyRPC, nil)
+// 同步poly区块头到palette, it is skipped if the eccd has recorded the poly consensus public keys already.
+func PLTSyncPolyGenesis() error {
+	cli, err := getPaletteCli(config.RoleOperator)
 	if err != nil {
-		log.Errorf("failed to generate poly client, err: %s", err)
-		return
-	} else {
-		log.Infof("generate poly client success!")
+		return fmt.Errorf("get palette operator failed, err: %w", err)
 	}
+	eccd := config.Conf.PaletteECCD
+	eccm := config.Conf.PaletteECCM
 
-	// `epoch` related with the poly validators changing,
-	// we can set it as 0 if poly validators never changed on develop environment.
-	var hasValidatorsBlockNumber uint32 = 0
-	gB, err := polyCli.GetBlockByHeight(hasValidatorsBlockNumber)
+	keys, err := cli.ECCDCurEpochPubKeys(eccd)
 	if err != nil {
-		log.Errorf("failed to get block, err: %s", err)
-		return
+		return fmt.Errorf("get eccd current epoch public keys failed, err: %w", err)
 	}
-	bookeepers, err := poly.GetBookeeper(gB)
-	if err != nil {
-		log.Errorf("failed to get bookeepers, err: %s", err)
-		return
+	if len(keys) > 0 {
+		return errs.AlreadyDone("sync poly genesis header to palette eccm %s", eccm.Hex())
 	}
-	bookeepersEnc := poly.AssembleNoCompressBookeeper(bookeepers)
-	headerEnc := gB.Header.ToArray()
 
-	cli, err := getPaletteCli()
+	headerEnc, bookeepersEnc, height, err := polyGenesis()
 	if err != nil {
-		log.Errorf("get palette cross chain admin client failed")
-		return
+		return err
 	}
-	eccm := config.Conf.PaletteECCM
 	txhash, err := cli.InitGenesisBlock(eccm, headerEnc, bookeepersEnc)
 	if err != nil {
-		log.Errorf("failed to initGenesisBlock, err: %s", err)
-		return
+		return fmt.Errorf("failed to initGenesisBlock, err: %w", err)
 	}
 
 	log.Infof("sync poly genesis header to palette success, txhash %s, block number %d",
-		txhash.Hex(), gB.Header.Height)
+		txhash.Hex(), height)
+
+	return nil
+}
+
+// polyGenesis returns the poly header and bookeepers used to init the eccm genesis block of side chains.
+func polyGenesis() (headerEnc, bookeepersEnc []byte, height uint32, err error) {
+	polyCli, err := poly.NewPolyClientPool(config.PolyPool, nil)
+	if err != nil {
+		return nil, nil, 0, fmt.Errorf("failed to generate poly client, err: %w", err)
+	}
+	log.Infof("generate poly client success!")
+	polyCli = polyCli.WithContext(frame.Tool.Context())
 
-	return true
+	// `epoch` related with the poly validators changing,
+	// we can set it as 0 if poly validators never changed on develop environment.
+	var hasValidatorsBlockNumber uint32 = 0
+	gB, err := polyCli.GetBlockByHeight(hasValidatorsBlockNumber)
+	if err != nil {
+		return nil, nil, 0, fmt.Errorf("failed to get block, err: %w", err)
+	}
+	bookeepers, err := poly.GetBookeeper(gB)
+	if err != nil {
+		return nil, nil, 0, fmt.Errorf("failed to get bookeepers, err: %w", err)
+	}
+	return gB.Header.ToArray(), poly.AssembleNoCompressBookeeper(bookeepers), gB.Header.Height, nil
 }
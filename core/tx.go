@@ -0,0 +1,91 @@
+package core
+
+import (
+	"flag"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/palettechain/deploy-tool/config"
+	"github.com/palettechain/deploy-tool/pkg/log"
+)
+
+type replaceFn func(hash common.Hash) (common.Hash, error)
+
+// TxSpeedUp replace the stuck tx with the same nonce and bumped fees, e.g:
+// `deploy-tool -config=config.json tx speedup --chain ethereum --role deployer 0x...`
+func TxSpeedUp(args []string) (succeed bool) {
+	hash, replace, err := parseReplaceArgs("tx speedup", args, false)
+	if err != nil {
+		log.Errorf("%v", err)
+		return
+	}
+	mined, err := replace(hash)
+	if err != nil {
+		log.Errorf("speed up tx %s failed, err: %v", hash.Hex(), err)
+		return
+	}
+	log.Infof("tx %s sped up, mined tx %s", hash.Hex(), mined.Hex())
+	return true
+}
+
+// TxCancel replace the stuck tx with a zero value self transfer at the same nonce, e.g:
+// `deploy-tool -config=config.json tx cancel --chain palette --role owner 0x...`
+func TxCancel(args []string) (succeed bool) {
+	hash, replace, err := parseReplaceArgs("tx cancel", args, true)
+	if err != nil {
+		log.Errorf("%v", err)
+		return
+	}
+	mined, err := replace(hash)
+	if err != nil {
+		log.Errorf("cancel tx %s failed, err: %v", hash.Hex(), err)
+		return
+	}
+	if mined == hash {
+		log.Errorf("tx %s mined before canceled", hash.Hex())
+		return
+	}
+	log.Infof("tx %s canceled by self transfer %s", hash.Hex(), mined.Hex())
+	return true
+}
+
+// parseReplaceArgs returns the tx hash and the replacement of the chain client signed by the role key,
+// which should be the sender of tx.
+func parseReplaceArgs(name string, args []string, cancel bool) (common.Hash, replaceFn, error) {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	chain := fs.String("chain", "ethereum", "chain of the tx, ethereum or palette")
+	role := fs.String("role", string(config.RoleDeployer), "role which sent the tx")
+	if err := fs.Parse(args); err != nil {
+		return common.Hash{}, nil, err
+	}
+	if fs.NArg() != 1 {
+		return common.Hash{}, nil, fmt.Errorf("usage: %s [--chain ethereum|palette] [--role deployer] <hash>", name)
+	}
+	raw := fs.Arg(0)
+	if len(common.FromHex(raw)) != common.HashLength {
+		return common.Hash{}, nil, fmt.Errorf("invalid tx hash %s", raw)
+	}
+	hash := common.HexToHash(raw)
+
+	switch *chain {
+	case "ethereum":
+		cli, err := getEthereumCli(config.Role(*role))
+		if err != nil {
+			return hash, nil, fmt.Errorf("get eth %s failed, err: %v", *role, err)
+		}
+		if cancel {
+			return hash, cli.Cancel, nil
+		}
+		return hash, cli.SpeedUp, nil
+	case "palette":
+		cli, err := getPaletteCli(config.Role(*role))
+		if err != nil {
+			return hash, nil, fmt.Errorf("get palette %s failed, err: %v", *role, err)
+		}
+		if cancel {
+			return hash, cli.Cancel, nil
+		}
+		return hash, cli.SpeedUp, nil
+	}
+	return hash, nil, fmt.Errorf("unknown chain %s", *chain)
+}
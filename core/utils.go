@@ -1,31 +1,308 @@
 package core
 
 import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/contracts/native/utils"
 	"github.com/palettechain/deploy-tool/config"
+	"github.com/palettechain/deploy-tool/pkg/dao"
+	"github.com/palettechain/deploy-tool/pkg/errs"
 	"github.com/palettechain/deploy-tool/pkg/eth"
+	"github.com/palettechain/deploy-tool/pkg/frame"
 	"github.com/palettechain/deploy-tool/pkg/log"
+	"github.com/palettechain/deploy-tool/pkg/poly"
 	"github.com/palettechain/deploy-tool/pkg/sdk"
 )
 
-func getPaletteCli() (*sdk.Client, error) {
-	url := config.Conf.PaletteRPCUrl
-	privateKey, err := config.Conf.LoadPLTAdminAccount()
+func getPaletteCli(role config.Role) (*sdk.Client, error) {
+	privateKey, err := config.Conf.LoadPLTAccount(role)
+	if err != nil {
+		return nil, err
+	}
+	cli, err := sdk.NewSenderPool(config.PalettePool, privateKey)
+	if err != nil {
+		return nil, err
+	}
+	log.Infof("palette %s %s", role, cli.Address().Hex())
+	return cli.WithContext(frame.Tool.Context()), nil
+}
+
+// getPolyCli load all poly validators as the poly approver, and fails if the quorum can not be reached.
+func getPolyCli() (*poly.PolyClient, error) {
+	validators, quorum, err := config.Conf.LoadPolyValidators()
+	if err != nil {
+		return nil, err
+	}
+	cli, err := poly.NewPolyClientPool(config.PolyPool, validators)
 	if err != nil {
 		return nil, err
 	}
-	return sdk.NewSender(url, privateKey), nil
+	log.Infof("poly %s quorum %d/%d", config.RolePolyApprover, quorum, len(validators))
+	return cli.SetQuorum(quorum).WithContext(frame.Tool.Context()), nil
 }
 
-func getEthereumCli() (*eth.EthInvoker, error) {
-	url := config.Conf.EthereumRPCUrl
-	privateKey, err := config.Conf.LoadETHAdminAccount()
+func getEthereumCli(role config.Role) (*eth.EthInvoker, error) {
+	privateKey, err := config.Conf.LoadETHAccount(role)
 	if err != nil {
 		return nil, err
 	}
 
-	return eth.NewEInvoker(url, privateKey), nil
+	cli, err := eth.NewEInvokerPool(config.EthereumPool, privateKey)
+	if err != nil {
+		return nil, err
+	}
+	log.Infof("ethereum %s %s", role, cli.Address().Hex())
+	return cli.WithContext(frame.Tool.Context()), nil
+}
+
+type transferOwnershipFn func(contract, newOwner common.Address) (common.Hash, error)
+type ownershipFn func(contract common.Address) (common.Address, error)
+
+// handOverToOwner transfer the contract which deployed by the deployer key to the owner role,
+// so that the following owner actions are signed by the owner key.
+func handOverToOwner(
+	name string,
+	deployer, owner common.Address,
+	contract common.Address,
+	transfer transferOwnershipFn,
+	ownership ownershipFn,
+) error {
+
+	if deployer == owner {
+		return nil
+	}
+
+	hash, err := transfer(contract, owner)
+	if err != nil {
+		return fmt.Errorf("transfer %s %s ownership to owner %s failed, err: %w", name, contract.Hex(), owner.Hex(), err)
+	}
+	actual, err := ownership(contract)
+	if err != nil {
+		return err
+	}
+	if actual != owner {
+		return fmt.Errorf("%s new owner %s != actual %s", name, owner.Hex(), actual.Hex())
+	}
+
+	log.Infof("hand over %s %s from deployer %s to owner %s, hash %s", name, contract.Hex(), deployer.Hex(), owner.Hex(), hash.Hex())
+	return nil
+}
+
+// pltHandOverToOwner hand over the palette contract deployed by deployer client to the owner role, it is
+// skipped if the contract is not owned by the deployer any more, e.g: handed over or transferred to eccm.
+func pltHandOverToOwner(cli *sdk.Client, name string, contract common.Address, transfer transferOwnershipFn, ownership ownershipFn) error {
+	owner, err := config.Conf.PaletteRoleAddress(config.RoleOwner)
+	if err != nil {
+		return err
+	}
+	cur, err := ownership(contract)
+	if err != nil {
+		return err
+	}
+	if cur != cli.Address() {
+		return nil
+	}
+	return handOverToOwner(name, cli.Address(), owner, contract, transfer, ownership)
+}
+
+// pltDeployed returns true if the contract in config has been deployed on palette, and hand over it
+// to the owner role if the last deployment is interrupted before that. the hand over is skipped if
+// transfer is nil, e.g: the owner is set in constructor.
+func pltDeployed(cli *sdk.Client, name string, contract common.Address, transfer transferOwnershipFn, ownership ownershipFn) (bool, error) {
+	if contract == utils.EmptyAddress {
+		return false, nil
+	}
+	deployed, err := cli.HasCode(contract)
+	if err != nil || !deployed {
+		return false, err
+	}
+	if transfer != nil {
+		if err := pltHandOverToOwner(cli, name, contract, transfer, ownership); err != nil {
+			return true, err
+		}
+	}
+	return true, errs.AlreadyDone("deploy %s %s on palette", name, contract.Hex())
+}
+
+type pltDeployFn func(cli *sdk.Client) (common.Address, error)
+
+// pltDeploy deploy the contract on palette and store it in config, see `resumableDeploy`.
+func pltDeploy(cli *sdk.Client, name string, deploy pltDeployFn, store storeAddressFn) (common.Address, error) {
+	return resumableDeploy("palette", name, cli.Address(), cli.ResumeDeploy, func(sent eth.DeploySentFn) (common.Address, error) {
+		return deploy(cli.WithDeploySent(sent))
+	}, store)
 }
 
 func logsplit() {
 	log.Info("------------------------------------------------------------------")
 }
+
+// ethHandOverToOwner hand over the ethereum contract deployed by deployer client to the owner role, it is
+// skipped if the contract is not owned by the deployer any more, e.g: handed over or transferred to eccm.
+func ethHandOverToOwner(cli *eth.EthInvoker, name string, contract common.Address, transfer transferOwnershipFn, ownership ownershipFn) error {
+	owner, err := config.Conf.EthereumRoleAddress(config.RoleOwner)
+	if err != nil {
+		return err
+	}
+	cur, err := ownership(contract)
+	if err != nil {
+		return err
+	}
+	if cur != cli.Address() {
+		return nil
+	}
+	return handOverToOwner(name, cli.Address(), owner, contract, transfer, ownership)
+}
+
+// ethDeployed returns true if the contract in config has been deployed on ethereum, and hand over it
+// to the owner role if the last deployment is interrupted before that.
+func ethDeployed(cli *eth.EthInvoker, name string, contract common.Address, transfer transferOwnershipFn, ownership ownershipFn) (bool, error) {
+	if contract == utils.EmptyAddress {
+		return false, nil
+	}
+	deployed, err := cli.HasCode(contract)
+	if err != nil || !deployed {
+		return false, err
+	}
+	if err := ethHandOverToOwner(cli, name, contract, transfer, ownership); err != nil {
+		return true, err
+	}
+	return true, errs.AlreadyDone("deploy %s %s on ethereum", name, contract.Hex())
+}
+
+// requireAddresses returns error with the sorted config names of empty addresses, which should be
+// deployed by the previous steps.
+func requireAddresses(addrs map[string]common.Address) error {
+	empty := make([]string, 0, len(addrs))
+	for name, addr := range addrs {
+		if addr == utils.EmptyAddress {
+			empty = append(empty, name)
+		}
+	}
+	if len(empty) == 0 {
+		return nil
+	}
+	sort.Strings(empty)
+	return fmt.Errorf("%s not configured", strings.Join(empty, ", "))
+}
+
+type ethDeployFn func(cli *eth.EthInvoker) (common.Address, error)
+type storeAddressFn func(addr common.Address) error
+
+// ethDeploy deploy the contract on ethereum and store it in config, see `resumableDeploy`.
+func ethDeploy(cli *eth.EthInvoker, name string, deploy ethDeployFn, store storeAddressFn) (common.Address, error) {
+	return resumableDeploy("ethereum", name, cli.Address(), cli.ResumeDeploy, func(sent eth.DeploySentFn) (common.Address, error) {
+		return deploy(cli.WithDeploySent(sent))
+	}, store)
+}
+
+type resumeDeployFn func(addr common.Address, hash common.Hash) (common.Address, error)
+
+// resumableDeploy deploy the contract and store it in config. the deployment tx is recorded in leveldb
+// before it is waited, so that the retried step waits the recorded tx instead of deploying again. the
+// record is dropped once the contract stored, or the recorded tx reverted or dropped.
+func resumableDeploy(
+	chain, name string,
+	deployer common.Address,
+	resume resumeDeployFn,
+	deploy func(sent eth.DeploySentFn) (common.Address, error),
+	store storeAddressFn,
+) (common.Address, error) {
+
+	pending := dao.NewPendingDeploy(fmt.Sprintf("%s-%s-%s", chain, name, deployer.Hex()))
+	hash, addr, ok, err := pending.Load()
+	if err != nil {
+		return utils.EmptyAddress, fmt.Errorf("load pending %s deployment failed, err: %w", name, err)
+	}
+	if ok {
+		log.Infof("resume %s deployment %s of %s on %s", name, hash.Hex(), addr.Hex(), chain)
+		addr, err = resume(addr, hash)
+	} else {
+		addr, err = deploy(func(addr common.Address, hash common.Hash) error {
+			return pending.Save(hash, addr)
+		})
+	}
+	if err != nil {
+		if errs.IsReverted(err) || errs.IsDropped(err) {
+			if e := pending.Clear(); e != nil {
+				log.Warnf("clear pending %s deployment failed, err: %v", name, e)
+			}
+		}
+		return utils.EmptyAddress, fmt.Errorf("deploy %s on %s failed, err: %w", name, chain, err)
+	}
+	if err := store(addr); err != nil {
+		return utils.EmptyAddress, fmt.Errorf("store %s %s failed, err: %w", chain, name, err)
+	}
+	if err := pending.Clear(); err != nil {
+		return utils.EmptyAddress, fmt.Errorf("clear pending %s deployment failed, err: %w", name, err)
+	}
+	return addr, nil
+}
+
+// pltAssetTwoStep returns true if the ownership of PLT asset is transferred in two steps, which means
+// the new owner is pending until it accepts the ownership.
+func pltAssetTwoStep(cli *eth.EthInvoker, asset common.Address) (bool, error) {
+	_, err := cli.PLTAssetPendingOwner(asset)
+	if err != nil && errs.Retryable(err) {
+		return false, err
+	}
+	return err == nil, nil
+}
+
+// ethHandOverPLTAsset hand over the PLT asset deployed by deployer client to the owner role, and the owner
+// role accepts the ownership if the asset transfers ownership in two steps.
+func ethHandOverPLTAsset(cli *eth.EthInvoker, asset common.Address) error {
+	owner, err := config.Conf.EthereumRoleAddress(config.RoleOwner)
+	if err != nil {
+		return err
+	}
+	cur, err := cli.PLTAssetOwnership(asset)
+	if err != nil {
+		return err
+	}
+	if cur != cli.Address() || cur == owner {
+		return nil
+	}
+	twoStep, err := pltAssetTwoStep(cli, asset)
+	if err != nil {
+		return err
+	}
+
+	pending := utils.EmptyAddress
+	if twoStep {
+		if pending, err = cli.PLTAssetPendingOwner(asset); err != nil {
+			return err
+		}
+	}
+	if pending != owner {
+		hash, err := cli.TransferPLTAssetOwnership(asset, owner)
+		if err != nil {
+			return fmt.Errorf("transfer PLT asset %s ownership to owner %s failed, err: %w", asset.Hex(), owner.Hex(), err)
+		}
+		log.Infof("transfer PLT asset %s ownership to owner %s, hash %s", asset.Hex(), owner.Hex(), hash.Hex())
+	}
+	if twoStep {
+		ownerCli, err := getEthereumCli(config.RoleOwner)
+		if err != nil {
+			return fmt.Errorf("get eth owner failed, err: %w", err)
+		}
+		hash, err := ownerCli.AcceptPLTAssetOwnership(asset)
+		if err != nil {
+			return fmt.Errorf("accept PLT asset %s ownership by owner %s failed, err: %w", asset.Hex(), owner.Hex(), err)
+		}
+		log.Infof("accept PLT asset %s ownership by owner %s, hash %s", asset.Hex(), owner.Hex(), hash.Hex())
+	}
+
+	actual, err := cli.PLTAssetOwnership(asset)
+	if err != nil {
+		return err
+	}
+	if actual != owner {
+		return fmt.Errorf("PLT asset new owner %s != actual %s", owner.Hex(), actual.Hex())
+	}
+	log.Infof("hand over PLT asset %s from deployer %s to owner %s", asset.Hex(), cli.Address().Hex(), owner.Hex())
+	return nil
+}
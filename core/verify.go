@@ -0,0 +1,105 @@
+package core
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/palettechain/deploy-tool/config"
+	"github.com/palettechain/deploy-tool/pkg/bytecode"
+	"github.com/palettechain/deploy-tool/pkg/errs"
+	"github.com/palettechain/deploy-tool/pkg/eth"
+	"github.com/palettechain/deploy-tool/pkg/files"
+	"github.com/palettechain/deploy-tool/pkg/frame"
+	"github.com/palettechain/deploy-tool/pkg/log"
+	"github.com/palettechain/deploy-tool/pkg/rpcpool"
+)
+
+// deployedContract is the contract address in config and the name of its expected artifact.
+type deployedContract struct {
+	name     string
+	address  common.Address
+	artifact string
+}
+
+// VerifyBytecode compare the runtime code at every configured contract address on palette and ethereum
+// with the runtime bytecode in the bundled go abi packages, the metadata hashes are ignored. it fails with
+// the list of mismatches, so that the contract swapped behind a config entry is found.
+func VerifyBytecode() error {
+	conf := config.Conf
+	palette := []*deployedContract{
+		{"PaletteECCD", conf.PaletteECCD, "ECCD"},
+		{"PaletteECCM", conf.PaletteECCM, "ECCM"},
+		{"PaletteCCMP", conf.PaletteCCMP, "CCMP"},
+		{"PaletteNFTProxy", conf.PaletteNFTProxy, "NFTLockProxy"},
+		{"PalettePLTWrapper", conf.PalettePLTWrapper, "PLTWrapper"},
+		{"PaletteNFTWrapper", conf.PaletteNFTWrapper, "NFTWrapper"},
+		{"PaletteNFTQuery", conf.PaletteNFTQuery, "NFTQuery"},
+	}
+	ethereum := []*deployedContract{
+		{"EthereumECCD", conf.EthereumECCD, "ECCD"},
+		{"EthereumECCM", conf.EthereumECCM, "ECCM"},
+		{"EthereumCCMP", conf.EthereumCCMP, "CCMP"},
+		{"EthereumPLTProxy", conf.EthereumPLTProxy, "LockProxy"},
+		{"EthereumNFTProxy", conf.EthereumNFTProxy, "NFTLockProxy"},
+	}
+
+	mismatches := make([]string, 0)
+	verify := func(pool *rpcpool.Pool, contracts []*deployedContract, extra map[string][]byte) error {
+		tools, err := eth.NewEthToolsPool(pool)
+		if err != nil {
+			return err
+		}
+		for _, c := range contracts {
+			if c.address == (common.Address{}) {
+				log.Infof("%s is not configured, skip", c.name)
+				continue
+			}
+			expected, ok := extra[c.artifact]
+			if !ok {
+				if expected, ok = bytecode.Artifact(c.artifact); !ok {
+					return fmt.Errorf("unknown artifact %s of %s", c.artifact, c.name)
+				}
+			}
+			code, err := tools.GetEthClient().CodeAt(frame.Tool.Context(), c.address, nil)
+			if err != nil {
+				return errs.RPC("eth_getCode", err)
+			}
+			if err := bytecode.Verify(code, expected); err != nil {
+				log.Errorf("%s %s mismatch %s, err: %v", c.name, c.address.Hex(), c.artifact, err)
+				mismatches = append(mismatches, c.name)
+				continue
+			}
+			log.Infof("%s %s matches %s", c.name, c.address.Hex(), c.artifact)
+		}
+		return nil
+	}
+
+	if err := verify(config.PalettePool, palette, nil); err != nil {
+		return fmt.Errorf("verify palette contracts failed, err: %w", err)
+	}
+
+	// the PLT asset is verified with the artifact it deployed from
+	extra := make(map[string][]byte)
+	if conf.EthereumPLTAssetBin != "" {
+		artifact, err := files.ReadFile(conf.EthereumPLTAssetBin)
+		if err != nil {
+			return err
+		}
+		bin, err := eth.ParseContractBin(artifact)
+		if err != nil {
+			return fmt.Errorf("read PLT asset artifact %s failed, err: %v", conf.EthereumPLTAssetBin, err)
+		}
+		extra["PLTAsset"] = bin
+		ethereum = append(ethereum, &deployedContract{"EthereumPLTAsset", conf.EthereumPLTAsset, "PLTAsset"})
+	}
+	if err := verify(config.EthereumPool, ethereum, extra); err != nil {
+		return fmt.Errorf("verify ethereum contracts failed, err: %w", err)
+	}
+
+	if len(mismatches) > 0 {
+		return fmt.Errorf("%d contracts mismatch the expected bytecode: %s", len(mismatches), strings.Join(mismatches, ", "))
+	}
+	log.Infof("all configured contracts match the expected bytecode")
+	return nil
+}
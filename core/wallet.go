@@ -0,0 +1,139 @@
+package core
+
+import (
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/howeyc/gopass"
+	"github.com/palettechain/deploy-tool/config"
+	"github.com/palettechain/deploy-tool/pkg/hdwallet"
+	"github.com/palettechain/deploy-tool/pkg/log"
+)
+
+// WalletDerive derive accounts from operator supplied mnemonic, print the addresses and write
+// go-ethereum keystore files into the keystore directory if it is not empty, e.g:
+// `deploy-tool -config=config.json wallet derive --from 0 --count 10 --keystore ./keystore`
+func WalletDerive(args []string) (succeed bool) {
+	fs := flag.NewFlagSet("wallet derive", flag.ContinueOnError)
+	from := fs.Int("from", 0, "index of the first derived account")
+	count := fs.Int("count", 1, "number of derived accounts")
+	template := fs.String("template", "", "derivation path template with one `%d`, default is config DerivationTemplate or "+hdwallet.DefaultDerivationTemplate)
+	keydir := fs.String("keystore", "", "directory to write go-ethereum keystore files, addresses only printed if empty")
+	if err := fs.Parse(args); err != nil {
+		return
+	}
+	if *from < 0 || *count <= 0 {
+		log.Errorf("invalid range, from %d count %d", *from, *count)
+		return
+	}
+
+	driver, err := config.Conf.LoadHDDriver(*template)
+	if err != nil {
+		log.Errorf("load hd wallet failed, err: %v", err)
+		return
+	}
+
+	var (
+		ks  *keystore.KeyStore
+		pwd string
+	)
+	if *keydir != "" {
+		if pwd, err = config.ReadNewPassword("derived keystore files"); err != nil {
+			log.Errorf("read keystore password failed, err: %v", err)
+			return
+		}
+		ks = keystore.NewKeyStore(*keydir, keystore.StandardScryptN, keystore.StandardScryptP)
+	}
+
+	log.Infof("derive accounts with template %s", driver.Template())
+	for i := *from; i < *from+*count; i++ {
+		acc, err := driver.Drive(i)
+		if err != nil {
+			log.Errorf("derive account %d failed, err: %v", i, err)
+			return
+		}
+		if ks == nil {
+			log.Infof("%d\t%s\t%s", i, acc.URL.Path, acc.Address.Hex())
+			continue
+		}
+
+		key, err := driver.PrivateKey(acc)
+		if err != nil {
+			log.Errorf("get private key of account %d failed, err: %v", i, err)
+			return
+		}
+		if ks.HasAddress(acc.Address) {
+			log.Infof("%d\t%s\t%s, keystore already exist", i, acc.URL.Path, acc.Address.Hex())
+			continue
+		}
+		stored, err := ks.ImportECDSA(key, pwd)
+		if err != nil {
+			log.Errorf("write keystore of account %d failed, err: %v", i, err)
+			return
+		}
+		log.Infof("%d\t%s\t%s\t%s", i, acc.URL.Path, acc.Address.Hex(), stored.URL.Path)
+	}
+
+	return true
+}
+
+// WalletEncryptMnemonic encrypt mnemonic read from terminal and save it into the mnemonic file,
+// which can be used as config `MnemonicFile`. the `--new` flag generate a new mnemonic instead.
+func WalletEncryptMnemonic(args []string) (succeed bool) {
+	fs := flag.NewFlagSet("wallet encrypt-mnemonic", flag.ContinueOnError)
+	out := fs.String("out", config.Conf.MnemonicFile, "encrypted mnemonic file path")
+	generate := fs.Bool("new", false, "generate a new mnemonic instead of reading from terminal")
+	if err := fs.Parse(args); err != nil {
+		return
+	}
+	if *out == "" {
+		log.Errorf("mnemonic file path is empty")
+		return
+	}
+	if _, err := os.Stat(*out); err == nil {
+		log.Errorf("mnemonic file %s already exist", *out)
+		return
+	}
+
+	var (
+		mnemonic string
+		err      error
+	)
+	if *generate {
+		if mnemonic, err = hdwallet.NewMnemonic(128); err != nil {
+			log.Errorf("generate mnemonic failed, err: %v", err)
+			return
+		}
+		// the new mnemonic only displayed once, the operator should write it down for backup.
+		fmt.Println(mnemonic)
+	} else {
+		log.Infof("please input mnemonic")
+		bz, err := gopass.GetPasswd()
+		if err != nil {
+			log.Errorf("read mnemonic failed, err: %v", err)
+			return
+		}
+		mnemonic = string(bz)
+	}
+
+	pwd, err := config.ReadNewPassword("mnemonic file")
+	if err != nil {
+		log.Errorf("read mnemonic password failed, err: %v", err)
+		return
+	}
+	enc, err := hdwallet.EncryptMnemonic(mnemonic, pwd)
+	if err != nil {
+		log.Errorf("encrypt mnemonic failed, err: %v", err)
+		return
+	}
+	if err := ioutil.WriteFile(*out, enc, 0600); err != nil {
+		log.Errorf("write mnemonic file failed, err: %v", err)
+		return
+	}
+
+	log.Infof("encrypt mnemonic into %s success!", *out)
+	return true
+}
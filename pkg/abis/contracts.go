@@ -0,0 +1,47 @@
+package abis
+
+import (
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/contracts/native/governance"
+	"github.com/ethereum/go-ethereum/contracts/native/nft"
+	"github.com/ethereum/go-ethereum/contracts/native/nftmanager"
+	"github.com/ethereum/go-ethereum/contracts/native/plt"
+	"github.com/polynetwork/eth-contracts/go_abi/eccd_abi"
+	"github.com/polynetwork/eth-contracts/go_abi/eccm_abi"
+	"github.com/polynetwork/eth-contracts/go_abi/eccmp_abi"
+	"github.com/polynetwork/eth-contracts/go_abi/lock_proxy_abi"
+	nftlp "github.com/polynetwork/nft-contracts/go_abi/nft_lock_proxy_abi"
+	nftmapping "github.com/polynetwork/nft-contracts/go_abi/nft_mapping_abi"
+	nftwp "github.com/polynetwork/nft-contracts/go_abi/nft_native_wrap_abi"
+	nftqy "github.com/polynetwork/nft-contracts/go_abi/nft_query_abi"
+	pltwp "github.com/polynetwork/nft-contracts/go_abi/plt_native_wrap_abi"
+)
+
+// contractABIs are the ABIs of solidity contracts deployed by the tool on both palette and ethereum.
+var contractABIs = map[string]string{
+	"ECCD":         eccd_abi.EthCrossChainDataABI,
+	"ECCM":         eccm_abi.EthCrossChainManagerABI,
+	"CCMP":         eccmp_abi.EthCrossChainManagerProxyABI,
+	"LockProxy":    lock_proxy_abi.LockProxyABI,
+	"NFTLockProxy": nftlp.PolyNFTLockProxyABI,
+	"NFTMapping":   nftmapping.CrossChainNFTMappingABI,
+	"NFTWrapper":   nftwp.PolyNativeNFTWrapperABI,
+	"NFTQuery":     nftqy.PolyNFTQueryABI,
+	"PLTWrapper":   pltwp.PolyWrapperABI,
+	"PLTAsset":     PLTAssetABI,
+}
+
+func init() {
+	for name, abiJSON := range contractABIs {
+		if err := RegisterJSON(name, abiJSON); err != nil {
+			panic(fmt.Sprintf("register %s abi failed, err: %v", name, err))
+		}
+	}
+
+	// palette native contracts
+	RegisterABI("PLT", plt.GetABI())
+	RegisterABI("NFT", nft.GetABI())
+	RegisterABI("NFTManager", nftmanager.GetABI())
+	RegisterABI("Governance", governance.GetABI())
+}
@@ -0,0 +1,211 @@
+package abis
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+type contractEvent struct {
+	contract string
+	event    abi.Event
+}
+
+var (
+	// events of registered contracts indexed by event id, different contracts may declare events
+	// with the same signature, e.g: `OwnershipTransferred(address,address)`.
+	events     = make(map[common.Hash][]*contractEvent)
+	eventsLock sync.RWMutex
+
+	eventJSON bool
+)
+
+// SetEventJSON print the decoded event logs as json instead of text.
+func SetEventJSON(enable bool) {
+	eventJSON = enable
+}
+
+// RegisterABI add the events of contract ABI to registry.
+func RegisterABI(contract string, ab abi.ABI) {
+	eventsLock.Lock()
+	defer eventsLock.Unlock()
+
+	for _, ev := range ab.Events {
+		id := ev.ID()
+		events[id] = append(events[id], &contractEvent{contract: contract, event: ev})
+	}
+}
+
+// RegisterJSON parse the contract ABI json, and register both events and custom errors of it.
+func RegisterJSON(contract, abiJSON string) error {
+	ab, err := abi.JSON(strings.NewReader(abiJSON))
+	if err != nil {
+		return fmt.Errorf("invalid %s abi, err: %v", contract, err)
+	}
+	RegisterABI(contract, ab)
+	return RegisterErrors(abiJSON)
+}
+
+// Field is the named argument of decoded event.
+type Field struct {
+	Name  string      `json:"name"`
+	Value interface{} `json:"value"`
+}
+
+// Event is the log decoded with registered ABIs.
+type Event struct {
+	Contract string         `json:"contract"`
+	Name     string         `json:"name"`
+	Address  common.Address `json:"address"`
+	Fields   []*Field       `json:"fields"`
+}
+
+func (e *Event) String() string {
+	list := make([]string, len(e.Fields))
+	for i, field := range e.Fields {
+		list[i] = fmt.Sprintf("%s: %s", field.Name, formatValue(field.Value))
+	}
+	return fmt.Sprintf("%s.%s(%s) at %s", e.Contract, e.Name, strings.Join(list, ", "), e.Address.Hex())
+}
+
+// Field returns the value of named field, and nil if the event has no such field.
+func (e *Event) Field(name string) interface{} {
+	for _, field := range e.Fields {
+		if field.Name == name {
+			return field.Value
+		}
+	}
+	return nil
+}
+
+// DecodeLog decode the log into event name and named fields with registered ABIs.
+func DecodeLog(l *types.Log) (*Event, error) {
+	if len(l.Topics) == 0 {
+		return nil, fmt.Errorf("anonymous log of %s", l.Address.Hex())
+	}
+
+	eventsLock.RLock()
+	candidates := events[l.Topics[0]]
+	eventsLock.RUnlock()
+	if len(candidates) == 0 {
+		return nil, fmt.Errorf("unknown event %s of %s", l.Topics[0].Hex(), l.Address.Hex())
+	}
+
+	var lastErr error
+	for _, candidate := range candidates {
+		fields, err := decodeFields(candidate.event, l)
+		if err != nil {
+			lastErr = err
+			continue
+		}
+		return &Event{
+			Contract: candidate.contract,
+			Name:     candidate.event.Name,
+			Address:  l.Address,
+			Fields:   fields,
+		}, nil
+	}
+	return nil, lastErr
+}
+
+func decodeFields(ev abi.Event, l *types.Log) ([]*Field, error) {
+	var indexed int
+	for _, input := range ev.Inputs {
+		if input.Indexed {
+			indexed++
+		}
+	}
+	if indexed != len(l.Topics)-1 {
+		return nil, fmt.Errorf("event %s expect %d topics, got %d", ev.Name, indexed+1, len(l.Topics))
+	}
+
+	values, err := ev.Inputs.NonIndexed().UnpackValues(l.Data)
+	if err != nil {
+		return nil, fmt.Errorf("unpack event %s data failed, err: %v", ev.Name, err)
+	}
+
+	fields := make([]*Field, 0, len(ev.Inputs))
+	topics := l.Topics[1:]
+	for _, input := range ev.Inputs {
+		field := &Field{Name: input.Name}
+		if input.Indexed {
+			field.Value = decodeTopic(input.Type, topics[0])
+			topics = topics[1:]
+		} else {
+			field.Value = normalize(values[0])
+			values = values[1:]
+		}
+		fields = append(fields, field)
+	}
+	return fields, nil
+}
+
+// decodeTopic decode the indexed argument, dynamic types are stored as keccak256 hash in topics.
+func decodeTopic(typ abi.Type, topic common.Hash) interface{} {
+	switch typ.T {
+	case abi.StringTy, abi.BytesTy, abi.SliceTy, abi.ArrayTy, abi.TupleTy:
+		return topic
+	}
+	values, err := abi.Arguments{{Type: typ}}.UnpackValues(topic.Bytes())
+	if err != nil || len(values) != 1 {
+		return topic
+	}
+	return normalize(values[0])
+}
+
+// normalize convert the bytes to hex encoded types, so that they are readable in both text and json.
+func normalize(v interface{}) interface{} {
+	switch data := v.(type) {
+	case []byte:
+		return hexutil.Bytes(data)
+	case [32]byte:
+		return common.Hash(data)
+	}
+	return v
+}
+
+func formatValue(v interface{}) string {
+	if s, ok := v.(fmt.Stringer); ok {
+		return s.String()
+	}
+	return fmt.Sprintf("%v", v)
+}
+
+// rawLog is the output of logs which can not be decoded.
+type rawLog struct {
+	Address common.Address `json:"address"`
+	Topics  []common.Hash  `json:"topics"`
+	Data    hexutil.Bytes  `json:"data"`
+}
+
+// FormatLog returns the decoded event in text or json, and the raw log if it can not be decoded.
+func FormatLog(l *types.Log) string {
+	var out interface{}
+	if ev, err := DecodeLog(l); err == nil {
+		out = ev
+	} else {
+		out = &rawLog{Address: l.Address, Topics: l.Topics, Data: l.Data}
+	}
+
+	if eventJSON {
+		enc, err := json.Marshal(out)
+		if err != nil {
+			return err.Error()
+		}
+		return string(enc)
+	}
+	if ev, ok := out.(*Event); ok {
+		return ev.String()
+	}
+	topics := make([]string, len(l.Topics))
+	for i, topic := range l.Topics {
+		topics[i] = topic.Hex()
+	}
+	return fmt.Sprintf("unknown event at %s, topics [%s], data %s", l.Address.Hex(), strings.Join(topics, " "), hexutil.Encode(l.Data))
+}
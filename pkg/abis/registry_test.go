@@ -0,0 +1,85 @@
+package abis
+
+import (
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/assert"
+)
+
+const testABI = `[
+	{"anonymous":false,"type":"event","name":"TestDeployed","inputs":[
+		{"indexed":true,"name":"deployer","type":"address"},
+		{"indexed":true,"name":"tag","type":"string"},
+		{"indexed":false,"name":"amount","type":"uint256"},
+		{"indexed":false,"name":"payload","type":"bytes"}
+	]}
+]`
+
+func newTestLog(t *testing.T) (*types.Log, common.Address) {
+	uint256Type, _ := abi.NewType("uint256", "", nil)
+	bytesType, _ := abi.NewType("bytes", "", nil)
+	data, err := abi.Arguments{{Type: uint256Type}, {Type: bytesType}}.Pack(big.NewInt(100), []byte{0x01, 0x02})
+	assert.NoError(t, err)
+
+	deployer := common.HexToAddress("0x1234")
+	return &types.Log{
+		Address: common.HexToAddress("0x5678"),
+		Topics: []common.Hash{
+			crypto.Keccak256Hash([]byte("TestDeployed(address,string,uint256,bytes)")),
+			common.BytesToHash(deployer.Bytes()),
+			crypto.Keccak256Hash([]byte("tag")),
+		},
+		Data: data,
+	}, deployer
+}
+
+func TestDecodeLog(t *testing.T) {
+	assert.NoError(t, RegisterJSON("Test", testABI))
+	l, deployer := newTestLog(t)
+
+	ev, err := DecodeLog(l)
+	assert.NoError(t, err)
+	assert.Equal(t, "Test", ev.Contract)
+	assert.Equal(t, "TestDeployed", ev.Name)
+	assert.Equal(t, 4, len(ev.Fields))
+	assert.Equal(t, deployer, ev.Fields[0].Value)
+	assert.Equal(t, crypto.Keccak256Hash([]byte("tag")), ev.Fields[1].Value)
+	assert.Equal(t, big.NewInt(100), ev.Fields[2].Value)
+	assert.Equal(t,
+		"Test.TestDeployed(deployer: "+deployer.Hex()+", tag: "+crypto.Keccak256Hash([]byte("tag")).Hex()+
+			", amount: 100, payload: 0x0102) at "+l.Address.Hex(),
+		ev.String())
+
+	// topics mismatch
+	l.Topics = l.Topics[:2]
+	_, err = DecodeLog(l)
+	assert.Error(t, err)
+
+	// unknown event
+	l.Topics = []common.Hash{crypto.Keccak256Hash([]byte("Unknown()"))}
+	_, err = DecodeLog(l)
+	assert.Error(t, err)
+	assert.Contains(t, FormatLog(l), "unknown event")
+}
+
+func TestFormatLogJSON(t *testing.T) {
+	assert.NoError(t, RegisterJSON("Test", testABI))
+	SetEventJSON(true)
+	defer SetEventJSON(false)
+
+	l, deployer := newTestLog(t)
+	out := make(map[string]interface{})
+	assert.NoError(t, json.Unmarshal([]byte(FormatLog(l)), &out))
+	assert.Equal(t, "TestDeployed", out["name"])
+
+	fields := out["fields"].([]interface{})
+	assert.Equal(t, "deployer", fields[0].(map[string]interface{})["name"])
+	assert.Equal(t, deployer.Hex(), common.HexToAddress(fields[0].(map[string]interface{})["value"].(string)).Hex())
+	assert.Equal(t, "0x0102", fields[3].(map[string]interface{})["value"])
+}
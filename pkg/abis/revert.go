@@ -0,0 +1,143 @@
+package abis
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"strings"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+var (
+	// revert data of `require(cond, "reason")` and `revert("reason")`
+	errorSelector = crypto.Keccak256([]byte("Error(string)"))[:4]
+	// revert data of `assert`, division by zero and other compiler checks since solidity 0.8
+	panicSelector = crypto.Keccak256([]byte("Panic(uint256)"))[:4]
+
+	stringArgs, uint256Args abi.Arguments
+)
+
+func init() {
+	stringType, _ := abi.NewType("string", "", nil)
+	uint256Type, _ := abi.NewType("uint256", "", nil)
+	stringArgs = abi.Arguments{{Type: stringType}}
+	uint256Args = abi.Arguments{{Type: uint256Type}}
+}
+
+// customError is the solidity custom error declared as `error Name(args)` in contract ABI.
+type customError struct {
+	name   string
+	inputs abi.Arguments
+}
+
+var (
+	customErrors = make(map[[4]byte]*customError)
+	errorsLock   sync.RWMutex
+)
+
+type abiEntry struct {
+	Type   string
+	Name   string
+	Inputs []abi.ArgumentMarshaling
+}
+
+// RegisterErrors parse the custom errors in contract ABI json, so that revert data of these errors
+// can be decoded. `abi.JSON` does not keep the error entries, so they are parsed here.
+func RegisterErrors(abiJSON string) error {
+	var entries []abiEntry
+	if err := json.Unmarshal([]byte(abiJSON), &entries); err != nil {
+		return fmt.Errorf("invalid abi json, err: %v", err)
+	}
+
+	errorsLock.Lock()
+	defer errorsLock.Unlock()
+
+	for _, entry := range entries {
+		if entry.Type != "error" {
+			continue
+		}
+		inputs := make(abi.Arguments, len(entry.Inputs))
+		types := make([]string, len(entry.Inputs))
+		for i, input := range entry.Inputs {
+			typ, err := abi.NewType(input.Type, input.InternalType, input.Components)
+			if err != nil {
+				return fmt.Errorf("invalid input %s of error %s, err: %v", input.Name, entry.Name, err)
+			}
+			inputs[i] = abi.Argument{Name: input.Name, Type: typ}
+			types[i] = typ.String()
+		}
+		sig := fmt.Sprintf("%s(%s)", entry.Name, strings.Join(types, ","))
+		var selector [4]byte
+		copy(selector[:], crypto.Keccak256([]byte(sig))[:4])
+		customErrors[selector] = &customError{name: entry.Name, inputs: inputs}
+	}
+	return nil
+}
+
+// DecodeRevert decode the revert data of `Error(string)`, `Panic(uint256)` and registered custom
+// errors, and returns false if the data is unknown.
+func DecodeRevert(data []byte) (string, bool) {
+	if len(data) < 4 {
+		return "", false
+	}
+	selector, payload := data[:4], data[4:]
+
+	switch {
+	case bytes.Equal(selector, errorSelector):
+		values, err := stringArgs.UnpackValues(payload)
+		if err != nil || len(values) != 1 {
+			return "", false
+		}
+		reason, ok := values[0].(string)
+		return reason, ok
+
+	case bytes.Equal(selector, panicSelector):
+		values, err := uint256Args.UnpackValues(payload)
+		if err != nil || len(values) != 1 {
+			return "", false
+		}
+		code, ok := values[0].(*big.Int)
+		if !ok {
+			return "", false
+		}
+		return fmt.Sprintf("panic code 0x%x", code), true
+	}
+
+	var key [4]byte
+	copy(key[:], selector)
+	errorsLock.RLock()
+	custom, ok := customErrors[key]
+	errorsLock.RUnlock()
+	if !ok {
+		return "", false
+	}
+	values, err := custom.inputs.UnpackValues(payload)
+	if err != nil {
+		return "", false
+	}
+	args := make([]string, len(values))
+	for i, v := range values {
+		if name := custom.inputs[i].Name; name != "" {
+			args[i] = fmt.Sprintf("%s: %v", name, v)
+		} else {
+			args[i] = fmt.Sprintf("%v", v)
+		}
+	}
+	return fmt.Sprintf("%s(%s)", custom.name, strings.Join(args, ", ")), true
+}
+
+// RevertReason returns the decoded reason, or the hex revert data if it can not be decoded.
+func RevertReason(data []byte) string {
+	if reason, ok := DecodeRevert(data); ok {
+		return reason
+	}
+	if len(data) == 0 {
+		return ""
+	}
+	return "unknown revert data " + hexutil.Encode(data)
+}
@@ -0,0 +1,52 @@
+package abis
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDecodeRevert(t *testing.T) {
+	enc, err := stringArgs.Pack("Ownable: caller is not the owner")
+	assert.NoError(t, err)
+	reason, ok := DecodeRevert(append(append([]byte{}, errorSelector...), enc...))
+	assert.True(t, ok)
+	assert.Equal(t, "Ownable: caller is not the owner", reason)
+
+	enc, err = uint256Args.Pack(big.NewInt(0x11))
+	assert.NoError(t, err)
+	reason, ok = DecodeRevert(append(append([]byte{}, panicSelector...), enc...))
+	assert.True(t, ok)
+	assert.Equal(t, "panic code 0x11", reason)
+
+	_, ok = DecodeRevert([]byte{0x01, 0x02})
+	assert.False(t, ok)
+	assert.Equal(t, "unknown revert data 0x01020304", RevertReason([]byte{0x01, 0x02, 0x03, 0x04}))
+	assert.Equal(t, "", RevertReason(nil))
+}
+
+func TestDecodeCustomError(t *testing.T) {
+	abiJSON := `[
+		{"type":"function","name":"owner","inputs":[],"outputs":[{"name":"","type":"address"}]},
+		{"type":"error","name":"Unauthorized","inputs":[{"name":"caller","type":"address"},{"name":"role","type":"uint8"}]}
+	]`
+	assert.NoError(t, RegisterErrors(abiJSON))
+
+	addressType, _ := abi.NewType("address", "", nil)
+	uint8Type, _ := abi.NewType("uint8", "", nil)
+	args := abi.Arguments{{Type: addressType}, {Type: uint8Type}}
+	caller := common.HexToAddress("0x1234")
+	enc, err := args.Pack(caller, uint8(2))
+	assert.NoError(t, err)
+
+	selector := crypto.Keccak256([]byte("Unauthorized(address,uint8)"))[:4]
+	reason, ok := DecodeRevert(append(selector, enc...))
+	assert.True(t, ok)
+	assert.Equal(t, "Unauthorized(caller: "+caller.Hex()+", role: 2)", reason)
+
+	assert.Error(t, RegisterErrors("not json"))
+}
@@ -0,0 +1,216 @@
+package batch
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/palettechain/deploy-tool/pkg/errs"
+)
+
+// DefaultMaxSize is the max number of calls in one json-rpc batch request, nodes limit the batch size.
+const DefaultMaxSize = 100
+
+// Caller is the json-rpc client supports batch request, which is implemented by `rpc.Client`.
+type Caller interface {
+	BatchCallContext(ctx context.Context, b []rpc.BatchElem) error
+}
+
+// Call is one `eth_call` in batch, Result is the returned data and Err is the error of the call
+// itself, e.g: reverted or the result can not be decoded.
+type Call struct {
+	From   common.Address
+	To     common.Address
+	Data   []byte
+	Result hexutil.Bytes
+	Err    error
+
+	decode func(enc []byte) error
+}
+
+// Batch packs many `eth_call`s into json-rpc batch requests, and decodes the results individually.
+type Batch struct {
+	calls   []*Call
+	maxSize int
+}
+
+func New() *Batch {
+	return &Batch{maxSize: DefaultMaxSize}
+}
+
+// SetMaxSize set the max number of calls in one batch request, calls are split into several requests
+// if there are more.
+func (b *Batch) SetMaxSize(size int) *Batch {
+	if size > 0 {
+		b.maxSize = size
+	}
+	return b
+}
+
+func (b *Batch) Len() int {
+	return len(b.calls)
+}
+
+func (b *Batch) Calls() []*Call {
+	return b.calls
+}
+
+// Add append the call with raw payload, decode is called with the returned data if it is not nil.
+func (b *Batch) Add(from, to common.Address, payload []byte, decode func(enc []byte) error) *Call {
+	call := &Call{From: from, To: to, Data: payload, decode: decode}
+	b.calls = append(b.calls, call)
+	return call
+}
+
+// AddMethod append the call of contract method, and the returned data is unpacked into out.
+func (b *Batch) AddMethod(from, to common.Address, abiJSON, method string, out interface{}, args ...interface{}) *Call {
+	parsed, err := parseABI(abiJSON)
+	if err != nil {
+		return b.failed(from, to, err)
+	}
+	payload, err := parsed.Pack(method, args...)
+	if err != nil {
+		return b.failed(from, to, fmt.Errorf("pack %s failed, err: %v", method, err))
+	}
+	return b.Add(from, to, payload, func(enc []byte) error {
+		return parsed.Methods[method].Outputs.Unpack(out, enc)
+	})
+}
+
+// AddAddress append the call of contract method which returns an address or address bytes, e.g: the
+// bound proxy or asset hash of lock proxy, and the returned data is decoded into out.
+func (b *Batch) AddAddress(from, to common.Address, abiJSON, method string, out *common.Address, args ...interface{}) *Call {
+	parsed, err := parseABI(abiJSON)
+	if err != nil {
+		return b.failed(from, to, err)
+	}
+	payload, err := parsed.Pack(method, args...)
+	if err != nil {
+		return b.failed(from, to, fmt.Errorf("pack %s failed, err: %v", method, err))
+	}
+	return b.Add(from, to, payload, func(enc []byte) error {
+		values, err := parsed.Methods[method].Outputs.UnpackValues(enc)
+		if err != nil {
+			return err
+		}
+		if len(values) == 0 {
+			return fmt.Errorf("%s returns nothing", method)
+		}
+		return DecodeAddress(values[0], out)
+	})
+}
+
+// DecodeAddress set the address or address bytes into out.
+func DecodeAddress(value interface{}, out *common.Address) error {
+	switch v := value.(type) {
+	case common.Address:
+		*out = v
+	case []byte:
+		*out = common.BytesToAddress(v)
+	default:
+		return fmt.Errorf("%T is not address", value)
+	}
+	return nil
+}
+
+// failed append the call which can not be packed, it is not sent and the error is kept.
+func (b *Batch) failed(from, to common.Address, err error) *Call {
+	call := &Call{From: from, To: to, Err: err}
+	b.calls = append(b.calls, call)
+	return call
+}
+
+// Send the calls in batch requests at the block number, e.g: "latest". the returned error is the
+// error of batch request, and errors of single calls are set in them, see `Err`.
+func (b *Batch) Send(ctx context.Context, caller Caller, blockNum string) error {
+	if blockNum == "" {
+		blockNum = "latest"
+	}
+
+	pending := make([]*Call, 0, len(b.calls))
+	for _, call := range b.calls {
+		if call.Err == nil {
+			pending = append(pending, call)
+		}
+	}
+
+	for start := 0; start < len(pending); start += b.maxSize {
+		end := start + b.maxSize
+		if end > len(pending) {
+			end = len(pending)
+		}
+		if err := send(ctx, caller, pending[start:end], blockNum); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Err returns the first error of calls.
+func (b *Batch) Err() error {
+	for i, call := range b.calls {
+		if call.Err != nil {
+			return fmt.Errorf("call %d to %s failed, err: %v", i, call.To.Hex(), call.Err)
+		}
+	}
+	return nil
+}
+
+func send(ctx context.Context, caller Caller, calls []*Call, blockNum string) error {
+	elems := make([]rpc.BatchElem, len(calls))
+	for i, call := range calls {
+		elems[i] = rpc.BatchElem{
+			Method: "eth_call",
+			Args:   []interface{}{toCallArg(call), blockNum},
+			Result: &call.Result,
+		}
+	}
+	if err := caller.BatchCallContext(ctx, elems); err != nil {
+		return errs.RPC("eth_call batch", err)
+	}
+
+	for i, call := range calls {
+		if elems[i].Error != nil {
+			call.Err = elems[i].Error
+			continue
+		}
+		if call.decode != nil {
+			call.Err = call.decode(call.Result)
+		}
+	}
+	return nil
+}
+
+func toCallArg(call *Call) interface{} {
+	return map[string]interface{}{
+		"from": call.From,
+		"to":   call.To,
+		"data": hexutil.Bytes(call.Data),
+	}
+}
+
+var (
+	abiLock  sync.Mutex
+	abiCache = make(map[string]*abi.ABI)
+)
+
+// parseABI parse the abi json once.
+func parseABI(abiJSON string) (*abi.ABI, error) {
+	abiLock.Lock()
+	defer abiLock.Unlock()
+
+	if parsed, ok := abiCache[abiJSON]; ok {
+		return parsed, nil
+	}
+	parsed, err := abi.JSON(strings.NewReader(abiJSON))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read abi json, err: %v", err)
+	}
+	abiCache[abiJSON] = &parsed
+	return &parsed, nil
+}
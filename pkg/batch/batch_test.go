@@ -0,0 +1,72 @@
+package batch
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/stretchr/testify/assert"
+)
+
+const ownerABI = `[{"inputs":[],"name":"owner","outputs":[{"internalType":"address","name":"","type":"address"}],"stateMutability":"view","type":"function"}]`
+
+// fakeCaller responds the `to` address as result, and fails the calls to `revert`.
+type fakeCaller struct {
+	revert   common.Address
+	requests int
+}
+
+func (c *fakeCaller) BatchCallContext(ctx context.Context, b []rpc.BatchElem) error {
+	c.requests++
+	for i := range b {
+		arg := b[i].Args[0].(map[string]interface{})
+		to := arg["to"].(common.Address)
+		if to == c.revert {
+			b[i].Error = errors.New("execution reverted")
+			continue
+		}
+		*b[i].Result.(*hexutil.Bytes) = common.LeftPadBytes(to.Bytes(), 32)
+	}
+	return nil
+}
+
+func TestBatchSend(t *testing.T) {
+	revert := common.HexToAddress("0x02")
+	caller := &fakeCaller{revert: revert}
+
+	b := New().SetMaxSize(2)
+	var owners [3]common.Address
+	calls := []*Call{
+		b.AddAddress(common.Address{}, common.HexToAddress("0x01"), ownerABI, "owner", &owners[0]),
+		b.AddAddress(common.Address{}, revert, ownerABI, "owner", &owners[1]),
+		b.AddAddress(common.Address{}, common.HexToAddress("0x03"), ownerABI, "owner", &owners[2]),
+	}
+	raw := b.Add(common.Address{}, common.HexToAddress("0x04"), []byte{0x01}, nil)
+	packErr := b.AddAddress(common.Address{}, common.HexToAddress("0x05"), ownerABI, "notExist", new(common.Address))
+
+	assert.NoError(t, b.Send(context.Background(), caller, ""))
+	assert.Equal(t, 2, caller.requests)
+
+	assert.NoError(t, calls[0].Err)
+	assert.Equal(t, common.HexToAddress("0x01"), owners[0])
+	assert.Error(t, calls[1].Err)
+	assert.Equal(t, common.Address{}, owners[1])
+	assert.NoError(t, calls[2].Err)
+	assert.Equal(t, common.HexToAddress("0x03"), owners[2])
+	assert.Equal(t, common.LeftPadBytes(common.HexToAddress("0x04").Bytes(), 32), []byte(raw.Result))
+	assert.Error(t, packErr.Err)
+	assert.Error(t, b.Err())
+}
+
+func TestDecodeAddress(t *testing.T) {
+	var out common.Address
+	addr := common.HexToAddress("0x01")
+	assert.NoError(t, DecodeAddress(addr, &out))
+	assert.Equal(t, addr, out)
+	assert.NoError(t, DecodeAddress(addr.Bytes(), &out))
+	assert.Equal(t, addr, out)
+	assert.Error(t, DecodeAddress(uint64(1), &out))
+}
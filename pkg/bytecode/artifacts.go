@@ -0,0 +1,35 @@
+package bytecode
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/polynetwork/eth-contracts/go_abi/eccd_abi"
+	"github.com/polynetwork/eth-contracts/go_abi/eccm_abi"
+	"github.com/polynetwork/eth-contracts/go_abi/eccmp_abi"
+	"github.com/polynetwork/eth-contracts/go_abi/lock_proxy_abi"
+	nftlp "github.com/polynetwork/nft-contracts/go_abi/nft_lock_proxy_abi"
+	nftwp "github.com/polynetwork/nft-contracts/go_abi/nft_native_wrap_abi"
+	nftqy "github.com/polynetwork/nft-contracts/go_abi/nft_query_abi"
+	pltwp "github.com/polynetwork/nft-contracts/go_abi/plt_native_wrap_abi"
+)
+
+// artifacts are the creation bytecode of contracts deployed by the tool, which come from the bundled go
+// abi packages, and the names are the same as the registered ABIs.
+var artifacts = map[string]string{
+	"ECCD":         eccd_abi.EthCrossChainDataBin,
+	"ECCM":         eccm_abi.EthCrossChainManagerBin,
+	"CCMP":         eccmp_abi.EthCrossChainManagerProxyBin,
+	"LockProxy":    lock_proxy_abi.LockProxyBin,
+	"NFTLockProxy": nftlp.PolyNFTLockProxyBin,
+	"NFTWrapper":   nftwp.PolyNativeNFTWrapperBin,
+	"NFTQuery":     nftqy.PolyNFTQueryBin,
+	"PLTWrapper":   pltwp.PolyWrapperBin,
+}
+
+// Artifact returns the expected creation bytecode of the contract, ok is false if it is unknown.
+func Artifact(name string) ([]byte, bool) {
+	bin, ok := artifacts[name]
+	if !ok {
+		return nil, false
+	}
+	return common.FromHex(bin), true
+}
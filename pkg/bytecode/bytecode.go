@@ -0,0 +1,118 @@
+package bytecode
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"math/big"
+)
+
+// StripMetadata returns the code without the CBOR encoded metadata appended by solc, the length of
+// metadata is in the last 2 bytes. the code is returned as it is if it has no metadata.
+func StripMetadata(code []byte) []byte {
+	if len(code) < 2 {
+		return code
+	}
+	n := int(binary.BigEndian.Uint16(code[len(code)-2:]))
+	start := len(code) - 2 - n
+	if n == 0 || start < 0 {
+		return code
+	}
+	// metadata is a CBOR map of 1 to 5 entries, e.g: `bzzr0`, `ipfs` and `solc`
+	if head := code[start]; head < 0xa1 || head > 0xa5 {
+		return code
+	}
+	return code[:start]
+}
+
+const (
+	opCodeCopy = 0x39
+	opPush0    = 0x5f
+	opPush1    = 0x60
+	opPush32   = 0x7f
+	opDup1     = 0x80
+	opReturn   = 0xf3
+)
+
+type instruction struct {
+	op   byte
+	data []byte
+}
+
+func (ins instruction) push() (*big.Int, bool) {
+	switch {
+	case ins.op == opPush0:
+		return new(big.Int), true
+	case ins.op >= opPush1 && ins.op <= opPush32:
+		return new(big.Int).SetBytes(ins.data), true
+	}
+	return nil, false
+}
+
+func (ins instruction) pushZero() bool {
+	v, ok := ins.push()
+	return ok && v.Sign() == 0
+}
+
+// disassemble returns the instructions of code, the truncated push data at the end is kept as it is.
+func disassemble(code []byte) []instruction {
+	list := make([]instruction, 0, len(code))
+	for pc := 0; pc < len(code); pc++ {
+		ins := instruction{op: code[pc]}
+		if ins.op >= opPush1 && ins.op <= opPush32 {
+			end := pc + 1 + int(ins.op-opPush1) + 1
+			if end > len(code) {
+				end = len(code)
+			}
+			ins.data = code[pc+1 : end]
+			pc = end - 1
+		}
+		list = append(list, ins)
+	}
+	return list
+}
+
+// Runtime extract the runtime code from the creation bytecode compiled by solc, the constructor copies
+// the runtime code into memory and returns it by `PUSH size, DUP1, PUSH offset, PUSH 0, CODECOPY, PUSH 0, RETURN`.
+func Runtime(creation []byte) ([]byte, error) {
+	list := disassemble(creation)
+	for k := 4; k+2 < len(list); k++ {
+		if list[k].op != opCodeCopy || !list[k+1].pushZero() || list[k+2].op != opReturn {
+			continue
+		}
+		size, ok := list[k-4].push()
+		if !ok || list[k-3].op != opDup1 {
+			continue
+		}
+		offset, ok := list[k-2].push()
+		if !ok || !list[k-1].pushZero() {
+			continue
+		}
+		end := new(big.Int).Add(offset, size)
+		if !end.IsInt64() || end.Int64() > int64(len(creation)) {
+			return nil, fmt.Errorf("runtime code [%s, %s) out of creation bytecode of %d bytes", offset, end, len(creation))
+		}
+		return creation[offset.Int64():end.Int64()], nil
+	}
+	return nil, fmt.Errorf("runtime code not found in creation bytecode of %d bytes", len(creation))
+}
+
+// Verify returns nil if the deployed runtime code equals the runtime code extracted from the creation
+// bytecode. the metadata hashes are ignored, so the contracts compiled from the same source at different
+// paths still match. contracts with immutable variables are not supported, which are filled in the
+// runtime code by constructor.
+func Verify(runtime, creation []byte) error {
+	if len(runtime) == 0 {
+		return fmt.Errorf("no code deployed")
+	}
+	expected, err := Runtime(creation)
+	if err != nil {
+		return err
+	}
+	stripped := StripMetadata(runtime)
+	if !bytes.Equal(stripped, StripMetadata(expected)) {
+		return fmt.Errorf("runtime code of %d bytes(%d bytes without metadata) differs from the expected runtime code of %d bytes",
+			len(runtime), len(stripped), len(expected))
+	}
+	return nil
+}
@@ -0,0 +1,64 @@
+package bytecode
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// metadata returns the solc CBOR metadata with ipfs hash and solc version.
+func metadata(hash byte) []byte {
+	meta := []byte{0xa2, 0x64, 'i', 'p', 'f', 's', 0x58, 0x22}
+	for i := 0; i < 34; i++ {
+		meta = append(meta, hash)
+	}
+	meta = append(meta, 0x64, 's', 'o', 'l', 'c', 0x43, 0x00, 0x08, 0x04)
+	return append(meta, 0x00, byte(len(meta)))
+}
+
+func TestStripMetadata(t *testing.T) {
+	code := []byte{0x60, 0x80, 0x60, 0x40, 0x52}
+	assert.Equal(t, code, StripMetadata(append(append([]byte{}, code...), metadata(1)...)))
+	// no metadata
+	assert.Equal(t, code, StripMetadata(code))
+	assert.Equal(t, []byte{0x01}, StripMetadata([]byte{0x01}))
+}
+
+// creation returns the creation bytecode of solc, which has a constructor copies and returns the runtime.
+func creation(runtime []byte) []byte {
+	constructor := []byte{0x60, 0x80, 0x60, 0x40, 0x52, 0x34, 0x80, 0x15, 0x61, 0x00, 0x10, 0x57}
+	constructor = append(constructor, 0x61, 0x00, byte(len(runtime)), 0x80, 0x61, 0x00, 0x00, 0x60, 0x00, 0x39, 0x60, 0x00, 0xf3, 0xfe)
+	// the offset of runtime is the length of constructor
+	constructor[len(constructor)-8] = byte(len(constructor))
+	return append(constructor, runtime...)
+}
+
+func TestRuntime(t *testing.T) {
+	body := []byte{0x60, 0x80, 0x60, 0x40, 0x52, 0x34, 0x80, 0x15}
+	runtime := append(append([]byte{}, body...), metadata(1)...)
+	actual, err := Runtime(creation(runtime))
+	assert.NoError(t, err)
+	assert.Equal(t, runtime, actual)
+
+	_, err = Runtime(body)
+	assert.Error(t, err)
+}
+
+func TestVerify(t *testing.T) {
+	body := []byte{0x60, 0x80, 0x60, 0x40, 0x52, 0x34, 0x80, 0x15}
+	expected := creation(append(append([]byte{}, body...), metadata(1)...))
+
+	// the metadata hash differs
+	runtime := append(append([]byte{}, body...), metadata(2)...)
+	assert.NoError(t, Verify(runtime, expected))
+
+	// the code is swapped
+	swapped := append([]byte{0x60, 0x80, 0x60, 0x40, 0x52, 0x34, 0x80, 0x16}, metadata(1)...)
+	assert.Error(t, Verify(swapped, expected))
+	assert.Error(t, Verify(nil, expected))
+
+	// the code is a part of the expected runtime code
+	assert.Error(t, Verify(body[:4], expected))
+	assert.Error(t, Verify([]byte{0x00}, expected))
+	assert.Error(t, Verify(body[:len(body)-1], expected))
+}
@@ -1,9 +1,11 @@
 package dao
 
 import (
+	"encoding/binary"
 	"fmt"
 
 	"github.com/btcsuite/goleveldb/leveldb"
+	"github.com/ethereum/go-ethereum/common"
 )
 
 var instance *DaoImpl
@@ -39,9 +41,82 @@ func GetPwd(typ byte, k []byte) ([]byte, error) {
 	return instance.db.Get(key, nil)
 }
 
+func DeletePwd(typ byte, k []byte) error {
+	key := formatKey(typ, k)
+	return instance.db.Delete(key, nil)
+}
+
 func formatKey(typ byte, k []byte) []byte {
 	key := make([]byte, 0)
 	key = append(key, typ)
 	key = append(key, k...)
 	return key
 }
+
+// checkpointKey is the key type of scan checkpoints, which differs from the password session types.
+const checkpointKey byte = 'c'
+
+// Checkpoint is the last scanned block number stored in leveldb by name.
+type Checkpoint struct {
+	name string
+}
+
+func NewCheckpoint(name string) *Checkpoint {
+	return &Checkpoint{name: name}
+}
+
+func (c *Checkpoint) Load() (uint64, bool, error) {
+	enc, err := instance.db.Get(formatKey(checkpointKey, []byte(c.name)), nil)
+	if err == leveldb.ErrNotFound {
+		return 0, false, nil
+	}
+	if err != nil {
+		return 0, false, err
+	}
+	if len(enc) != 8 {
+		return 0, false, fmt.Errorf("invalid checkpoint %s", c.name)
+	}
+	return binary.BigEndian.Uint64(enc), true, nil
+}
+
+func (c *Checkpoint) Save(height uint64) error {
+	enc := make([]byte, 8)
+	binary.BigEndian.PutUint64(enc, height)
+	return instance.db.Put(formatKey(checkpointKey, []byte(c.name)), enc, nil)
+}
+
+// pendingKey is the key type of sent deployments which have not been confirmed.
+const pendingKey byte = 'd'
+
+// PendingDeploy is the deployment tx hash and contract address stored in leveldb by name before the tx
+// confirmed, so that the retried step waits the same tx instead of deploying again.
+type PendingDeploy struct {
+	name string
+}
+
+func NewPendingDeploy(name string) *PendingDeploy {
+	return &PendingDeploy{name: name}
+}
+
+func (p *PendingDeploy) Load() (common.Hash, common.Address, bool, error) {
+	enc, err := instance.db.Get(formatKey(pendingKey, []byte(p.name)), nil)
+	if err == leveldb.ErrNotFound {
+		return common.Hash{}, common.Address{}, false, nil
+	}
+	if err != nil {
+		return common.Hash{}, common.Address{}, false, err
+	}
+	if len(enc) != common.HashLength+common.AddressLength {
+		return common.Hash{}, common.Address{}, false, fmt.Errorf("invalid pending deploy %s", p.name)
+	}
+	return common.BytesToHash(enc[:common.HashLength]), common.BytesToAddress(enc[common.HashLength:]), true, nil
+}
+
+func (p *PendingDeploy) Save(hash common.Hash, addr common.Address) error {
+	enc := append(hash.Bytes(), addr.Bytes()...)
+	return instance.db.Put(formatKey(pendingKey, []byte(p.name)), enc, nil)
+}
+
+func (p *PendingDeploy) Clear() error {
+	return instance.db.Delete(formatKey(pendingKey, []byte(p.name)), nil)
+}
@@ -0,0 +1,111 @@
+package errs
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// TimeoutError returned if the transaction is not confirmed before deadline.
+type TimeoutError struct {
+	Hash    common.Hash
+	Timeout time.Duration
+}
+
+func (e *TimeoutError) Error() string {
+	return fmt.Sprintf("tx %s not confirmed in %s", e.Hash.Hex(), e.Timeout)
+}
+
+// RevertedError returned if the transaction is mined but the receipt status is failed.
+type RevertedError struct {
+	Hash        common.Hash
+	BlockNumber uint64
+	Reason      string
+}
+
+func (e *RevertedError) Error() string {
+	if e.Reason != "" {
+		return fmt.Sprintf("tx %s reverted at block %d, reason: %s", e.Hash.Hex(), e.BlockNumber, e.Reason)
+	}
+	return fmt.Sprintf("tx %s reverted at block %d", e.Hash.Hex(), e.BlockNumber)
+}
+
+// SimulationError returned if the pre-flight `eth_call` of transaction reverted, and the transaction
+// is never signed and sent.
+type SimulationError struct {
+	From   common.Address
+	To     *common.Address
+	Reason string
+}
+
+func (e *SimulationError) Error() string {
+	to := "contract creation"
+	if e.To != nil {
+		to = e.To.Hex()
+	}
+	return fmt.Sprintf("simulate tx from %s to %s reverted, reason: %s", e.From.Hex(), to, e.Reason)
+}
+
+// DroppedError returned if the transaction can not be found in both mempool and chain.
+type DroppedError struct {
+	Hash common.Hash
+}
+
+func (e *DroppedError) Error() string {
+	return fmt.Sprintf("tx %s dropped from mempool", e.Hash.Hex())
+}
+
+// ReorgedError returned if the mined transaction is removed from chain by reorg, and it is not mined
+// again in time.
+type ReorgedError struct {
+	Hash        common.Hash
+	BlockNumber uint64
+	BlockHash   common.Hash
+}
+
+func (e *ReorgedError) Error() string {
+	return fmt.Sprintf("tx %s reorged out of block %d %s", e.Hash.Hex(), e.BlockNumber, e.BlockHash.Hex())
+}
+
+// PendingError returned if the account still has transactions pending in mempool when the step is
+// retried, so that the step is not sent again until the transactions of the failed attempt are mined.
+type PendingError struct {
+	From    common.Address
+	Pending uint64
+	Latest  uint64
+}
+
+func (e *PendingError) Error() string {
+	return fmt.Sprintf("account %s has %d pending txs, pending nonce %d latest nonce %d", e.From.Hex(), e.Pending-e.Latest, e.Pending, e.Latest)
+}
+
+func IsTimeout(err error) bool {
+	var e *TimeoutError
+	return errors.As(err, &e)
+}
+
+// IsReverted returns true if the transaction reverted on chain or in simulation.
+func IsReverted(err error) bool {
+	var (
+		e   *RevertedError
+		sim *SimulationError
+	)
+	return errors.As(err, &e) || errors.As(err, &sim)
+}
+
+func IsDropped(err error) bool {
+	var e *DroppedError
+	return errors.As(err, &e)
+}
+
+func IsPending(err error) bool {
+	var e *PendingError
+	return errors.As(err, &e)
+}
+
+func IsReorged(err error) bool {
+	var e *ReorgedError
+	return errors.As(err, &e)
+}
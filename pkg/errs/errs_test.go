@@ -0,0 +1,46 @@
+package errs
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+)
+
+type testNodeError struct{}
+
+func (e *testNodeError) Error() string  { return "nonce too low" }
+func (e *testNodeError) ErrorCode() int { return -32000 }
+
+func TestClassify(t *testing.T) {
+	hash := common.HexToHash("0x01")
+	dialErr := &net.OpError{Op: "dial", Net: "tcp", Err: errors.New("connection refused")}
+
+	cases := []struct {
+		err       error
+		kind      Kind
+		retryable bool
+	}{
+		{nil, KindUnknown, false},
+		{errors.New("invalid config"), KindUnknown, false},
+		{RPC("eth_blockNumber", errors.New("EOF")), KindRPCUnavailable, true},
+		{fmt.Errorf("makeAuth, %w", dialErr), KindRPCUnavailable, true},
+		{RPC("eth_sendRawTransaction", &testNodeError{}), KindUnknown, false},
+		{&TimeoutError{Hash: hash, Timeout: time.Minute}, KindTimeout, true},
+		{fmt.Errorf("bind failed, err: %w", &DroppedError{Hash: hash}), KindTimeout, true},
+		{fmt.Errorf("deploy failed, err: %w", &PendingError{Pending: 2, Latest: 1}), KindTimeout, true},
+		{&ReorgedError{Hash: hash, BlockNumber: 1}, KindReorged, false},
+		{&RevertedError{Hash: hash, BlockNumber: 1}, KindReverted, false},
+		{&SimulationError{Reason: "Ownable: caller is not the owner"}, KindReverted, false},
+		{AlreadyDone("bind asset %s", "0x01"), KindAlreadyDone, false},
+	}
+	for i, c := range cases {
+		assert.Equal(t, c.kind, Classify(c.err), "case %d", i)
+		assert.Equal(t, c.retryable, Retryable(c.err), "case %d", i)
+	}
+	assert.Equal(t, "bind asset 0x01 already done", AlreadyDone("bind asset %s", "0x01").Error())
+}
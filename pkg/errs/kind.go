@@ -0,0 +1,121 @@
+package errs
+
+import (
+	"errors"
+	"fmt"
+	"net"
+)
+
+// Kind is the category of errors, the frame decide whether to retry a failed method by it.
+type Kind int
+
+const (
+	KindUnknown Kind = iota
+	// node can not be reached or the request failed in transport
+	KindRPCUnavailable
+	// transaction reverted on chain or in simulation
+	KindReverted
+	// transaction not confirmed in time, dropped from mempool or still pending when retried
+	KindTimeout
+	// the step has been done before, e.g: the asset has already been bound
+	KindAlreadyDone
+	// mined transaction reorged out of chain and not mined again
+	KindReorged
+)
+
+func (k Kind) String() string {
+	switch k {
+	case KindRPCUnavailable:
+		return "rpc unavailable"
+	case KindReverted:
+		return "reverted"
+	case KindTimeout:
+		return "timeout"
+	case KindAlreadyDone:
+		return "already done"
+	case KindReorged:
+		return "reorged"
+	default:
+		return "unknown"
+	}
+}
+
+// RPCError returned if the node can not be reached or the request failed in transport.
+type RPCError struct {
+	Op  string
+	Err error
+}
+
+func (e *RPCError) Error() string {
+	return fmt.Sprintf("rpc %s unavailable, err: %v", e.Op, e.Err)
+}
+
+func (e *RPCError) Unwrap() error {
+	return e.Err
+}
+
+// nodeError is the json-rpc error responded by node, e.g: `nonce too low`, which means the node is
+// available and the request should not be retried as it is.
+type nodeError interface {
+	Error() string
+	ErrorCode() int
+}
+
+// RPC wrap the error of rpc request as `RPCError`, errors responded by node are returned as it is.
+func RPC(op string, err error) error {
+	if err == nil {
+		return nil
+	}
+	var ne nodeError
+	if errors.As(err, &ne) {
+		return err
+	}
+	return &RPCError{Op: op, Err: err}
+}
+
+// AlreadyDoneError returned if the step has been done before.
+type AlreadyDoneError struct {
+	What string
+}
+
+func (e *AlreadyDoneError) Error() string {
+	return e.What + " already done"
+}
+
+func AlreadyDone(format string, args ...interface{}) error {
+	return &AlreadyDoneError{What: fmt.Sprintf(format, args...)}
+}
+
+// Classify returns the kind of error, typed errors wrapped by `fmt.Errorf("%w")` are classified too.
+func Classify(err error) Kind {
+	var (
+		rpcErr  *RPCError
+		netErr  net.Error
+		doneErr *AlreadyDoneError
+	)
+	switch {
+	case err == nil:
+		return KindUnknown
+	case errors.As(err, &doneErr):
+		return KindAlreadyDone
+	case IsReverted(err):
+		return KindReverted
+	case IsReorged(err):
+		return KindReorged
+	case IsTimeout(err), IsDropped(err), IsPending(err):
+		return KindTimeout
+	case errors.As(err, &rpcErr), errors.As(err, &netErr):
+		// transport errors of http client are `net.Error`, even if they are not wrapped by `RPC`
+		return KindRPCUnavailable
+	}
+	return KindUnknown
+}
+
+// Retryable returns true if the error may disappear by retrying, e.g: flaky node or congested mempool.
+func Retryable(err error) bool {
+	switch Classify(err) {
+	case KindRPCUnavailable, KindTimeout:
+		return true
+	}
+	return false
+}
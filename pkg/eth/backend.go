@@ -0,0 +1,144 @@
+/*
+* Copyright (C) 2020 The poly network Authors
+* This file is part of The poly network library.
+*
+* The poly network is free software: you can redistribute it and/or modify
+* it under the terms of the GNU Lesser General Public License as published by
+* the Free Software Foundation, either version 3 of the License, or
+* (at your option) any later version.
+*
+* The poly network is distributed in the hope that it will be useful,
+* but WITHOUT ANY WARRANTY; without even the implied warranty of
+* MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+* GNU Lesser General Public License for more details.
+* You should have received a copy of the GNU Lesser General Public License
+* along with The poly network . If not, see <http://www.gnu.org/licenses/>.
+ */
+package eth
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"math/big"
+	"sync"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/palettechain/deploy-tool/pkg/errs"
+	"github.com/palettechain/deploy-tool/pkg/log"
+)
+
+// backend simulate the call before estimating gas and estimate gas with margin for contract bindings.
+// the bindings only build legacy transactions, the ones signed with fees are replaced by EIP-1559
+// transactions when they are sent.
+type backend struct {
+	*ethclient.Client
+	rpc *rpc.Client
+
+	lock     sync.Mutex
+	dynamic  map[common.Hash][]byte      // legacy tx hash => raw EIP-1559 tx
+	replaced map[common.Hash]common.Hash // legacy tx hash => sent EIP-1559 tx hash
+}
+
+func newBackend(tools *ETHTools) *backend {
+	return &backend{
+		Client:   tools.GetEthClient(),
+		rpc:      tools.GetRPCClient(),
+		dynamic:  make(map[common.Hash][]byte),
+		replaced: make(map[common.Hash]common.Hash),
+	}
+}
+
+// EstimateGas simulate the call at pending block first, so the reverted calls are aborted with the
+// decoded reason.
+func (b *backend) EstimateGas(ctx context.Context, msg ethereum.CallMsg) (uint64, error) {
+	if err := Simulate(ctx, b.Client, msg); err != nil {
+		return 0, err
+	}
+	gasLimit, err := b.Client.EstimateGas(ctx, msg)
+	if err != nil {
+		return 0, err
+	}
+	return gasLimit + gasLimit*gasMargin/100, nil
+}
+
+// SendTransaction send the EIP-1559 transaction instead if the legacy one is replaced.
+func (b *backend) SendTransaction(ctx context.Context, tx *types.Transaction) error {
+	b.lock.Lock()
+	raw, ok := b.dynamic[tx.Hash()]
+	delete(b.dynamic, tx.Hash())
+	b.lock.Unlock()
+
+	if !ok {
+		return errs.RPC("eth_sendRawTransaction", b.Client.SendTransaction(ctx, tx))
+	}
+	var hash common.Hash
+	if err := b.rpc.CallContext(ctx, &hash, "eth_sendRawTransaction", hexutil.Bytes(raw)); err != nil {
+		return errs.RPC("eth_sendRawTransaction", err)
+	}
+
+	b.lock.Lock()
+	b.replaced[tx.Hash()] = hash
+	b.lock.Unlock()
+	log.Debugf("send EIP-1559 tx %s instead of %s", hash.Hex(), tx.Hash().Hex())
+	return nil
+}
+
+// dynamicSigner wrap the signer of `bind.TransactOpts`, the legacy tx is signed as it is, and the
+// EIP-1559 tx with the same nonce, gas, payload and fees is signed to be sent instead.
+func (b *backend) dynamicSigner(key *ecdsa.PrivateKey, chainID *big.Int, fees *Fees, signer bind.SignerFn) bind.SignerFn {
+	return func(s types.Signer, from common.Address, tx *types.Transaction) (*types.Transaction, error) {
+		signed, err := signer(s, from, tx)
+		if err != nil {
+			return nil, err
+		}
+		dtx := &dynamicFeeTx{
+			ChainID: chainID,
+			Nonce:   tx.Nonce(),
+			TipCap:  fees.TipCap,
+			FeeCap:  fees.FeeCap,
+			Gas:     tx.Gas(),
+			To:      tx.To(),
+			Value:   tx.Value(),
+			Data:    tx.Data(),
+		}
+		raw, _, err := dtx.sign(key)
+		if err != nil {
+			return nil, err
+		}
+
+		b.lock.Lock()
+		b.dynamic[signed.Hash()] = raw
+		b.lock.Unlock()
+		return signed, nil
+	}
+}
+
+// replace record the tx replaced by another one, e.g: the sped up tx mined instead.
+func (b *backend) replace(hash, by common.Hash) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	b.replaced[hash] = by
+	delete(b.replaced, by)
+}
+
+// txHash returns the hash of the tx sent and mined instead of the legacy one, or the hash of tx itself.
+func (b *backend) txHash(tx *types.Transaction) common.Hash {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	hash := tx.Hash()
+	for {
+		next, ok := b.replaced[hash]
+		if !ok {
+			return hash
+		}
+		hash = next
+	}
+}
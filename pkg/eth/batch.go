@@ -0,0 +1,94 @@
+/*
+* Copyright (C) 2020 The poly network Authors
+* This file is part of The poly network library.
+*
+* The poly network is free software: you can redistribute it and/or modify
+* it under the terms of the GNU Lesser General Public License as published by
+* the Free Software Foundation, either version 3 of the License, or
+* (at your option) any later version.
+*
+* The poly network is distributed in the hope that it will be useful,
+* but WITHOUT ANY WARRANTY; without even the implied warranty of
+* MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+* GNU Lesser General Public License for more details.
+* You should have received a copy of the GNU Lesser General Public License
+* along with The poly network . If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package eth
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/palettechain/deploy-tool/pkg/batch"
+	"github.com/polynetwork/eth-contracts/go_abi/eccd_abi"
+	"github.com/polynetwork/eth-contracts/go_abi/eccm_abi"
+	"github.com/polynetwork/eth-contracts/go_abi/eccmp_abi"
+	"github.com/polynetwork/eth-contracts/go_abi/lock_proxy_abi"
+	nftlp "github.com/polynetwork/nft-contracts/go_abi/nft_lock_proxy_abi"
+)
+
+// Batch packs view calls of invoker into json-rpc batch requests, e.g: check the bindings of many assets
+// or chain ids in one round trip. results are decoded into the out params after `Send`, and errors of
+// single calls are set in the returned `batch.Call`.
+type Batch struct {
+	*batch.Batch
+	i *EthInvoker
+}
+
+func (i *EthInvoker) NewBatch() *Batch {
+	return &Batch{Batch: batch.New(), i: i}
+}
+
+// Send the calls at block number, e.g: "latest", the returned error is the error of batch request.
+func (b *Batch) Send(blockNum string) error {
+	return b.Batch.Send(b.i.Context(), b.i.Tools.GetRPCClient(), blockNum)
+}
+
+// CallContract append the raw `eth_call`, and the returned data is set in the call result.
+func (b *Batch) CallContract(contractAddr common.Address, payload []byte) *batch.Call {
+	return b.Add(b.i.Address(), contractAddr, payload, nil)
+}
+
+func (b *Batch) GetPLTCCMP(proxyAddr common.Address, out *common.Address) *batch.Call {
+	return b.AddAddress(b.i.Address(), proxyAddr, lock_proxy_abi.LockProxyABI, "managerProxyContract", out)
+}
+
+func (b *Batch) GetBoundPLTAsset(localLockProxyAddr, fromAssetHash common.Address, toChainId uint64, out *common.Address) *batch.Call {
+	return b.AddAddress(b.i.Address(), localLockProxyAddr, lock_proxy_abi.LockProxyABI, "assetHashMap", out, fromAssetHash, toChainId)
+}
+
+func (b *Batch) GetBoundPLTProxy(localLockProxy common.Address, targetSideChainID uint64, out *common.Address) *batch.Call {
+	return b.AddAddress(b.i.Address(), localLockProxy, lock_proxy_abi.LockProxyABI, "proxyHashMap", out, targetSideChainID)
+}
+
+func (b *Batch) GetNFTCCMP(proxyAddr common.Address, out *common.Address) *batch.Call {
+	return b.AddAddress(b.i.Address(), proxyAddr, nftlp.PolyNFTLockProxyABI, "managerProxyContract", out)
+}
+
+func (b *Batch) GetBoundNFTAsset(lockProxyAddr, fromAssetHash common.Address, targetSideChainId uint64, out *common.Address) *batch.Call {
+	return b.AddAddress(b.i.Address(), lockProxyAddr, nftlp.PolyNFTLockProxyABI, "assetHashMap", out, fromAssetHash, targetSideChainId)
+}
+
+func (b *Batch) GetBoundNFTProxy(localLockProxy common.Address, targetSideChainID uint64, out *common.Address) *batch.Call {
+	return b.AddAddress(b.i.Address(), localLockProxy, nftlp.PolyNFTLockProxyABI, "proxyHashMap", out, targetSideChainID)
+}
+
+func (b *Batch) ECCDOwnership(eccdAddr common.Address, out *common.Address) *batch.Call {
+	return b.AddAddress(b.i.Address(), eccdAddr, eccd_abi.EthCrossChainDataABI, "owner", out)
+}
+
+func (b *Batch) ECCMOwnership(eccmAddr common.Address, out *common.Address) *batch.Call {
+	return b.AddAddress(b.i.Address(), eccmAddr, eccm_abi.EthCrossChainManagerABI, "owner", out)
+}
+
+func (b *Batch) CCMPOwnership(ccmpAddr common.Address, out *common.Address) *batch.Call {
+	return b.AddAddress(b.i.Address(), ccmpAddr, eccmp_abi.EthCrossChainManagerProxyABI, "owner", out)
+}
+
+func (b *Batch) PLTProxyOwnership(proxyAddr common.Address, out *common.Address) *batch.Call {
+	return b.AddAddress(b.i.Address(), proxyAddr, lock_proxy_abi.LockProxyABI, "owner", out)
+}
+
+func (b *Batch) NFTProxyOwnership(proxyAddr common.Address, out *common.Address) *batch.Call {
+	return b.AddAddress(b.i.Address(), proxyAddr, nftlp.PolyNFTLockProxyABI, "owner", out)
+}
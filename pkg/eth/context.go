@@ -0,0 +1,208 @@
+/*
+* Copyright (C) 2020 The poly network Authors
+* This file is part of The poly network library.
+*
+* The poly network is free software: you can redistribute it and/or modify
+* it under the terms of the GNU Lesser General Public License as published by
+* the Free Software Foundation, either version 3 of the License, or
+* (at your option) any later version.
+*
+* The poly network is distributed in the hope that it will be useful,
+* but WITHOUT ANY WARRANTY; without even the implied warranty of
+* MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+* GNU Lesser General Public License for more details.
+* You should have received a copy of the GNU Lesser General Public License
+* along with The poly network . If not, see <http://www.gnu.org/licenses/>.
+ */
+package eth
+
+import (
+	"context"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// context variants of invoker methods, the rpc requests and transaction waits of them are canceled
+// with the context, e.g: the per-step context supplied by frame.
+
+func (i *EthInvoker) DeployPLTLockProxyContext(ctx context.Context) (common.Address, error) {
+	return i.WithContext(ctx).DeployPLTLockProxy()
+}
+
+func (i *EthInvoker) DeployNFTLockProxyContext(ctx context.Context) (common.Address, error) {
+	return i.WithContext(ctx).DeployNFTLockProxy()
+}
+
+func (i *EthInvoker) SetPLTCCMPContext(ctx context.Context, proxyAddr, ccmpAddr common.Address) (common.Hash, error) {
+	return i.WithContext(ctx).SetPLTCCMP(proxyAddr, ccmpAddr)
+}
+
+func (i *EthInvoker) GetPLTCCMPContext(ctx context.Context, proxyAddr common.Address) (common.Address, error) {
+	return i.WithContext(ctx).GetPLTCCMP(proxyAddr)
+}
+
+func (i *EthInvoker) SetNFTCCMPContext(ctx context.Context, proxyAddr, ccmpAddr common.Address) (common.Hash, error) {
+	return i.WithContext(ctx).SetNFTCCMP(proxyAddr, ccmpAddr)
+}
+
+func (i *EthInvoker) GetNFTCCMPContext(ctx context.Context, proxyAddr common.Address) (common.Address, error) {
+	return i.WithContext(ctx).GetNFTCCMP(proxyAddr)
+}
+
+func (i *EthInvoker) DeployNFTContext(ctx context.Context, name, symbol string) (common.Address, error) {
+	return i.WithContext(ctx).DeployNFT(name, symbol)
+}
+
+func (i *EthInvoker) BindPLTAssetContext(ctx context.Context, localLockProxyAddr, fromAssetHash, toAssetHash common.Address, toChainId uint64) (common.Hash, error) {
+	return i.WithContext(ctx).BindPLTAsset(localLockProxyAddr, fromAssetHash, toAssetHash, toChainId)
+}
+
+func (i *EthInvoker) GetBoundPLTAssetContext(ctx context.Context, localLockProxyAddr, fromAssetHash common.Address, toChainId uint64) (common.Address, error) {
+	return i.WithContext(ctx).GetBoundPLTAsset(localLockProxyAddr, fromAssetHash, toChainId)
+}
+
+func (i *EthInvoker) BindPLTProxyContext(ctx context.Context, localLockProxy, targetLockProxy common.Address, targetSideChainID uint64) (common.Hash, error) {
+	return i.WithContext(ctx).BindPLTProxy(localLockProxy, targetLockProxy, targetSideChainID)
+}
+
+func (i *EthInvoker) GetBoundPLTProxyContext(ctx context.Context, localLockProxy common.Address, targetSideChainID uint64) (common.Address, error) {
+	return i.WithContext(ctx).GetBoundPLTProxy(localLockProxy, targetSideChainID)
+}
+
+func (i *EthInvoker) BindNFTAssetContext(ctx context.Context, lockProxyAddr, fromAssetHash, toAssetHash common.Address, targetSideChainId uint64) (common.Hash, error) {
+	return i.WithContext(ctx).BindNFTAsset(lockProxyAddr, fromAssetHash, toAssetHash, targetSideChainId)
+}
+
+func (i *EthInvoker) GetBoundNFTAssetContext(ctx context.Context, lockProxyAddr, fromAssetHash common.Address, targetSideChainId uint64) (common.Address, error) {
+	return i.WithContext(ctx).GetBoundNFTAsset(lockProxyAddr, fromAssetHash, targetSideChainId)
+}
+
+func (i *EthInvoker) BindNFTProxyContext(ctx context.Context, localLockProxy, targetLockProxy common.Address, targetSideChainID uint64) (common.Hash, error) {
+	return i.WithContext(ctx).BindNFTProxy(localLockProxy, targetLockProxy, targetSideChainID)
+}
+
+func (i *EthInvoker) GetBoundNFTProxyContext(ctx context.Context, localLockProxy common.Address, targetSideChainID uint64) (common.Address, error) {
+	return i.WithContext(ctx).GetBoundNFTProxy(localLockProxy, targetSideChainID)
+}
+
+func (i *EthInvoker) DeployECCDContext(ctx context.Context) (common.Address, error) {
+	return i.WithContext(ctx).DeployECCD()
+}
+
+func (i *EthInvoker) DeployECCMContext(ctx context.Context, eccd common.Address, sideChainID uint64, whiteList []common.Address, bookeeperBytes []byte) (common.Address, error) {
+	return i.WithContext(ctx).DeployECCM(eccd, sideChainID, whiteList, bookeeperBytes)
+}
+
+func (i *EthInvoker) DeployCCMPContext(ctx context.Context, eccm common.Address) (common.Address, error) {
+	return i.WithContext(ctx).DeployCCMP(eccm)
+}
+
+func (i *EthInvoker) HasCodeContext(ctx context.Context, addr common.Address) (bool, error) {
+	return i.WithContext(ctx).HasCode(addr)
+}
+
+func (i *EthInvoker) ECCDCurEpochPubKeysContext(ctx context.Context, eccdAddr common.Address) ([]byte, error) {
+	return i.WithContext(ctx).ECCDCurEpochPubKeys(eccdAddr)
+}
+
+func (i *EthInvoker) TransferECCDOwnershipContext(ctx context.Context, eccd, eccm common.Address) (common.Hash, error) {
+	return i.WithContext(ctx).TransferECCDOwnership(eccd, eccm)
+}
+
+func (i *EthInvoker) ECCDOwnershipContext(ctx context.Context, eccdAddr common.Address) (common.Address, error) {
+	return i.WithContext(ctx).ECCDOwnership(eccdAddr)
+}
+
+func (i *EthInvoker) TransferECCMOwnershipContext(ctx context.Context, eccm, ccmp common.Address) (common.Hash, error) {
+	return i.WithContext(ctx).TransferECCMOwnership(eccm, ccmp)
+}
+
+func (i *EthInvoker) ECCMOwnershipContext(ctx context.Context, eccmAddr common.Address) (common.Address, error) {
+	return i.WithContext(ctx).ECCMOwnership(eccmAddr)
+}
+
+func (i *EthInvoker) TransferCCMPOwnershipContext(ctx context.Context, ccmpAddr, newOwner common.Address) (common.Hash, error) {
+	return i.WithContext(ctx).TransferCCMPOwnership(ccmpAddr, newOwner)
+}
+
+func (i *EthInvoker) CCMPOwnershipContext(ctx context.Context, ccmpAddr common.Address) (common.Address, error) {
+	return i.WithContext(ctx).CCMPOwnership(ccmpAddr)
+}
+
+func (i *EthInvoker) TransferPLTProxyOwnershipContext(ctx context.Context, proxyAddr, newOwner common.Address) (common.Hash, error) {
+	return i.WithContext(ctx).TransferPLTProxyOwnership(proxyAddr, newOwner)
+}
+
+func (i *EthInvoker) PLTProxyOwnershipContext(ctx context.Context, proxyAddr common.Address) (common.Address, error) {
+	return i.WithContext(ctx).PLTProxyOwnership(proxyAddr)
+}
+
+func (i *EthInvoker) TransferNFTProxyOwnershipContext(ctx context.Context, proxyAddr, newOwner common.Address) (common.Hash, error) {
+	return i.WithContext(ctx).TransferNFTProxyOwnership(proxyAddr, newOwner)
+}
+
+func (i *EthInvoker) NFTProxyOwnershipContext(ctx context.Context, proxyAddr common.Address) (common.Address, error) {
+	return i.WithContext(ctx).NFTProxyOwnership(proxyAddr)
+}
+
+func (i *EthInvoker) DumpTxContext(ctx context.Context, hash common.Hash) error {
+	return i.WithContext(ctx).DumpTx(hash)
+}
+
+func (i *EthInvoker) GetReceiptContext(ctx context.Context, hash common.Hash) (*types.Receipt, error) {
+	return i.WithContext(ctx).GetReceipt(hash)
+}
+
+func (i *EthInvoker) GetCurrentHeightContext(ctx context.Context) (uint64, error) {
+	return i.WithContext(ctx).GetCurrentHeight()
+}
+
+func (i *EthInvoker) GetHeaderContext(ctx context.Context, height uint64) (*types.Header, error) {
+	return i.WithContext(ctx).GetHeader(height)
+}
+
+func (i *EthInvoker) InitGenesisBlockContext(ctx context.Context, eccmAddr common.Address, rawHdr, publickeys []byte) (common.Hash, error) {
+	return i.WithContext(ctx).InitGenesisBlock(eccmAddr, rawHdr, publickeys)
+}
+
+func (i *EthInvoker) SuggestGasPriceContext(ctx context.Context) (*big.Int, error) {
+	return i.WithContext(ctx).SuggestGasPrice()
+}
+
+func (i *EthInvoker) ResumeDeployContext(ctx context.Context, addr common.Address, hash common.Hash) (common.Address, error) {
+	return i.WithContext(ctx).ResumeDeploy(addr, hash)
+}
+
+func (i *EthInvoker) SpeedUpContext(ctx context.Context, hash common.Hash) (common.Hash, error) {
+	return i.WithContext(ctx).SpeedUp(hash)
+}
+
+func (i *EthInvoker) CancelContext(ctx context.Context, hash common.Hash) (common.Hash, error) {
+	return i.WithContext(ctx).Cancel(hash)
+}
+
+func (i *EthInvoker) FundContext(ctx context.Context, to []common.Address, amount *big.Int) ([]common.Hash, error) {
+	return i.WithContext(ctx).Fund(to, amount)
+}
+
+func (i *EthInvoker) DeployPLTAssetContext(ctx context.Context, bin []byte) (common.Address, error) {
+	return i.WithContext(ctx).DeployPLTAsset(bin)
+}
+
+func (i *EthInvoker) TransferPLTAssetOwnershipContext(ctx context.Context, asset, newOwner common.Address) (common.Hash, error) {
+	return i.WithContext(ctx).TransferPLTAssetOwnership(asset, newOwner)
+}
+
+func (i *EthInvoker) AcceptPLTAssetOwnershipContext(ctx context.Context, asset common.Address) (common.Hash, error) {
+	return i.WithContext(ctx).AcceptPLTAssetOwnership(asset)
+}
+
+func (i *EthInvoker) PLTAssetOwnershipContext(ctx context.Context, asset common.Address) (common.Address, error) {
+	return i.WithContext(ctx).PLTAssetOwnership(asset)
+}
+
+func (i *EthInvoker) PLTAssetPendingOwnerContext(ctx context.Context, asset common.Address) (common.Address, error) {
+	return i.WithContext(ctx).PLTAssetPendingOwner(asset)
+}
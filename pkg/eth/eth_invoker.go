@@ -27,8 +27,10 @@ import (
 	"github.com/ethereum/go-ethereum/contracts/native/utils"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/palettechain/deploy-tool/pkg/abis"
+	"github.com/palettechain/deploy-tool/pkg/errs"
 	"github.com/palettechain/deploy-tool/pkg/log"
-	// pltabi "github.com/palettechain/palette_token/go_abi/plt"
+	"github.com/palettechain/deploy-tool/pkg/rpcpool"
 	"github.com/polynetwork/eth-contracts/go_abi/eccd_abi"
 	"github.com/polynetwork/eth-contracts/go_abi/eccm_abi"
 	"github.com/polynetwork/eth-contracts/go_abi/eccmp_abi"
@@ -44,21 +46,37 @@ import (
 type EthInvoker struct {
 	PrivateKey *ecdsa.PrivateKey
 	Tools      *ETHTools
-	NM         *NonceManager
 	TestSigner *EthSigner
+	sender     *backend
+	ctx        context.Context
+	deploySent DeploySentFn
 }
 
-var (
-	DefaultGasLimit = 100000
-)
+// DeploySentFn is called with the contract address and tx hash after the deployment tx sent and before
+// it is waited.
+type DeploySentFn func(addr common.Address, hash common.Hash) error
 
-func NewEInvoker(url string, privateKey *ecdsa.PrivateKey) *EthInvoker {
-	instance := &EthInvoker{}
-	instance.Tools = NewEthTools(url)
-	if instance.Tools == nil {
-		log.Errorf("dail eth failed")
+func NewEInvoker(url string, privateKey *ecdsa.PrivateKey) (*EthInvoker, error) {
+	tools, err := NewEthTools(url)
+	if err != nil {
+		return nil, err
+	}
+	return newEInvoker(tools, privateKey), nil
+}
+
+// NewEInvokerPool dial the ethereum endpoints in pool, and the invoker fail over between them.
+func NewEInvokerPool(pool *rpcpool.Pool, privateKey *ecdsa.PrivateKey) (*EthInvoker, error) {
+	tools, err := NewEthToolsPool(pool)
+	if err != nil {
+		return nil, err
 	}
-	instance.NM = NewNonceManager(instance.Tools.GetEthClient())
+	return newEInvoker(tools, privateKey), nil
+}
+
+func newEInvoker(tools *ETHTools, privateKey *ecdsa.PrivateKey) *EthInvoker {
+	instance := &EthInvoker{}
+	instance.Tools = tools
+	instance.sender = newBackend(tools)
 	instance.PrivateKey = privateKey
 	address := crypto.PubkeyToAddress(privateKey.PublicKey)
 	instance.TestSigner = &EthSigner{
@@ -68,40 +86,61 @@ func NewEInvoker(url string, privateKey *ecdsa.PrivateKey) *EthInvoker {
 	return instance
 }
 
-func (i *EthInvoker) Address() common.Address {
-	publicKey := i.PrivateKey.Public()
-	publicKeyECDSA, ok := publicKey.(*ecdsa.PublicKey)
-	if !ok {
-		panic("ecdsa public key convert failed")
+// WithContext returns a shallow copy of invoker bound to the context, all rpc requests and
+// transaction waits of the copy are canceled with the context.
+func (i *EthInvoker) WithContext(ctx context.Context) *EthInvoker {
+	cp := *i
+	cp.ctx = ctx
+	return &cp
+}
+
+// WithDeploySent returns a shallow copy of invoker which calls fn after every deployment tx sent, the
+// deployment fails without waiting if fn fails.
+func (i *EthInvoker) WithDeploySent(fn DeploySentFn) *EthInvoker {
+	cp := *i
+	cp.deploySent = fn
+	return &cp
+}
+
+// Context returns the context bound to invoker, and the background context if it is not bound.
+func (i *EthInvoker) Context() context.Context {
+	if i.ctx != nil {
+		return i.ctx
 	}
-	return crypto.PubkeyToAddress(*publicKeyECDSA)
+	return context.Background()
+}
+
+func (i *EthInvoker) callOpts() *bind.CallOpts {
+	return &bind.CallOpts{Context: i.Context()}
+}
+
+func (i *EthInvoker) Address() common.Address {
+	return crypto.PubkeyToAddress(i.PrivateKey.PublicKey)
 }
 
 func (i *EthInvoker) DeployPLTLockProxy() (common.Address, error) {
-	auth, err := i.makeAuth()
-	if err != nil {
-		return utils.EmptyAddress, err
-	}
-	contractAddr, tx, _, err := lock_proxy_abi.DeployLockProxy(auth, i.backend())
+	contractAddr, tx, err := i.deploy(func(auth *bind.TransactOpts) (addr common.Address, tx *types.Transaction, err error) {
+		addr, tx, _, err = lock_proxy_abi.DeployLockProxy(auth, i.backend())
+		return
+	})
 	if err != nil {
 		return utils.EmptyAddress, err
 	}
-	if err := i.waitTxConfirm(tx.Hash()); err != nil {
+	if err := i.waitTxConfirm(i.txHash(tx)); err != nil {
 		return utils.EmptyAddress, err
 	}
 	return contractAddr, nil
 }
 
 func (i *EthInvoker) DeployNFTLockProxy() (common.Address, error) {
-	auth, err := i.makeAuth()
+	contractAddr, tx, err := i.deploy(func(auth *bind.TransactOpts) (addr common.Address, tx *types.Transaction, err error) {
+		addr, tx, _, err = nftlp.DeployPolyNFTLockProxy(auth, i.backend())
+		return
+	})
 	if err != nil {
 		return utils.EmptyAddress, err
 	}
-	contractAddr, tx, _, err := nftlp.DeployPolyNFTLockProxy(auth, i.backend())
-	if err != nil {
-		return utils.EmptyAddress, err
-	}
-	if err := i.waitTxConfirm(tx.Hash()); err != nil {
+	if err := i.waitTxConfirm(i.txHash(tx)); err != nil {
 		return utils.EmptyAddress, err
 	}
 	return contractAddr, nil
@@ -112,18 +151,16 @@ func (i *EthInvoker) SetPLTCCMP(proxyAddr, ccmpAddr common.Address) (common.Hash
 	if err != nil {
 		return utils.EmptyHash, err
 	}
-	auth, err := i.makeAuth()
-	if err != nil {
-		return utils.EmptyHash, err
-	}
-	tx, err := proxy.SetManagerProxy(auth, ccmpAddr)
+	tx, err := i.transact(func(auth *bind.TransactOpts) (*types.Transaction, error) {
+		return proxy.SetManagerProxy(auth, ccmpAddr)
+	})
 	if err != nil {
 		return utils.EmptyHash, err
 	}
-	if err := i.waitTxConfirm(tx.Hash()); err != nil {
+	if err := i.waitTxConfirm(i.txHash(tx)); err != nil {
 		return utils.EmptyHash, err
 	}
-	return tx.Hash(), nil
+	return i.txHash(tx), nil
 }
 
 func (i *EthInvoker) GetPLTCCMP(proxyAddr common.Address) (common.Address, error) {
@@ -132,7 +169,7 @@ func (i *EthInvoker) GetPLTCCMP(proxyAddr common.Address) (common.Address, error
 		return utils.EmptyAddress, err
 	}
 
-	return proxy.ManagerProxyContract(nil)
+	return proxy.ManagerProxyContract(i.callOpts())
 }
 
 func (i *EthInvoker) SetNFTCCMP(proxyAddr, ccmpAddr common.Address) (common.Hash, error) {
@@ -140,18 +177,16 @@ func (i *EthInvoker) SetNFTCCMP(proxyAddr, ccmpAddr common.Address) (common.Hash
 	if err != nil {
 		return utils.EmptyHash, err
 	}
-	auth, err := i.makeAuth()
+	tx, err := i.transact(func(auth *bind.TransactOpts) (*types.Transaction, error) {
+		return proxy.SetManagerProxy(auth, ccmpAddr)
+	})
 	if err != nil {
 		return utils.EmptyHash, err
 	}
-	tx, err := proxy.SetManagerProxy(auth, ccmpAddr)
-	if err != nil {
+	if err := i.waitTxConfirm(i.txHash(tx)); err != nil {
 		return utils.EmptyHash, err
 	}
-	if err := i.waitTxConfirm(tx.Hash()); err != nil {
-		return utils.EmptyHash, err
-	}
-	return tx.Hash(), nil
+	return i.txHash(tx), nil
 }
 
 func (i *EthInvoker) GetNFTCCMP(proxyAddr common.Address) (common.Address, error) {
@@ -160,22 +195,22 @@ func (i *EthInvoker) GetNFTCCMP(proxyAddr common.Address) (common.Address, error
 		return utils.EmptyAddress, err
 	}
 
-	return proxy.ManagerProxyContract(nil)
+	return proxy.ManagerProxyContract(i.callOpts())
 }
 
 func (i *EthInvoker) DeployNFT(name, symbol string) (common.Address, error) {
-	auth, err := i.makeAuth()
-	if err != nil {
-		return utils.EmptyAddress, err
-	}
-	address, tx, inst, err := nftmapping.DeployCrossChainNFTMapping(auth, i.backend(), name, symbol)
+	var inst *nftmapping.CrossChainNFTMapping
+	address, tx, err := i.deploy(func(auth *bind.TransactOpts) (addr common.Address, tx *types.Transaction, err error) {
+		addr, tx, inst, err = nftmapping.DeployCrossChainNFTMapping(auth, i.backend(), name, symbol)
+		return
+	})
 	if err != nil {
 		return utils.EmptyAddress, err
 	}
-	if err := i.waitTxConfirm(tx.Hash()); err != nil {
+	if err := i.waitTxConfirm(i.txHash(tx)); err != nil {
 		return utils.EmptyAddress, err
 	}
-	nameAfterDeploy, err := inst.Name(nil)
+	nameAfterDeploy, err := inst.Name(i.callOpts())
 	if err != nil {
 		return utils.EmptyAddress, err
 	}
@@ -197,18 +232,16 @@ func (i *EthInvoker) BindPLTAsset(
 		return utils.EmptyHash, err
 	}
 
-	auth, err := i.makeAuth()
-	if err != nil {
-		return utils.EmptyHash, err
-	}
-	tx, err := proxy.BindAssetHash(auth, fromAssetHash, toChainId, toAssetHash[:])
+	tx, err := i.transact(func(auth *bind.TransactOpts) (*types.Transaction, error) {
+		return proxy.BindAssetHash(auth, fromAssetHash, toChainId, toAssetHash[:])
+	})
 	if err != nil {
 		return utils.EmptyHash, err
 	}
-	if err := i.waitTxConfirm(tx.Hash()); err != nil {
+	if err := i.waitTxConfirm(i.txHash(tx)); err != nil {
 		return utils.EmptyHash, err
 	}
-	return tx.Hash(), nil
+	return i.txHash(tx), nil
 }
 
 func (i *EthInvoker) GetBoundPLTAsset(
@@ -222,7 +255,7 @@ func (i *EthInvoker) GetBoundPLTAsset(
 		return utils.EmptyAddress, err
 	}
 
-	bz, err := proxy.AssetHashMap(nil, fromAssetHash, toChainId)
+	bz, err := proxy.AssetHashMap(i.callOpts(), fromAssetHash, toChainId)
 	if err != nil {
 		return utils.EmptyAddress, err
 	}
@@ -241,18 +274,16 @@ func (i *EthInvoker) BindPLTProxy(
 		return utils.EmptyHash, err
 	}
 
-	auth, err := i.makeAuth()
+	tx, err := i.transact(func(auth *bind.TransactOpts) (*types.Transaction, error) {
+		return proxy.BindProxyHash(auth, targetSideChainID, targetLockProxy.Bytes())
+	})
 	if err != nil {
 		return utils.EmptyHash, err
 	}
-	tx, err := proxy.BindProxyHash(auth, targetSideChainID, targetLockProxy.Bytes())
-	if err != nil {
-		return utils.EmptyHash, err
-	}
-	if err := i.waitTxConfirm(tx.Hash()); err != nil {
+	if err := i.waitTxConfirm(i.txHash(tx)); err != nil {
 		return utils.EmptyHash, err
 	}
-	return tx.Hash(), nil
+	return i.txHash(tx), nil
 }
 
 func (i *EthInvoker) GetBoundPLTProxy(
@@ -265,7 +296,7 @@ func (i *EthInvoker) GetBoundPLTProxy(
 		return utils.EmptyAddress, err
 	}
 
-	bz, err := proxy.ProxyHashMap(nil, targetSideChainID)
+	bz, err := proxy.ProxyHashMap(i.callOpts(), targetSideChainID)
 	if err != nil {
 		return utils.EmptyAddress, err
 	}
@@ -284,18 +315,16 @@ func (i *EthInvoker) BindNFTAsset(
 		return utils.EmptyHash, err
 	}
 
-	auth, err := i.makeAuth()
-	if err != nil {
-		return utils.EmptyHash, err
-	}
-	tx, err := proxy.BindAssetHash(auth, fromAssetHash, targetSideChainId, toAssetHash[:])
+	tx, err := i.transact(func(auth *bind.TransactOpts) (*types.Transaction, error) {
+		return proxy.BindAssetHash(auth, fromAssetHash, targetSideChainId, toAssetHash[:])
+	})
 	if err != nil {
 		return utils.EmptyHash, err
 	}
-	if err := i.waitTxConfirm(tx.Hash()); err != nil {
+	if err := i.waitTxConfirm(i.txHash(tx)); err != nil {
 		return utils.EmptyHash, err
 	}
-	return tx.Hash(), nil
+	return i.txHash(tx), nil
 }
 
 func (i *EthInvoker) GetBoundNFTAsset(
@@ -309,7 +338,7 @@ func (i *EthInvoker) GetBoundNFTAsset(
 		return utils.EmptyAddress, err
 	}
 
-	bz, err := proxy.AssetHashMap(nil, fromAssetHash, targetSideChainId)
+	bz, err := proxy.AssetHashMap(i.callOpts(), fromAssetHash, targetSideChainId)
 	if err != nil {
 		return utils.EmptyAddress, err
 	}
@@ -326,19 +355,17 @@ func (i *EthInvoker) BindNFTProxy(
 	if err != nil {
 		return utils.EmptyHash, err
 	}
-	auth, err := i.makeAuth()
-	if err != nil {
-		return utils.EmptyHash, err
-	}
-	tx, err := proxy.BindProxyHash(auth, targetSideChainID, targetLockProxy.Bytes())
+	tx, err := i.transact(func(auth *bind.TransactOpts) (*types.Transaction, error) {
+		return proxy.BindProxyHash(auth, targetSideChainID, targetLockProxy.Bytes())
+	})
 	if err != nil {
 		return utils.EmptyHash, err
 	}
 
-	if err := i.waitTxConfirm(tx.Hash()); err != nil {
+	if err := i.waitTxConfirm(i.txHash(tx)); err != nil {
 		return utils.EmptyHash, err
 	}
-	return tx.Hash(), nil
+	return i.txHash(tx), nil
 }
 
 func (i *EthInvoker) GetBoundNFTProxy(
@@ -350,7 +377,7 @@ func (i *EthInvoker) GetBoundNFTProxy(
 		return utils.EmptyAddress, err
 	}
 
-	bz, err := proxy.ProxyHashMap(nil, targetSideChainID)
+	bz, err := proxy.ProxyHashMap(i.callOpts(), targetSideChainID)
 	if err != nil {
 		return utils.EmptyAddress, err
 	}
@@ -358,23 +385,88 @@ func (i *EthInvoker) GetBoundNFTProxy(
 	return common.BytesToAddress(bz), nil
 }
 
+func (i *EthInvoker) DeployECCD() (common.Address, error) {
+	contractAddr, tx, err := i.deploy(func(auth *bind.TransactOpts) (addr common.Address, tx *types.Transaction, err error) {
+		addr, tx, _, err = eccd_abi.DeployEthCrossChainData(auth, i.backend())
+		return
+	})
+	if err != nil {
+		return utils.EmptyAddress, fmt.Errorf("DeployECCD, err: %w", err)
+	}
+	if err := i.waitTxConfirm(i.txHash(tx)); err != nil {
+		return utils.EmptyAddress, err
+	}
+	return contractAddr, nil
+}
+
+func (i *EthInvoker) DeployECCM(
+	eccd common.Address,
+	sideChainID uint64,
+	whiteList []common.Address,
+	bookeeperBytes []byte,
+) (common.Address, error) {
+
+	contractAddr, tx, err := i.deploy(func(auth *bind.TransactOpts) (addr common.Address, tx *types.Transaction, err error) {
+		addr, tx, _, err = eccm_abi.DeployEthCrossChainManager(auth, i.backend(), eccd, sideChainID, whiteList, bookeeperBytes)
+		return
+	})
+	if err != nil {
+		return utils.EmptyAddress, fmt.Errorf("DeployECCM, err: %w", err)
+	}
+	if err := i.waitTxConfirm(i.txHash(tx)); err != nil {
+		return utils.EmptyAddress, err
+	}
+	return contractAddr, nil
+}
+
+func (i *EthInvoker) DeployCCMP(eccm common.Address) (common.Address, error) {
+	contractAddr, tx, err := i.deploy(func(auth *bind.TransactOpts) (addr common.Address, tx *types.Transaction, err error) {
+		addr, tx, _, err = eccmp_abi.DeployEthCrossChainManagerProxy(auth, i.backend(), eccm)
+		return
+	})
+	if err != nil {
+		return utils.EmptyAddress, fmt.Errorf("DeployCCMP, err: %w", err)
+	}
+	if err := i.waitTxConfirm(i.txHash(tx)); err != nil {
+		return utils.EmptyAddress, err
+	}
+	return contractAddr, nil
+}
+
+// HasCode returns true if there is contract code at the address.
+func (i *EthInvoker) HasCode(addr common.Address) (bool, error) {
+	code, err := i.Tools.GetEthClient().CodeAt(i.Context(), addr, nil)
+	if err != nil {
+		return false, errs.RPC("eth_getCode", err)
+	}
+	return len(code) > 0, nil
+}
+
+// ECCDCurEpochPubKeys returns the poly consensus public keys of current epoch recorded in eccd, which
+// is empty before the poly genesis block initialized.
+func (i *EthInvoker) ECCDCurEpochPubKeys(eccdAddr common.Address) ([]byte, error) {
+	eccd, err := eccd_abi.NewEthCrossChainData(eccdAddr, i.backend())
+	if err != nil {
+		return nil, err
+	}
+	return eccd.GetCurEpochConPubKeyBytes(i.callOpts())
+}
+
 func (i *EthInvoker) TransferECCDOwnership(eccd, eccm common.Address) (common.Hash, error) {
 	eccdContract, err := eccd_abi.NewEthCrossChainData(eccd, i.backend())
 	if err != nil {
 		return utils.EmptyHash, fmt.Errorf("TransferECCDOwnership, err: %v", err)
 	}
-	auth, err := i.makeAuth()
-	if err != nil {
-		return utils.EmptyHash, err
-	}
-	tx, err := eccdContract.TransferOwnership(auth, eccm)
+	tx, err := i.transact(func(auth *bind.TransactOpts) (*types.Transaction, error) {
+		return eccdContract.TransferOwnership(auth, eccm)
+	})
 	if err != nil {
 		return utils.EmptyHash, fmt.Errorf("TransferECCDOwnership, err: %v", err)
 	}
-	if err := i.waitTxConfirm(tx.Hash()); err != nil {
+	if err := i.waitTxConfirm(i.txHash(tx)); err != nil {
 		return utils.EmptyHash, err
 	}
-	return tx.Hash(), nil
+	return i.txHash(tx), nil
 }
 
 func (i *EthInvoker) ECCDOwnership(eccdAddr common.Address) (common.Address, error) {
@@ -382,7 +474,7 @@ func (i *EthInvoker) ECCDOwnership(eccdAddr common.Address) (common.Address, err
 	if err != nil {
 		return utils.EmptyAddress, err
 	}
-	return eccd.Owner(nil)
+	return eccd.Owner(i.callOpts())
 }
 
 func (i *EthInvoker) TransferECCMOwnership(eccm, ccmp common.Address) (common.Hash, error) {
@@ -390,18 +482,16 @@ func (i *EthInvoker) TransferECCMOwnership(eccm, ccmp common.Address) (common.Ha
 	if err != nil {
 		return utils.EmptyHash, fmt.Errorf("TransferECCMOwnership err: %v", err)
 	}
-	auth, err := i.makeAuth()
-	if err != nil {
-		return utils.EmptyHash, err
-	}
-	tx, err := eccmContract.TransferOwnership(auth, ccmp)
+	tx, err := i.transact(func(auth *bind.TransactOpts) (*types.Transaction, error) {
+		return eccmContract.TransferOwnership(auth, ccmp)
+	})
 	if err != nil {
 		return utils.EmptyHash, fmt.Errorf("TransferECCMOwnership err: %v", err)
 	}
-	if err := i.waitTxConfirm(tx.Hash()); err != nil {
+	if err := i.waitTxConfirm(i.txHash(tx)); err != nil {
 		return utils.EmptyHash, err
 	}
-	return tx.Hash(), nil
+	return i.txHash(tx), nil
 }
 
 func (i *EthInvoker) ECCMOwnership(eccmAddr common.Address) (common.Address, error) {
@@ -409,7 +499,7 @@ func (i *EthInvoker) ECCMOwnership(eccmAddr common.Address) (common.Address, err
 	if err != nil {
 		return utils.EmptyAddress, err
 	}
-	return eccm.Owner(nil)
+	return eccm.Owner(i.callOpts())
 }
 
 func (i *EthInvoker) TransferCCMPOwnership(ccmpAddr, newOwner common.Address) (common.Hash, error) {
@@ -418,18 +508,16 @@ func (i *EthInvoker) TransferCCMPOwnership(ccmpAddr, newOwner common.Address) (c
 		return utils.EmptyHash, err
 	}
 
-	auth, err := i.makeAuth()
-	if err != nil {
-		return utils.EmptyHash, err
-	}
-	tx, err := ccmp.TransferOwnership(auth, newOwner)
+	tx, err := i.transact(func(auth *bind.TransactOpts) (*types.Transaction, error) {
+		return ccmp.TransferOwnership(auth, newOwner)
+	})
 	if err != nil {
 		return utils.EmptyHash, err
 	}
-	if err := i.waitTxConfirm(tx.Hash()); err != nil {
+	if err := i.waitTxConfirm(i.txHash(tx)); err != nil {
 		return utils.EmptyHash, err
 	}
-	return tx.Hash(), nil
+	return i.txHash(tx), nil
 }
 
 func (i *EthInvoker) CCMPOwnership(ccmpAddr common.Address) (common.Address, error) {
@@ -437,57 +525,8 @@ func (i *EthInvoker) CCMPOwnership(ccmpAddr common.Address) (common.Address, err
 	if err != nil {
 		return utils.EmptyAddress, err
 	}
-	return ccmp.Owner(nil)
-}
-
-func (i *EthInvoker) TransferPLTAssetOwnership(asset, newOwner common.Address) (common.Hash, error) {
-	//instance, err := pltabi.NewPaletteToken(asset, i.backend())
-	//if err != nil {
-	//	return utils.EmptyHash, err
-	//}
-	//
-	//auth, err := i.makeAuth()
-	//if err != nil {
-	//	return utils.EmptyHash, err
-	//}
-	//tx, err := instance.TransferOwnership(auth, newOwner)
-	//if err != nil {
-	//	return utils.EmptyHash, err
-	//}
-	//if err := i.waitTxConfirm(tx.Hash()); err != nil {
-	//	return utils.EmptyHash, err
-	//}
-	//return tx.Hash(), nil
-	return utils.EmptyHash, nil
-}
-
-//func (i *EthInvoker) AcceptOwnership(asset common.Address) (common.Hash, error) {
-//	instance, err := pltabi.NewPaletteToken(asset, i.backend())
-//	if err != nil {
-//		return utils.EmptyHash, err
-//	}
-//
-//	auth, err := i.makeAuth()
-//	if err != nil {
-//		return utils.EmptyHash, err
-//	}
-//	tx, err := instance.AcceptOwnership(auth)
-//	if err != nil {
-//		return utils.EmptyHash, err
-//	}
-//	if err := i.waitTxConfirm(tx.Hash()); err != nil {
-//		return utils.EmptyHash, err
-//	}
-//	return tx.Hash(), nil
-//}
-//
-//func (i *EthInvoker) PLTAssetOwnership(asset common.Address) (common.Address, error) {
-//	instance, err := pltabi.NewPaletteToken(asset, i.backend())
-//	if err != nil {
-//		return utils.EmptyAddress, err
-//	}
-//	return instance.Owner(nil)
-//}
+	return ccmp.Owner(i.callOpts())
+}
 
 func (i *EthInvoker) TransferPLTProxyOwnership(proxyAddr, newOwner common.Address) (common.Hash, error) {
 	proxy, err := lock_proxy_abi.NewLockProxy(proxyAddr, i.backend())
@@ -495,18 +534,16 @@ func (i *EthInvoker) TransferPLTProxyOwnership(proxyAddr, newOwner common.Addres
 		return utils.EmptyHash, err
 	}
 
-	auth, err := i.makeAuth()
-	if err != nil {
-		return utils.EmptyHash, err
-	}
-	tx, err := proxy.TransferOwnership(auth, newOwner)
+	tx, err := i.transact(func(auth *bind.TransactOpts) (*types.Transaction, error) {
+		return proxy.TransferOwnership(auth, newOwner)
+	})
 	if err != nil {
 		return utils.EmptyHash, err
 	}
-	if err := i.waitTxConfirm(tx.Hash()); err != nil {
+	if err := i.waitTxConfirm(i.txHash(tx)); err != nil {
 		return utils.EmptyHash, err
 	}
-	return tx.Hash(), nil
+	return i.txHash(tx), nil
 }
 
 func (i *EthInvoker) PLTProxyOwnership(proxyAddr common.Address) (common.Address, error) {
@@ -514,7 +551,7 @@ func (i *EthInvoker) PLTProxyOwnership(proxyAddr common.Address) (common.Address
 	if err != nil {
 		return utils.EmptyAddress, err
 	}
-	return proxy.Owner(nil)
+	return proxy.Owner(i.callOpts())
 }
 
 func (i *EthInvoker) TransferNFTProxyOwnership(proxyAddr, newOwner common.Address) (common.Hash, error) {
@@ -523,18 +560,16 @@ func (i *EthInvoker) TransferNFTProxyOwnership(proxyAddr, newOwner common.Addres
 		return utils.EmptyHash, err
 	}
 
-	auth, err := i.makeAuth()
+	tx, err := i.transact(func(auth *bind.TransactOpts) (*types.Transaction, error) {
+		return proxy.TransferOwnership(auth, newOwner)
+	})
 	if err != nil {
 		return utils.EmptyHash, err
 	}
-	tx, err := proxy.TransferOwnership(auth, newOwner)
-	if err != nil {
+	if err := i.waitTxConfirm(i.txHash(tx)); err != nil {
 		return utils.EmptyHash, err
 	}
-	if err := i.waitTxConfirm(tx.Hash()); err != nil {
-		return utils.EmptyHash, err
-	}
-	return tx.Hash(), nil
+	return i.txHash(tx), nil
 }
 
 func (i *EthInvoker) NFTProxyOwnership(proxyAddr common.Address) (common.Address, error) {
@@ -542,7 +577,7 @@ func (i *EthInvoker) NFTProxyOwnership(proxyAddr common.Address) (common.Address
 	if err != nil {
 		return utils.EmptyAddress, err
 	}
-	return proxy.Owner(nil)
+	return proxy.Owner(i.callOpts())
 }
 
 func (i *EthInvoker) DumpTx(hash common.Hash) error {
@@ -557,17 +592,13 @@ func (i *EthInvoker) DumpTx(hash common.Hash) error {
 
 	log.Infof("txhash %s, block height %d", hash.Hex(), tx.BlockNumber.Uint64())
 	for _, event := range tx.Logs {
-		log.Infof("eventlog address %s", event.Address.Hex())
-		log.Infof("eventlog data %s", new(big.Int).SetBytes(event.Data).String())
-		for i, topic := range event.Topics {
-			log.Infof("eventlog topic[%d] %s", i, topic.String())
-		}
+		log.Infof("eventlog %s", abis.FormatLog(event))
 	}
 	return nil
 }
 
 func (i *EthInvoker) GetReceipt(hash common.Hash) (*types.Receipt, error) {
-	tx, err := i.Tools.ethclient.TransactionReceipt(context.Background(), hash)
+	tx, err := i.Tools.ethclient.TransactionReceipt(i.Context(), hash)
 	if err != nil {
 		return nil, err
 	}
@@ -575,11 +606,11 @@ func (i *EthInvoker) GetReceipt(hash common.Hash) (*types.Receipt, error) {
 }
 
 func (i *EthInvoker) GetCurrentHeight() (uint64, error) {
-	return i.Tools.GetNodeHeight()
+	return i.Tools.GetNodeHeightContext(i.Context())
 }
 
 func (i *EthInvoker) GetHeader(height uint64) (*types.Header, error) {
-	return i.Tools.GetBlockHeader(height)
+	return i.Tools.GetBlockHeaderContext(i.Context(), height)
 }
 
 func (i *EthInvoker) InitGenesisBlock(eccmAddr common.Address, rawHdr, publickeys []byte) (common.Hash, error) {
@@ -588,54 +619,185 @@ func (i *EthInvoker) InitGenesisBlock(eccmAddr common.Address, rawHdr, publickey
 		return utils.EmptyHash, fmt.Errorf("new EthCrossChainManager err: %s", err)
 	}
 
-	auth, err := i.makeAuth()
-	if err != nil {
-		return utils.EmptyHash, err
-	}
-	tx, err := eccm.InitGenesisBlock(auth, rawHdr, publickeys)
+	tx, err := i.transact(func(auth *bind.TransactOpts) (*types.Transaction, error) {
+		return eccm.InitGenesisBlock(auth, rawHdr, publickeys)
+	})
 	if err != nil {
 		return utils.EmptyHash, fmt.Errorf("call eccm InitGenesisBlock err: %s", err)
 	}
 
-	if err := i.waitTxConfirm(tx.Hash()); err != nil {
+	if err := i.waitTxConfirm(i.txHash(tx)); err != nil {
 		return utils.EmptyHash, err
 	}
-	return tx.Hash(), nil
+	return i.txHash(tx), nil
 }
 
 func (i *EthInvoker) SuggestGasPrice() (*big.Int, error) {
-	return i.backend().SuggestGasPrice(context.Background())
+	return i.backend().SuggestGasPrice(i.Context())
+}
+
+type transactFn func(auth *bind.TransactOpts) (*types.Transaction, error)
+type deployFn func(auth *bind.TransactOpts) (common.Address, *types.Transaction, error)
+
+// nonceManager returns the nonce manager shared by all ethereum invokers with the same chain id, so
+// that parallel steps signed by the same key never collide.
+func (i *EthInvoker) nonceManager() (*NonceManager, error) {
+	chainID, err := i.Tools.GetChainIDContext(i.Context())
+	if err != nil {
+		return nil, errs.RPC("eth_chainId", err)
+	}
+	return SharedNonceManager(fmt.Sprintf("ethereum-%s", chainID), i.Tools.GetEthClient()), nil
 }
 
-func (i *EthInvoker) makeAuth() (*bind.TransactOpts, error) {
-	fromAddress := i.Address()
-	nonce, err := i.backend().PendingNonceAt(context.Background(), fromAddress)
+// transact send contract binding tx with nonce reserved from the shared nonce manager, the nonce is
+// rolled back if the tx is not sent, and the stale nonce is resynced from node.
+func (i *EthInvoker) transact(fn transactFn) (*types.Transaction, error) {
+	nm, err := i.nonceManager()
 	if err != nil {
-		return nil, fmt.Errorf("makeAuth, addr %s, err %v", fromAddress.Hex(), err)
+		return nil, err
+	}
+	addr := i.Address()
+	if err := nm.CheckPending(i.Context(), addr); err != nil {
+		return nil, err
+	}
+	nonce, err := nm.Reserve(i.Context(), addr, 1)
+	if err != nil {
+		return nil, err
+	}
+
+	auth, err := i.makeAuth(nonce)
+	if err == nil {
+		var tx *types.Transaction
+		if tx, err = fn(auth); err == nil {
+			nm.Sent(addr)
+			return tx, nil
+		}
+	}
+	if IsNonceError(err) {
+		log.Debugf("%s nonce %d is stale, resync nonce, err: %v", addr.Hex(), nonce, err)
+		nm.Resync(addr)
+	}
+	nm.Release(addr, nonce)
+	return nil, err
+}
+
+// deploy send contract deployment tx with nonce reserved from the shared nonce manager.
+func (i *EthInvoker) deploy(fn deployFn) (common.Address, *types.Transaction, error) {
+	var addr common.Address
+	tx, err := i.transact(func(auth *bind.TransactOpts) (tx *types.Transaction, err error) {
+		addr, tx, err = fn(auth)
+		return
+	})
+	if err == nil && i.deploySent != nil {
+		if err := i.deploySent(addr, i.txHash(tx)); err != nil {
+			return addr, tx, fmt.Errorf("deployment %s of %s sent, err: %w", i.txHash(tx).Hex(), addr.Hex(), err)
+		}
 	}
+	return addr, tx, err
+}
 
-	gasPrice, err := i.backend().SuggestGasPrice(context.Background())
+// ResumeDeploy wait the deployment tx sent before, and returns the contract address once the tx confirmed.
+// the tx is not waited if the contract code exists, e.g: the tx has been replaced by a speed up.
+func (i *EthInvoker) ResumeDeploy(addr common.Address, hash common.Hash) (common.Address, error) {
+	deployed, err := i.HasCode(addr)
 	if err != nil {
-		return nil, fmt.Errorf("makeAuth, %v", err)
+		return utils.EmptyAddress, err
 	}
+	if !deployed {
+		if err := i.waitTxConfirm(hash); err != nil {
+			return utils.EmptyAddress, err
+		}
+	}
+	return addr, nil
+}
 
+// makeAuth returns the transact opts with EIP-1559 fees on chains after London, and legacy gas price
+// otherwise. the gas limit is estimated by binding with margin.
+func (i *EthInvoker) makeAuth(nonce uint64) (*bind.TransactOpts, error) {
 	auth := bind.NewKeyedTransactor(i.PrivateKey)
-	auth.Nonce = big.NewInt(int64(nonce))
-	auth.Value = big.NewInt(int64(0))       // in wei
-	auth.GasLimit = uint64(DefaultGasLimit) // in units
-	auth.GasPrice = gasPrice.Mul(gasPrice, big.NewInt(1))
+	auth.Context = i.Context()
+	auth.Nonce = new(big.Int).SetUint64(nonce)
+	auth.Value = big.NewInt(int64(0)) // in wei
+
+	fees, err := i.Tools.SuggestFees(i.Context())
+	if err != nil {
+		return nil, fmt.Errorf("makeAuth, %w", err)
+	}
+	if fees == nil {
+		gasPrice, err := i.backend().SuggestGasPrice(i.Context())
+		if err != nil {
+			return nil, fmt.Errorf("makeAuth, %w", errs.RPC("eth_gasPrice", err))
+		}
+		auth.GasPrice = capGasPrice(gasPrice)
+		return auth, nil
+	}
 
+	chainID, err := i.Tools.GetChainIDContext(i.Context())
+	if err != nil {
+		return nil, fmt.Errorf("makeAuth, %w", errs.RPC("eth_chainId", err))
+	}
+	// gas estimated with the fee cap, which is the most the tx pays
+	auth.GasPrice = fees.FeeCap
+	auth.Signer = i.sender.dynamicSigner(i.PrivateKey, chainID, fees, auth.Signer)
+	log.Debugf("makeAuth, %s", fees)
 	return auth, nil
 }
 
+// waitTxConfirm wait the tx and speed it up if it is stuck, the tx hash is mapped to the replacement
+// if the replacement mined.
 func (i *EthInvoker) waitTxConfirm(hash common.Hash) error {
-	i.Tools.WaitTransactionConfirm(hash)
-	if err := i.DumpTx(hash); err != nil {
+	receipt, err := i.Tools.waitReceipt(i.Context(), []common.Hash{hash}, i.speedUp)
+	if err != nil {
+		return err
+	}
+	if receipt.TxHash != hash {
+		i.sender.replace(hash, receipt.TxHash)
+	}
+	if err := i.DumpTx(receipt.TxHash); err != nil {
 		return err
 	}
 	return nil
 }
 
+func (i *EthInvoker) speedUp(ctx context.Context, hash common.Hash) (common.Hash, error) {
+	return Replace(ctx, i.Tools.GetRPCClient(), i.PrivateKey, hash, false, maxFeeCap)
+}
+
+// SpeedUp replace the pending tx with bumped fees, and wait until the tx or the replacement confirmed.
+// the hash of the confirmed one is returned.
+func (i *EthInvoker) SpeedUp(hash common.Hash) (common.Hash, error) {
+	next, err := i.speedUp(i.Context(), hash)
+	if err != nil {
+		return utils.EmptyHash, err
+	}
+	log.Infof("tx %s replaced by %s with bumped fees", hash.Hex(), next.Hex())
+	receipt, err := i.Tools.waitReceipt(i.Context(), []common.Hash{hash, next}, nil)
+	if err != nil {
+		return utils.EmptyHash, err
+	}
+	return receipt.TxHash, nil
+}
+
+// Cancel replace the pending tx with a zero value self transfer with bumped fees, and wait until the
+// tx or the replacement confirmed. the tx is canceled if the returned hash is not the tx hash.
+func (i *EthInvoker) Cancel(hash common.Hash) (common.Hash, error) {
+	next, err := Replace(i.Context(), i.Tools.GetRPCClient(), i.PrivateKey, hash, true, maxFeeCap)
+	if err != nil {
+		return utils.EmptyHash, err
+	}
+	log.Infof("tx %s replaced by self transfer %s", hash.Hex(), next.Hex())
+	receipt, err := i.Tools.waitReceipt(i.Context(), []common.Hash{hash, next}, nil)
+	if err != nil {
+		return utils.EmptyHash, err
+	}
+	return receipt.TxHash, nil
+}
+
 func (i *EthInvoker) backend() bind.ContractBackend {
-	return i.Tools.GetEthClient()
+	return i.sender
+}
+
+// txHash returns the hash of tx actually sent, the EIP-1559 tx replaces the legacy one built by binding.
+func (i *EthInvoker) txHash(tx *types.Transaction) common.Hash {
+	return i.sender.txHash(tx)
 }
@@ -30,17 +30,21 @@ import (
 	"strings"
 	"time"
 
-	"github.com/ethereum/go-ethereum/accounts"
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/palettechain/deploy-tool/pkg/errs"
 	"github.com/palettechain/deploy-tool/pkg/log"
+	"github.com/palettechain/deploy-tool/pkg/rpcpool"
+	"github.com/palettechain/deploy-tool/pkg/txwait"
 	"github.com/polynetwork/eth-contracts/go_abi/eccm_abi"
 )
 
 type ETHTools struct {
 	restclient *RestClient
+	rpcclient  *rpc.Client
 	ethclient  *ethclient.Client
 }
 
@@ -89,26 +93,58 @@ type BlockRep struct {
 	Id      uint          `json:"id"`
 }
 
-func NewEthTools(url string) *ETHTools {
-	ethclient, err := ethclient.Dial(url)
+func NewEthTools(url string) (*ETHTools, error) {
+	client, err := rpc.Dial(url)
 	if err != nil {
-		log.Error("NewEthTools: cannot dial sync node, err: %s", err)
-		return nil
+		return nil, errs.RPC(fmt.Sprintf("dial %s", url), err)
 	}
 	restclient := NewRestClient()
 	restclient.SetAddr(url)
 	tool := &ETHTools{
 		restclient: restclient,
-		ethclient:  ethclient,
+		rpcclient:  client,
+		ethclient:  ethclient.NewClient(client),
 	}
-	return tool
+	return tool, nil
+}
+
+// NewEthToolsPool dial the ethereum endpoints in pool, requests of both eth client and rest client
+// fail over to another healthy endpoint if the current one can not be reached.
+func NewEthToolsPool(pool *rpcpool.Pool) (*ETHTools, error) {
+	urls := pool.URLs()
+	if len(urls) == 0 {
+		return nil, fmt.Errorf("no ethereum endpoint")
+	}
+	if pool.Single() {
+		return NewEthTools(urls[0])
+	}
+	client, err := rpc.DialHTTPWithClient(urls[0], pool.HTTPClient())
+	if err != nil {
+		return nil, errs.RPC(fmt.Sprintf("dial %s", rpcpool.Endpoints(urls)), err)
+	}
+	restclient := NewRestClient()
+	restclient.SetAddr(urls[0]).SetRestClient(pool.HTTPClient())
+	tool := &ETHTools{
+		restclient: restclient,
+		rpcclient:  client,
+		ethclient:  ethclient.NewClient(client),
+	}
+	return tool, nil
 }
 
 func (s *ETHTools) GetEthClient() *ethclient.Client {
 	return s.ethclient
 }
 
+func (s *ETHTools) GetRPCClient() *rpc.Client {
+	return s.rpcclient
+}
+
 func (s *ETHTools) GetNodeHeight() (uint64, error) {
+	return s.GetNodeHeightContext(context.Background())
+}
+
+func (s *ETHTools) GetNodeHeightContext(ctx context.Context) (uint64, error) {
 	req := &heightReq{
 		JsonRpc: "2.0",
 		Method:  "eth_blockNumber",
@@ -119,7 +155,7 @@ func (s *ETHTools) GetNodeHeight() (uint64, error) {
 	if err != nil {
 		return 0, fmt.Errorf("GetNodeHeight: marshal req err: %s", err)
 	}
-	resp, err := s.restclient.SendRestRequest(data)
+	resp, err := s.restclient.SendRestRequestContext(ctx, data)
 	if err != nil {
 		return 0, fmt.Errorf("GetNodeHeight err: %s", err)
 	}
@@ -137,6 +173,10 @@ func (s *ETHTools) GetNodeHeight() (uint64, error) {
 }
 
 func (s *ETHTools) GetBlockHeader(height uint64) (*types.Header, error) {
+	return s.GetBlockHeaderContext(context.Background(), height)
+}
+
+func (s *ETHTools) GetBlockHeaderContext(ctx context.Context, height uint64) (*types.Header, error) {
 	params := []interface{}{fmt.Sprintf("0x%x", height), true}
 	req := &BlockReq{
 		JsonRpc: "2.0",
@@ -148,7 +188,7 @@ func (s *ETHTools) GetBlockHeader(height uint64) (*types.Header, error) {
 	if err != nil {
 		return nil, fmt.Errorf("GetNodeHeight: marshal req err: %s", err)
 	}
-	resp, err := s.restclient.SendRestRequest(data)
+	resp, err := s.restclient.SendRestRequestContext(ctx, data)
 	if err != nil {
 		return nil, fmt.Errorf("GetNodeHeight err: %s", err)
 	}
@@ -162,10 +202,18 @@ func (s *ETHTools) GetBlockHeader(height uint64) (*types.Header, error) {
 }
 
 func (s *ETHTools) GetChainID() (*big.Int, error) {
-	return s.ethclient.ChainID(context.Background())
+	return s.GetChainIDContext(context.Background())
+}
+
+func (s *ETHTools) GetChainIDContext(ctx context.Context) (*big.Int, error) {
+	return s.ethclient.ChainID(ctx)
 }
 
 func (s *ETHTools) GetSmartContractEventByBlock(contractAddr string, height uint64) ([]*LockEvent, []*UnlockEvent, error) {
+	return s.GetSmartContractEventByBlockContext(context.Background(), contractAddr, height)
+}
+
+func (s *ETHTools) GetSmartContractEventByBlockContext(ctx context.Context, contractAddr string, height uint64) ([]*LockEvent, []*UnlockEvent, error) {
 	eccmAddr := common.HexToAddress(contractAddr)
 	instance, err := eccm_abi.NewEthCrossChainManager(eccmAddr, s.ethclient)
 	if err != nil {
@@ -175,7 +223,7 @@ func (s *ETHTools) GetSmartContractEventByBlock(contractAddr string, height uint
 	opt := &bind.FilterOpts{
 		Start:   height,
 		End:     &height,
-		Context: context.Background(),
+		Context: ctx,
 	}
 
 	ethlockevents := make([]*LockEvent, 0)
@@ -236,82 +284,50 @@ func EncodeBigInt(b *big.Int) string {
 	return hex.EncodeToString(b.Bytes())
 }
 
-type getMoneyReq struct {
-	Address string `json:"address"`
-	Amount  uint   `json:"amount"`
-}
+// waitOptions used to wait ethereum transactions, set by config.
+var waitOptions txwait.Options
 
-type getMoneyRsp struct {
-	Hash   string `json:"tx_hash"`
-	Result string `json:"result"`
+func SetWaitOptions(opts txwait.Options) {
+	waitOptions = opts
 }
 
-func (s *ETHTools) GetMoney(accounts []accounts.Account) []common.Hash {
-	restclient := NewRestClient()
-	restclient.SetAddr("https://api.bitaps.com/eth/testnet/v1/faucet/send/payment")
-	hash := make([]common.Hash, 0)
-	for _, account := range accounts {
-		req := &getMoneyReq{
-			Address: account.Address.String(),
-			Amount:  1000000000000000000,
+func (s *ETHTools) WaitTransactionsConfirm(hashs []common.Hash) error {
+	for _, hash := range hashs {
+		if err := s.WaitTransactionConfirm(hash); err != nil {
+			return err
 		}
-		data, err := json.Marshal(req)
-		if err != nil {
-			log.Errorf("getMoney: marshal req err: %s", err)
-			continue
-		}
-		resp, err := restclient.SendRestRequest([]byte(data))
-		if err != nil {
-			log.Errorf("getMoney err: %s", err)
-			continue
-		}
-		rep := &getMoneyRsp{}
-		err = json.Unmarshal(resp, rep)
-		if err != nil {
-			log.Errorf("getMoney, unmarshal resp err: %s", err)
-			continue
-		}
-		hashBytes := common.HexToHash(rep.Hash)
-		hash = append(hash, hashBytes)
 	}
-	return hash
-}
-
-func (s *ETHTools) WaitTransactionsConfirm(hashs []common.Hash) {
-	hasPending := true
-	for hasPending {
-		time.Sleep(time.Second * 1)
-		hasPending = false
-		for _, hash := range hashs {
-			_, ispending, err := s.ethclient.TransactionByHash(context.Background(), hash)
-			log.Infof("transaction %s is pending: %d", hash.String(), ispending)
-			if err != nil {
-				hasPending = true
-				continue
-			}
-			if ispending == true {
-				hasPending = true
-			} else {
-			}
-		}
+	return nil
+}
+
+// WaitTransactionConfirm wait the receipt of tx with configured confirmations, and returns
+// typed errors defined in `errs` if the tx timeout, reverted, dropped or reorged out.
+func (s *ETHTools) WaitTransactionConfirm(hash common.Hash) error {
+	return s.WaitTransactionConfirmContext(context.Background(), hash)
+}
+
+func (s *ETHTools) WaitTransactionConfirmContext(ctx context.Context, hash common.Hash) error {
+	_, err := s.waitReceipt(ctx, []common.Hash{hash}, nil)
+	return err
+}
+
+// waitReceipt wait the txs with the same nonce until one of them confirmed, and the latest one is
+// replaced by replace if it is stuck longer than `EscalateAfter`.
+func (s *ETHTools) waitReceipt(ctx context.Context, hashes []common.Hash, replace txwait.Replacer) (*types.Receipt, error) {
+	receipt, err := txwait.WaitEscalate(ctx, s.ethclient, hashes, waitOptions, replace)
+	if err != nil {
+		return nil, s.fillRevertReason(ctx, err)
 	}
+	log.Infof("tx %s confirmed at block %d %s", receipt.TxHash.Hex(), receipt.BlockNumber.Uint64(), receipt.BlockHash.Hex())
+	return receipt, nil
 }
 
-func (s *ETHTools) WaitTransactionConfirm(hash common.Hash) {
-	for {
-		time.Sleep(time.Second * 1)
-		_, ispending, err := s.ethclient.TransactionByHash(context.Background(), hash)
-		if err != nil {
-			log.Errorf("failed to call TransactionByHash: %v", err)
-			continue
-		}
-		if ispending == true {
-			continue
-		} else {
-			break
-		}
+func (s *ETHTools) fillRevertReason(ctx context.Context, err error) error {
+	chainID, e := s.GetChainIDContext(ctx)
+	if e != nil {
+		return err
 	}
-	log.Infof("tx %s confirmed", hash.Hex())
+	return FillRevertReason(ctx, s.ethclient, types.NewEIP155Signer(chainID), err)
 }
 
 type RestClient struct {
@@ -353,7 +369,17 @@ func (self *RestClient) SetRestClient(restClient *http.Client) *RestClient {
 }
 
 func (self *RestClient) SendRestRequest(data []byte) ([]byte, error) {
-	resp, err := self.restClient.Post(self.addr, "application/json", strings.NewReader(string(data)))
+	return self.SendRestRequestContext(context.Background(), data)
+}
+
+// SendRestRequestContext posts the data with ctx, the request is aborted once ctx is done.
+func (self *RestClient) SendRestRequestContext(ctx context.Context, data []byte) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, self.addr, strings.NewReader(string(data)))
+	if err != nil {
+		return nil, fmt.Errorf("build http request error:%s", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := self.restClient.Do(req)
 	if err != nil {
 		return nil, fmt.Errorf("http post request:%s error:%s", data, err)
 	}
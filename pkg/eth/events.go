@@ -0,0 +1,208 @@
+/*
+* Copyright (C) 2020 The poly network Authors
+* This file is part of The poly network library.
+*
+* The poly network is free software: you can redistribute it and/or modify
+* it under the terms of the GNU Lesser General Public License as published by
+* the Free Software Foundation, either version 3 of the License, or
+* (at your option) any later version.
+*
+* The poly network is distributed in the hope that it will be useful,
+* but WITHOUT ANY WARRANTY; without even the implied warranty of
+* MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+* GNU Lesser General Public License for more details.
+* You should have received a copy of the GNU Lesser General Public License
+* along with The poly network . If not, see <http://www.gnu.org/licenses/>.
+ */
+package eth
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/palettechain/deploy-tool/pkg/abis"
+	"github.com/palettechain/deploy-tool/pkg/log"
+	"github.com/palettechain/deploy-tool/pkg/scan"
+)
+
+// CrossChainEvent is the cross chain lock or unlock on ethereum, which is assembled from the logs of ECCM and
+// lock proxies in the same tx.
+type CrossChainEvent struct {
+	Method      string      `json:"method"`
+	BlockNumber uint64      `json:"blockNumber"`
+	TxHash      common.Hash `json:"txHash"`
+	// target chain id of lock, and source chain id of unlock
+	ChainID uint64         `json:"chainId"`
+	Proxy   common.Address `json:"proxy"`
+	// tx origin of lock
+	Sender common.Address `json:"sender,omitempty"`
+	// asset and address on ethereum, which are the source of lock and the target of unlock
+	Asset   common.Address `json:"asset"`
+	Address common.Address `json:"address"`
+	// asset and address on the target chain of lock
+	ToAsset   hexutil.Bytes `json:"toAsset,omitempty"`
+	ToAddress hexutil.Bytes `json:"toAddress,omitempty"`
+	// amount of token, or the token id of NFT
+	Amount   *big.Int `json:"amount"`
+	TokenURI string   `json:"tokenURI,omitempty"`
+	// cross chain tx id of lock, the poly tx hash and source chain tx hash of unlock
+	TxID             hexutil.Bytes `json:"txId,omitempty"`
+	CrossChainTxHash hexutil.Bytes `json:"crossChainTxHash,omitempty"`
+	FromChainTxHash  hexutil.Bytes `json:"fromChainTxHash,omitempty"`
+}
+
+func (e *CrossChainEvent) String() string {
+	if e.Method == "unlock" {
+		return fmt.Sprintf("%d %s unlock from chain %d, asset %s, to %s, amount %v, poly tx %s, source tx %s",
+			e.BlockNumber, e.TxHash.Hex(), e.ChainID, e.Asset.Hex(), e.Address.Hex(), e.Amount,
+			e.CrossChainTxHash, e.FromChainTxHash)
+	}
+	return fmt.Sprintf("%d %s lock to chain %d, asset %s, from %s(sender %s), amount %v, to asset %s, to %s",
+		e.BlockNumber, e.TxHash.Hex(), e.ChainID, e.Asset.Hex(), e.Address.Hex(), e.Sender.Hex(), e.Amount,
+		e.ToAsset, e.ToAddress)
+}
+
+// Match returns true if the address is the sender, source or target of the event and the asset is the
+// ethereum or target chain asset, the empty address and asset match any.
+func (e *CrossChainEvent) Match(address, asset common.Address) bool {
+	if address != (common.Address{}) && e.Sender != address && e.Address != address &&
+		common.BytesToAddress(e.ToAddress) != address {
+		return false
+	}
+	if asset != (common.Address{}) && e.Asset != asset && common.BytesToAddress(e.ToAsset) != asset {
+		return false
+	}
+	return true
+}
+
+// NewEventScanner returns the scanner of logs emitted by the contracts, e.g: ECCM and lock proxies.
+func (s *ETHTools) NewEventScanner(contracts ...common.Address) *scan.Scanner {
+	return scan.New(s.ethclient, contracts...)
+}
+
+// CrossChainEvents assemble the cross chain events from the logs of ECCM `CrossChainEvent`,
+// `VerifyHeaderAndExecuteTxEvent` and lock proxy `LockEvent`, `UnlockEvent`. the events are in the
+// order of logs, and one tx is treated as one cross chain event.
+func CrossChainEvents(logs []types.Log) []*CrossChainEvent {
+	list := make([]*CrossChainEvent, 0)
+	txs := make(map[common.Hash]*CrossChainEvent)
+	get := func(l *types.Log) *CrossChainEvent {
+		if e, ok := txs[l.TxHash]; ok {
+			return e
+		}
+		e := &CrossChainEvent{BlockNumber: l.BlockNumber, TxHash: l.TxHash}
+		txs[l.TxHash] = e
+		list = append(list, e)
+		return e
+	}
+
+	for i := range logs {
+		l := &logs[i]
+		if l.Removed {
+			continue
+		}
+		ev, err := abis.DecodeLog(l)
+		if err != nil {
+			continue
+		}
+
+		switch ev.Name {
+		case "CrossChainEvent":
+			e := get(l)
+			e.Method = "lock"
+			e.ChainID = fieldUint64(ev, "toChainId")
+			e.Sender = fieldAddress(ev, "sender")
+			e.Proxy = fieldAddress(ev, "proxyOrAssetContract")
+			e.TxID = fieldBytes(ev, "txId")
+			fillTxArgs(e, fieldBytes(ev, "rawdata"))
+		case "VerifyHeaderAndExecuteTxEvent":
+			e := get(l)
+			e.Method = "unlock"
+			e.ChainID = fieldUint64(ev, "fromChainID")
+			e.Proxy = common.BytesToAddress(fieldBytes(ev, "toContract"))
+			e.CrossChainTxHash = fieldBytes(ev, "crossChainTxHash")
+			e.FromChainTxHash = fieldBytes(ev, "fromChainTxHash")
+		case "LockEvent":
+			e := get(l)
+			e.Proxy = l.Address
+			e.Asset = fieldAddress(ev, "fromAssetHash")
+			e.Address = fieldAddress(ev, "fromAddress")
+			e.ToAsset = fieldBytes(ev, "toAssetHash")
+			e.ToAddress = fieldBytes(ev, "toAddress")
+			e.Amount = fieldAmount(ev)
+		case "UnlockEvent":
+			e := get(l)
+			e.Proxy = l.Address
+			e.Asset = fieldAddress(ev, "toAssetHash")
+			e.Address = fieldAddress(ev, "toAddress")
+			e.Amount = fieldAmount(ev)
+		}
+	}
+
+	// txs with lock proxy events only are not cross chain events, e.g: emitted by other contracts
+	events := make([]*CrossChainEvent, 0, len(list))
+	for _, e := range list {
+		if e.Method != "" {
+			events = append(events, e)
+		}
+	}
+	return events
+}
+
+// fillTxArgs decode the tx param in `CrossChainEvent`, and fill the target asset, address and amount which
+// are not set by lock proxy events.
+func fillTxArgs(e *CrossChainEvent, raw []byte) {
+	param, err := scan.DecodeTxParam(raw)
+	if err != nil {
+		log.Debugf("decode tx param of %s failed, err: %v", e.TxHash.Hex(), err)
+		return
+	}
+	args, err := scan.DecodeTxArgs(param.Args)
+	if err != nil {
+		log.Debugf("decode tx args of %s method %s failed, err: %v", e.TxHash.Hex(), param.Method, err)
+		return
+	}
+	if len(e.ToAsset) == 0 {
+		e.ToAsset = args.ToAsset
+	}
+	if len(e.ToAddress) == 0 {
+		e.ToAddress = args.ToAddress
+	}
+	if e.Amount == nil {
+		e.Amount = args.Amount
+	}
+	e.TokenURI = string(args.TokenURI)
+}
+
+func fieldAddress(ev *abis.Event, name string) common.Address {
+	addr, _ := ev.Field(name).(common.Address)
+	return addr
+}
+
+func fieldBytes(ev *abis.Event, name string) hexutil.Bytes {
+	enc, _ := ev.Field(name).(hexutil.Bytes)
+	return enc
+}
+
+func fieldUint64(ev *abis.Event, name string) uint64 {
+	switch v := ev.Field(name).(type) {
+	case uint64:
+		return v
+	case *big.Int:
+		return v.Uint64()
+	}
+	return 0
+}
+
+// fieldAmount returns the amount of token, or the token id of NFT lock proxy events.
+func fieldAmount(ev *abis.Event) *big.Int {
+	for _, name := range []string{"amount", "tokenId"} {
+		if v, ok := ev.Field(name).(*big.Int); ok {
+			return v
+		}
+	}
+	return nil
+}
@@ -0,0 +1,199 @@
+/*
+* Copyright (C) 2020 The poly network Authors
+* This file is part of The poly network library.
+*
+* The poly network is free software: you can redistribute it and/or modify
+* it under the terms of the GNU Lesser General Public License as published by
+* the Free Software Foundation, either version 3 of the License, or
+* (at your option) any later version.
+*
+* The poly network is distributed in the hope that it will be useful,
+* but WITHOUT ANY WARRANTY; without even the implied warranty of
+* MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+* GNU Lesser General Public License for more details.
+* You should have received a copy of the GNU Lesser General Public License
+* along with The poly network . If not, see <http://www.gnu.org/licenses/>.
+ */
+package eth
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"fmt"
+	"math/big"
+	"sort"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/palettechain/deploy-tool/pkg/errs"
+)
+
+const (
+	// DefaultGasMargin is the percent added to the estimated gas limit.
+	DefaultGasMargin uint64 = 20
+
+	// number of recent blocks and the reward percentile to suggest the priority fee.
+	feeHistoryBlocks     = 20
+	feeHistoryPercentile = 50
+
+	dynamicFeeTxType = 2
+)
+
+var (
+	maxFeeCap *big.Int
+	maxTipCap *big.Int
+	gasMargin = DefaultGasMargin
+)
+
+// SetFeePolicy set the caps of max fee and priority fee per gas of ethereum transactions, no cap if
+// it is nil. margin is the percent added to the estimated gas, default margin used if it is 0.
+func SetFeePolicy(maxFee, maxPriorityFee *big.Int, margin uint64) {
+	maxFeeCap = maxFee
+	maxTipCap = maxPriorityFee
+	if margin > 0 {
+		gasMargin = margin
+	} else {
+		gasMargin = DefaultGasMargin
+	}
+}
+
+// Fees is the fee per gas of EIP-1559 transaction, the tx pays base fee plus tip and no more than
+// fee cap.
+type Fees struct {
+	BaseFee *big.Int
+	TipCap  *big.Int
+	FeeCap  *big.Int
+}
+
+func (f *Fees) String() string {
+	return fmt.Sprintf("base fee %s tip cap %s fee cap %s", f.BaseFee, f.TipCap, f.FeeCap)
+}
+
+// SuggestFees derive the fees from the base fee of the pending block and the median reward of recent
+// blocks, and returns nil if the chain is not upgraded to London, legacy gas price should be used.
+func (s *ETHTools) SuggestFees(ctx context.Context) (*Fees, error) {
+	return suggestFees(ctx, s.rpcclient)
+}
+
+func suggestFees(ctx context.Context, caller RPCCaller) (*Fees, error) {
+	var head struct {
+		BaseFee *hexutil.Big `json:"baseFeePerGas"`
+	}
+	if err := caller.CallContext(ctx, &head, "eth_getBlockByNumber", "latest", false); err != nil {
+		return nil, errs.RPC("eth_getBlockByNumber", err)
+	}
+	if head.BaseFee == nil {
+		return nil, nil
+	}
+
+	var history struct {
+		BaseFee []*hexutil.Big   `json:"baseFeePerGas"`
+		Reward  [][]*hexutil.Big `json:"reward"`
+	}
+	err := caller.CallContext(ctx, &history, "eth_feeHistory",
+		hexutil.Uint64(feeHistoryBlocks), "latest", []float64{feeHistoryPercentile})
+	if err != nil {
+		// nodes without fee history, e.g: the old version geth
+		var tip hexutil.Big
+		if err := caller.CallContext(ctx, &tip, "eth_maxPriorityFeePerGas"); err != nil {
+			return nil, errs.RPC("eth_maxPriorityFeePerGas", err)
+		}
+		return calcFees(head.BaseFee.ToInt(), tip.ToInt())
+	}
+
+	// the last base fee is the one of pending block
+	baseFee := head.BaseFee.ToInt()
+	if n := len(history.BaseFee); n > 0 && history.BaseFee[n-1] != nil {
+		baseFee = history.BaseFee[n-1].ToInt()
+	}
+	rewards := make([]*big.Int, 0, len(history.Reward))
+	for _, reward := range history.Reward {
+		if len(reward) > 0 && reward[0] != nil {
+			rewards = append(rewards, reward[0].ToInt())
+		}
+	}
+	return calcFees(baseFee, median(rewards))
+}
+
+// calcFees returns fee cap of 2 * base fee + tip, so that the tx is still valid after several full
+// blocks, and the caps configured are applied.
+func calcFees(baseFee, tip *big.Int) (*Fees, error) {
+	tip = new(big.Int).Set(tip)
+	if maxTipCap != nil && tip.Cmp(maxTipCap) > 0 {
+		tip.Set(maxTipCap)
+	}
+	feeCap := new(big.Int).Mul(baseFee, big.NewInt(2))
+	feeCap.Add(feeCap, tip)
+	if maxFeeCap != nil && feeCap.Cmp(maxFeeCap) > 0 {
+		if baseFee.Cmp(maxFeeCap) > 0 {
+			return nil, fmt.Errorf("base fee %s exceeds the max fee %s", baseFee, maxFeeCap)
+		}
+		feeCap.Set(maxFeeCap)
+	}
+	if tip.Cmp(feeCap) > 0 {
+		tip.Set(feeCap)
+	}
+	return &Fees{BaseFee: new(big.Int).Set(baseFee), TipCap: tip, FeeCap: feeCap}, nil
+}
+
+// capGasPrice applies the max fee cap to the legacy gas price.
+func capGasPrice(gasPrice *big.Int) *big.Int {
+	if maxFeeCap != nil && gasPrice.Cmp(maxFeeCap) > 0 {
+		return new(big.Int).Set(maxFeeCap)
+	}
+	return gasPrice
+}
+
+func median(list []*big.Int) *big.Int {
+	if len(list) == 0 {
+		return new(big.Int)
+	}
+	sorted := make([]*big.Int, len(list))
+	copy(sorted, list)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].Cmp(sorted[j]) < 0
+	})
+	return new(big.Int).Set(sorted[len(sorted)/2])
+}
+
+// dynamicFeeTx is the EIP-1559 transaction, the geth version in use only knows legacy transactions,
+// so it is encoded and signed here.
+type dynamicFeeTx struct {
+	ChainID *big.Int
+	Nonce   uint64
+	TipCap  *big.Int
+	FeeCap  *big.Int
+	Gas     uint64
+	To      *common.Address
+	Value   *big.Int
+	Data    []byte
+}
+
+func (tx *dynamicFeeTx) fields() []interface{} {
+	// empty access list
+	return []interface{}{tx.ChainID, tx.Nonce, tx.TipCap, tx.FeeCap, tx.Gas, tx.To, tx.Value, tx.Data, []interface{}{}}
+}
+
+// sign returns the raw typed transaction and its hash.
+func (tx *dynamicFeeTx) sign(key *ecdsa.PrivateKey) ([]byte, common.Hash, error) {
+	enc, err := rlp.EncodeToBytes(tx.fields())
+	if err != nil {
+		return nil, common.Hash{}, err
+	}
+	sigHash := crypto.Keccak256(append([]byte{dynamicFeeTxType}, enc...))
+	sig, err := crypto.Sign(sigHash, key)
+	if err != nil {
+		return nil, common.Hash{}, err
+	}
+
+	r := new(big.Int).SetBytes(sig[:32])
+	s := new(big.Int).SetBytes(sig[32:64])
+	v := new(big.Int).SetUint64(uint64(sig[64]))
+	if enc, err = rlp.EncodeToBytes(append(tx.fields(), v, r, s)); err != nil {
+		return nil, common.Hash{}, err
+	}
+	raw := append([]byte{dynamicFeeTxType}, enc...)
+	return raw, crypto.Keccak256Hash(raw), nil
+}
@@ -0,0 +1,37 @@
+package eth
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCalcFees(t *testing.T) {
+	defer SetFeePolicy(nil, nil, 0)
+
+	fees, err := calcFees(big.NewInt(100), big.NewInt(2))
+	assert.NoError(t, err)
+	assert.Equal(t, big.NewInt(202), fees.FeeCap)
+	assert.Equal(t, big.NewInt(2), fees.TipCap)
+
+	// tip and fee cap are capped
+	SetFeePolicy(big.NewInt(150), big.NewInt(1), 0)
+	fees, err = calcFees(big.NewInt(100), big.NewInt(2))
+	assert.NoError(t, err)
+	assert.Equal(t, big.NewInt(150), fees.FeeCap)
+	assert.Equal(t, big.NewInt(1), fees.TipCap)
+	assert.Equal(t, big.NewInt(150), capGasPrice(big.NewInt(300)))
+
+	// the tx never included if base fee exceeds the cap
+	_, err = calcFees(big.NewInt(200), big.NewInt(2))
+	assert.Error(t, err)
+}
+
+func TestMedian(t *testing.T) {
+	assert.Equal(t, new(big.Int), median(nil))
+	list := []*big.Int{big.NewInt(3), big.NewInt(1), big.NewInt(2)}
+	assert.Equal(t, big.NewInt(2), median(list))
+	// the input is not sorted in place
+	assert.Equal(t, big.NewInt(3), list[0])
+}
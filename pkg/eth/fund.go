@@ -0,0 +1,120 @@
+/*
+* Copyright (C) 2020 The poly network Authors
+* This file is part of The poly network library.
+*
+* The poly network is free software: you can redistribute it and/or modify
+* it under the terms of the GNU Lesser General Public License as published by
+* the Free Software Foundation, either version 3 of the License, or
+* (at your option) any later version.
+*
+* The poly network is distributed in the hope that it will be useful,
+* but WITHOUT ANY WARRANTY; without even the implied warranty of
+* MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+* GNU Lesser General Public License for more details.
+* You should have received a copy of the GNU Lesser General Public License
+* along with The poly network . If not, see <http://www.gnu.org/licenses/>.
+ */
+package eth
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/palettechain/deploy-tool/pkg/errs"
+	"github.com/palettechain/deploy-tool/pkg/log"
+)
+
+// Fund transfer amount of native currency to every address, the nonces are reserved in one range and
+// the txs are waited after all of them sent. the confirmed tx hashes are returned in the order of
+// addresses, and the hashes sent before the failure are returned with the error.
+func (i *EthInvoker) Fund(to []common.Address, amount *big.Int) ([]common.Hash, error) {
+	if len(to) == 0 {
+		return nil, nil
+	}
+	if amount == nil || amount.Sign() <= 0 {
+		return nil, fmt.Errorf("invalid fund amount %v", amount)
+	}
+
+	nm, err := i.nonceManager()
+	if err != nil {
+		return nil, err
+	}
+	from := i.Address()
+	first, err := nm.Reserve(i.Context(), from, len(to))
+	if err != nil {
+		return nil, err
+	}
+
+	hashes := make([]common.Hash, 0, len(to))
+	for k, addr := range to {
+		hash, err := i.sendValue(first+uint64(k), addr, amount)
+		if err != nil {
+			if IsNonceError(err) {
+				log.Debugf("%s nonce %d is stale, resync nonce, err: %v", from.Hex(), first+uint64(k), err)
+				nm.Resync(from)
+			}
+			// release the unsent nonces from the last one, so that they are rolled back
+			for j := len(to) - 1; j >= k; j-- {
+				nm.Release(from, first+uint64(j))
+			}
+			return hashes, fmt.Errorf("fund %s failed, err: %w", addr.Hex(), err)
+		}
+		nm.Sent(from)
+		log.Infof("fund %s %s wei, tx %s", addr.Hex(), amount, hash.Hex())
+		hashes = append(hashes, hash)
+	}
+
+	for k, hash := range hashes {
+		receipt, err := i.Tools.waitReceipt(i.Context(), []common.Hash{hash}, i.speedUp)
+		if err != nil {
+			return hashes, fmt.Errorf("fund %s failed, err: %w", to[k].Hex(), err)
+		}
+		hashes[k] = receipt.TxHash
+	}
+	return hashes, nil
+}
+
+// sendValue send plain transfer with the reserved nonce.
+func (i *EthInvoker) sendValue(nonce uint64, to common.Address, amount *big.Int) (common.Hash, error) {
+	auth, err := i.makeAuth(nonce)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	auth.Value = new(big.Int).Set(amount)
+	tx, err := transferValue(i.backend(), auth, to)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	return i.txHash(tx), nil
+}
+
+// transferValue sign and send the plain transfer of `auth.Value`. the bound contract is not used, because
+// it refuses the target without code, e.g: EOA and hd wallet accounts.
+func transferValue(backend bind.ContractBackend, auth *bind.TransactOpts, to common.Address) (*types.Transaction, error) {
+	gasLimit := auth.GasLimit
+	if gasLimit == 0 {
+		var err error
+		gasLimit, err = backend.EstimateGas(auth.Context, ethereum.CallMsg{
+			From:     auth.From,
+			To:       &to,
+			GasPrice: auth.GasPrice,
+			Value:    auth.Value,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("estimate transfer gas failed, err: %w", errs.RPC("eth_estimateGas", err))
+		}
+	}
+	tx := types.NewTransaction(auth.Nonce.Uint64(), to, auth.Value, gasLimit, auth.GasPrice, nil)
+	signed, err := auth.Signer(types.HomesteadSigner{}, auth.From, tx)
+	if err != nil {
+		return nil, err
+	}
+	if err := backend.SendTransaction(auth.Context, signed); err != nil {
+		return nil, err
+	}
+	return signed, nil
+}
@@ -0,0 +1,47 @@
+package eth
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind/backends"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTransferValueToEOA(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	assert.NoError(t, err)
+	from := crypto.PubkeyToAddress(key.PublicKey)
+	sim := backends.NewSimulatedBackend(core.GenesisAlloc{from: {Balance: big.NewInt(1e18)}}, 8000000)
+	defer sim.Close()
+
+	ctx := context.Background()
+	to := common.HexToAddress("0x000000000000000000000000000000000000abcd")
+	auth := bind.NewKeyedTransactor(key)
+	auth.Context = ctx
+	auth.Nonce = big.NewInt(0)
+	auth.GasPrice = big.NewInt(1)
+	auth.Value = big.NewInt(1000)
+
+	// the bound contract refuses the target without code
+	_, err = bind.NewBoundContract(to, abi.ABI{}, sim, sim, sim).Transfer(auth)
+	assert.Equal(t, bind.ErrNoCode, err)
+
+	tx, err := transferValue(sim, auth, to)
+	assert.NoError(t, err)
+	sim.Commit()
+
+	receipt, err := sim.TransactionReceipt(ctx, tx.Hash())
+	assert.NoError(t, err)
+	assert.Equal(t, types.ReceiptStatusSuccessful, receipt.Status)
+	balance, err := sim.BalanceAt(ctx, to, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, big.NewInt(1000), balance)
+}
@@ -18,33 +18,68 @@ package eth
 
 import (
 	"context"
+	"fmt"
+	"math/big"
+	"strings"
 	"sync"
 	"time"
 
 	"github.com/ethereum/go-ethereum/common"
-	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/palettechain/deploy-tool/pkg/errs"
 	"github.com/palettechain/deploy-tool/pkg/log"
+	"github.com/palettechain/deploy-tool/pkg/txwait"
 )
 
 const ClearNonceInterval = 10 * time.Minute
 
+// NonceSource fetch the pending and mined nonce of account, which is implemented by `ethclient.Client`.
+type NonceSource interface {
+	PendingNonceAt(ctx context.Context, account common.Address) (uint64, error)
+	NonceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (uint64, error)
+}
+
 type NonceManager struct {
 	addressNonce map[common.Address]uint64
-	ethClient    *ethclient.Client
-	lock         sync.RWMutex
+	// number of reserved nonces which are neither sent nor released
+	outstanding map[common.Address]int
+	// addresses resynced from node once there are no outstanding nonces
+	stale     map[common.Address]bool
+	ethClient NonceSource
+	lock      sync.RWMutex
 }
 
-func NewNonceManager(ethClient *ethclient.Client) *NonceManager {
+func NewNonceManager(ethClient NonceSource) *NonceManager {
 	nonceManager := &NonceManager{
 		addressNonce: make(map[common.Address]uint64),
+		outstanding:  make(map[common.Address]int),
+		stale:        make(map[common.Address]bool),
 		ethClient:    ethClient,
 	}
 	go nonceManager.clearNonce()
 	return nonceManager
 }
 
+var (
+	sharedNonceManagers = make(map[string]*NonceManager)
+	sharedLock          sync.Mutex
+)
+
+// SharedNonceManager returns the nonce manager of chain, clients of the same chain share one
+// nonce manager, so that txs of the same address sent by different clients never collide.
+func SharedNonceManager(chain string, source NonceSource) *NonceManager {
+	sharedLock.Lock()
+	defer sharedLock.Unlock()
+
+	if nm, ok := sharedNonceManagers[chain]; ok {
+		return nm
+	}
+	nm := NewNonceManager(source)
+	sharedNonceManagers[chain] = nm
+	return nm
+}
+
 // return account nonce, and than nonce++
-func (this *NonceManager) GetAddressNonce(address common.Address) uint64 {
+func (this *NonceManager) GetAddressNonce(ctx context.Context, address common.Address) uint64 {
 	this.lock.Lock()
 	defer this.lock.Unlock()
 
@@ -52,7 +87,7 @@ func (this *NonceManager) GetAddressNonce(address common.Address) uint64 {
 	nonce, ok := this.addressNonce[address]
 	if !ok {
 		// get nonce from eth network
-		uintNonce, err := this.ethClient.PendingNonceAt(context.Background(), address)
+		uintNonce, err := this.ethClient.PendingNonceAt(ctx, address)
 		if err != nil {
 			log.Infof("GetAddressNonce: cannot get account %s nonce, err: %s, set it to nil!",
 				address, err)
@@ -65,25 +100,159 @@ func (this *NonceManager) GetAddressNonce(address common.Address) uint64 {
 	return nonce
 }
 
+// Reserve returns the first nonce of a contiguous range with n nonces, and the pending nonce
+// fetched from the node if the address has no cached nonce. every reserved nonce is outstanding
+// until it is `Sent` or `Release`d. the pending nonce is fetched with ctx, so that it is canceled with
+// the running method.
+func (this *NonceManager) Reserve(ctx context.Context, address common.Address, n int) (uint64, error) {
+	if n <= 0 {
+		return 0, fmt.Errorf("invalid nonce range %d", n)
+	}
+
+	this.lock.Lock()
+	defer this.lock.Unlock()
+
+	nonce, ok := this.addressNonce[address]
+	if !ok {
+		pending, err := this.ethClient.PendingNonceAt(ctx, address)
+		if err != nil {
+			return 0, fmt.Errorf("get account %s pending nonce failed, err: %w", address.Hex(), errs.RPC("eth_getTransactionCount", err))
+		}
+		nonce = pending
+	}
+	this.addressNonce[address] = nonce + uint64(n)
+	this.outstanding[address] += n
+	return nonce, nil
+}
+
+// CheckPending returns `errs.PendingError` if the address has txs pending in mempool before its first tx
+// of the retried attempt, see `txwait.WithRetry`. the failed attempt may have sent the tx which is not
+// mined yet, and sending again may duplicate it. the cached nonce is resynced once nothing is pending,
+// in case the tx of the failed attempt is dropped.
+func (this *NonceManager) CheckPending(ctx context.Context, address common.Address) error {
+	if !txwait.FirstRetrySend(ctx, address) {
+		return nil
+	}
+	pending, err := this.ethClient.PendingNonceAt(ctx, address)
+	if err != nil {
+		return fmt.Errorf("get account %s pending nonce failed, err: %w", address.Hex(), errs.RPC("eth_getTransactionCount", err))
+	}
+	latest, err := this.ethClient.NonceAt(ctx, address, nil)
+	if err != nil {
+		return fmt.Errorf("get account %s nonce failed, err: %w", address.Hex(), errs.RPC("eth_getTransactionCount", err))
+	}
+	if pending > latest {
+		return &errs.PendingError{From: address, Pending: pending, Latest: latest}
+	}
+	this.Resync(address)
+	return nil
+}
+
+// Sent settle the reserved nonce of tx which has been sent.
+func (this *NonceManager) Sent(address common.Address) {
+	this.lock.Lock()
+	defer this.lock.Unlock()
+
+	this.settle(address)
+}
+
+// Release give back the nonce of tx which failed to be sent. the nonce is rolled back if it is the
+// last one reserved, otherwise the address is resynced from node to avoid the nonce gap, once the
+// other outstanding nonces settled.
+func (this *NonceManager) Release(address common.Address, nonce uint64) {
+	this.lock.Lock()
+	defer this.lock.Unlock()
+
+	if next, ok := this.addressNonce[address]; ok && next == nonce+1 && !this.stale[address] {
+		this.decrease(address)
+	} else {
+		this.stale[address] = true
+	}
+	this.settle(address)
+}
+
+// Resync drop the cached nonce if there are no outstanding nonces, otherwise it is dropped after they
+// settled, and the next reservation fetch the pending nonce from node.
+func (this *NonceManager) Resync(address common.Address) {
+	this.lock.Lock()
+	defer this.lock.Unlock()
+
+	this.stale[address] = true
+	if this.outstanding[address] == 0 {
+		this.drop(address)
+	}
+}
+
 func (this *NonceManager) DecreaseAddressNonce(address common.Address) {
 	this.lock.Lock()
 	defer this.lock.Unlock()
 
+	this.decrease(address)
+}
+
+func (this *NonceManager) decrease(address common.Address) {
 	nonce, ok := this.addressNonce[address]
 	if ok && nonce > 0 {
 		this.addressNonce[address]--
 	}
 }
 
-// clear nonce per
+// settle one outstanding nonce, and drop the stale cached nonce if it is the last one.
+func (this *NonceManager) settle(address common.Address) {
+	if this.outstanding[address] > 0 {
+		this.outstanding[address]--
+	}
+	if this.outstanding[address] > 0 {
+		return
+	}
+	delete(this.outstanding, address)
+	if this.stale[address] {
+		this.drop(address)
+	}
+}
+
+func (this *NonceManager) drop(address common.Address) {
+	delete(this.addressNonce, address)
+	delete(this.stale, address)
+}
+
+// clearNonce drop the cached nonce of addresses without outstanding nonces periodically, so that the
+// txs sent by others are noticed. the addresses with txs in flight are kept.
 func (this *NonceManager) clearNonce() {
 	for {
 		<-time.After(ClearNonceInterval)
-		this.lock.Lock()
-		for addr := range this.addressNonce {
-			delete(this.addressNonce, addr)
+		this.clearIdle()
+	}
+}
+
+func (this *NonceManager) clearIdle() {
+	this.lock.Lock()
+	defer this.lock.Unlock()
+
+	for addr := range this.addressNonce {
+		if this.outstanding[addr] == 0 {
+			this.drop(addr)
+		}
+	}
+}
+
+var nonceErrors = []string{
+	"nonce too low",
+	"known transaction",
+	"already known",
+}
+
+// IsNonceError returns true if the tx rejected by node because the nonce is stale, and the cached
+// nonce should be resynced.
+func IsNonceError(err error) bool {
+	if err == nil {
+		return false
+	}
+	msg := strings.ToLower(err.Error())
+	for _, e := range nonceErrors {
+		if strings.Contains(msg, e) {
+			return true
 		}
-		this.lock.Unlock()
-		//log.Infof("clearNonce: clear all cache nonce")
 	}
+	return false
 }
@@ -0,0 +1,175 @@
+package eth
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"sync"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/palettechain/deploy-tool/pkg/errs"
+	"github.com/palettechain/deploy-tool/pkg/txwait"
+	"github.com/stretchr/testify/assert"
+)
+
+type testNonceSource struct {
+	pending uint64
+	latest  uint64
+}
+
+func (s *testNonceSource) PendingNonceAt(ctx context.Context, account common.Address) (uint64, error) {
+	if err := ctx.Err(); err != nil {
+		return 0, err
+	}
+	return s.pending, nil
+}
+
+func (s *testNonceSource) NonceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (uint64, error) {
+	return s.latest, nil
+}
+
+func TestNonceManagerReserve(t *testing.T) {
+	src := &testNonceSource{pending: 5}
+	nm := NewNonceManager(src)
+	addr := common.HexToAddress("0x01")
+
+	first, err := nm.Reserve(context.Background(), addr, 3)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(5), first)
+
+	next, err := nm.Reserve(context.Background(), addr, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(8), next)
+
+	// the last reserved nonce is reused after released
+	nm.Release(addr, next)
+	next, err = nm.Reserve(context.Background(), addr, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(8), next)
+
+	// release nonce in the middle of range resync from pending nonce, after the outstanding
+	// nonces 5, 7, 8 and 9 sent
+	src.pending = 7
+	nm.Release(addr, 6)
+	next, err = nm.Reserve(context.Background(), addr, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(9), next)
+	for i := 0; i < 4; i++ {
+		nm.Sent(addr)
+	}
+	next, err = nm.Reserve(context.Background(), addr, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(7), next)
+
+	// resync is deferred until the outstanding nonce 7 sent
+	src.pending = 20
+	nm.Resync(addr)
+	next, err = nm.Reserve(context.Background(), addr, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(8), next)
+	nm.Sent(addr)
+	nm.Sent(addr)
+	next, err = nm.Reserve(context.Background(), addr, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(20), next)
+
+	_, err = nm.Reserve(context.Background(), addr, 0)
+	assert.Error(t, err)
+
+	// the pending nonce is fetched with the context of caller
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = nm.Reserve(ctx, common.HexToAddress("0x02"), 1)
+	assert.Error(t, err)
+}
+
+func TestNonceManagerClear(t *testing.T) {
+	src := &testNonceSource{pending: 5}
+	nm := NewNonceManager(src)
+	idle, busy := common.HexToAddress("0x01"), common.HexToAddress("0x02")
+
+	_, err := nm.Reserve(context.Background(), idle, 1)
+	assert.NoError(t, err)
+	nm.Sent(idle)
+	_, err = nm.Reserve(context.Background(), busy, 1)
+	assert.NoError(t, err)
+
+	// only the cached nonce of idle address is cleared
+	nm.clearIdle()
+
+	src.pending = 10
+	next, err := nm.Reserve(context.Background(), idle, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(10), next)
+	next, err = nm.Reserve(context.Background(), busy, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(6), next)
+}
+
+func TestNonceManagerParallel(t *testing.T) {
+	nm := NewNonceManager(&testNonceSource{})
+	addr := common.HexToAddress("0x01")
+
+	var (
+		lock sync.Mutex
+		seen = make(map[uint64]bool)
+		wg   sync.WaitGroup
+	)
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			nonce, err := nm.Reserve(context.Background(), addr, 1)
+			assert.NoError(t, err)
+			nm.Sent(addr)
+			lock.Lock()
+			seen[nonce] = true
+			lock.Unlock()
+		}()
+	}
+	wg.Wait()
+	assert.Equal(t, 50, len(seen))
+}
+
+func TestSharedNonceManager(t *testing.T) {
+	src := &testNonceSource{}
+	assert.True(t, SharedNonceManager("test-1", src) == SharedNonceManager("test-1", src))
+	assert.True(t, SharedNonceManager("test-1", src) != SharedNonceManager("test-2", src))
+}
+
+func TestIsNonceError(t *testing.T) {
+	assert.True(t, IsNonceError(errors.New("failed to send raw transaction: [nonce too low]")))
+	assert.True(t, IsNonceError(errors.New("known transaction: 0x1234")))
+	assert.True(t, IsNonceError(errors.New("already known")))
+	assert.False(t, IsNonceError(errors.New("execution reverted")))
+	assert.False(t, IsNonceError(nil))
+}
+
+func TestNonceManagerCheckPending(t *testing.T) {
+	src := &testNonceSource{pending: 6, latest: 5}
+	nm := NewNonceManager(src)
+	addr := common.HexToAddress("0x01")
+
+	// only the retried attempt is checked
+	assert.NoError(t, nm.CheckPending(context.Background(), addr))
+
+	ctx := txwait.WithRetry(context.Background())
+	err := nm.CheckPending(ctx, addr)
+	assert.True(t, errs.IsPending(err))
+	assert.Equal(t, errs.KindTimeout, errs.Classify(err))
+	// the following txs of the same attempt are not checked
+	assert.NoError(t, nm.CheckPending(ctx, addr))
+
+	// the cached nonce is resynced once the tx of the failed attempt dropped
+	first, err := nm.Reserve(context.Background(), addr, 2)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(6), first)
+	nm.Sent(addr)
+	nm.Sent(addr)
+	src.pending = 5
+	assert.NoError(t, nm.CheckPending(txwait.WithRetry(context.Background()), addr))
+	next, err := nm.Reserve(context.Background(), addr, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(5), next)
+}
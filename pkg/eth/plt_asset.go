@@ -0,0 +1,175 @@
+/*
+* Copyright (C) 2020 The poly network Authors
+* This file is part of The poly network library.
+*
+* The poly network is free software: you can redistribute it and/or modify
+* it under the terms of the GNU Lesser General Public License as published by
+* the Free Software Foundation, either version 3 of the License, or
+* (at your option) any later version.
+*
+* The poly network is distributed in the hope that it will be useful,
+* but WITHOUT ANY WARRANTY; without even the implied warranty of
+* MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+* GNU Lesser General Public License for more details.
+* You should have received a copy of the GNU Lesser General Public License
+* along with The poly network . If not, see <http://www.gnu.org/licenses/>.
+ */
+package eth
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/contracts/native/utils"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/palettechain/deploy-tool/pkg/abis"
+	"github.com/palettechain/deploy-tool/pkg/batch"
+	"github.com/palettechain/deploy-tool/pkg/errs"
+)
+
+var (
+	pltAssetOnce sync.Once
+	pltAssetABI  abi.ABI
+	pltAssetErr  error
+)
+
+// loadPLTAssetABI parse the PLT asset abi once, and the error is returned by every call if it is invalid.
+func loadPLTAssetABI() (abi.ABI, error) {
+	pltAssetOnce.Do(func() {
+		if pltAssetABI, pltAssetErr = abi.JSON(strings.NewReader(abis.PLTAssetABI)); pltAssetErr != nil {
+			pltAssetErr = fmt.Errorf("read PLT asset abi failed, err: %v", pltAssetErr)
+		}
+	})
+	return pltAssetABI, pltAssetErr
+}
+
+// ParseContractBin returns the creation bytecode in compiled artifact, which is the json file of hardhat,
+// truffle or foundry with the `bytecode` field, or the bytecode in hex.
+func ParseContractBin(artifact []byte) ([]byte, error) {
+	artifact = bytes.TrimSpace(artifact)
+	code := string(artifact)
+	if bytes.HasPrefix(artifact, []byte("{")) {
+		var out struct {
+			Bytecode json.RawMessage `json:"bytecode"`
+		}
+		if err := json.Unmarshal(artifact, &out); err != nil {
+			return nil, fmt.Errorf("invalid artifact json, err: %v", err)
+		}
+		// foundry puts the bytecode in `bytecode.object`
+		var object struct {
+			Object string `json:"object"`
+		}
+		if err := json.Unmarshal(out.Bytecode, &code); err != nil {
+			if err := json.Unmarshal(out.Bytecode, &object); err != nil {
+				return nil, fmt.Errorf("invalid artifact bytecode, err: %v", err)
+			}
+			code = object.Object
+		}
+	}
+
+	if !strings.HasPrefix(code, "0x") && !strings.HasPrefix(code, "0X") {
+		code = "0x" + code
+	}
+	bin, err := hexutil.Decode(code)
+	if err != nil {
+		return nil, fmt.Errorf("invalid bytecode, err: %v", err)
+	}
+	if len(bin) == 0 {
+		return nil, fmt.Errorf("empty bytecode")
+	}
+	return bin, nil
+}
+
+// DeployPLTAsset deploy the PLT ERC20 token with the creation bytecode, the token is owned by the sender.
+func (i *EthInvoker) DeployPLTAsset(bin []byte) (common.Address, error) {
+	parsed, err := loadPLTAssetABI()
+	if err != nil {
+		return utils.EmptyAddress, err
+	}
+	contractAddr, tx, err := i.deploy(func(auth *bind.TransactOpts) (addr common.Address, tx *types.Transaction, err error) {
+		addr, tx, _, err = bind.DeployContract(auth, parsed, bin, i.backend())
+		return
+	})
+	if err != nil {
+		return utils.EmptyAddress, err
+	}
+	if err := i.waitTxConfirm(i.txHash(tx)); err != nil {
+		return utils.EmptyAddress, err
+	}
+	return contractAddr, nil
+}
+
+// TransferPLTAssetOwnership start the ownership transfer of PLT asset, and the new owner is pending
+// until it accepts the ownership.
+func (i *EthInvoker) TransferPLTAssetOwnership(asset, newOwner common.Address) (common.Hash, error) {
+	return i.transactPLTAsset(asset, "transferOwnership", newOwner)
+}
+
+// AcceptPLTAssetOwnership accept the pending ownership of PLT asset, the sender should be the pending owner.
+func (i *EthInvoker) AcceptPLTAssetOwnership(asset common.Address) (common.Hash, error) {
+	return i.transactPLTAsset(asset, "acceptOwnership")
+}
+
+func (i *EthInvoker) PLTAssetOwnership(asset common.Address) (common.Address, error) {
+	return i.callPLTAssetAddress(asset, "owner")
+}
+
+// PLTAssetPendingOwner returns the owner which has not accepted the ownership, and it is empty if there
+// is no ownership transfer.
+func (i *EthInvoker) PLTAssetPendingOwner(asset common.Address) (common.Address, error) {
+	return i.callPLTAssetAddress(asset, "pendingOwner")
+}
+
+func (i *EthInvoker) transactPLTAsset(asset common.Address, method string, args ...interface{}) (common.Hash, error) {
+	parsed, err := loadPLTAssetABI()
+	if err != nil {
+		return utils.EmptyHash, err
+	}
+	instance := bind.NewBoundContract(asset, parsed, i.backend(), i.backend(), i.backend())
+	tx, err := i.transact(func(auth *bind.TransactOpts) (*types.Transaction, error) {
+		return instance.Transact(auth, method, args...)
+	})
+	if err != nil {
+		return utils.EmptyHash, err
+	}
+	if err := i.waitTxConfirm(i.txHash(tx)); err != nil {
+		return utils.EmptyHash, err
+	}
+	return i.txHash(tx), nil
+}
+
+func (i *EthInvoker) callPLTAssetAddress(asset common.Address, method string) (common.Address, error) {
+	parsed, err := loadPLTAssetABI()
+	if err != nil {
+		return utils.EmptyAddress, err
+	}
+	payload, err := parsed.Pack(method)
+	if err != nil {
+		return utils.EmptyAddress, err
+	}
+	msg := ethereum.CallMsg{From: i.Address(), To: &asset, Data: payload}
+	enc, err := i.Tools.GetEthClient().CallContract(i.Context(), msg, nil)
+	if err != nil {
+		return utils.EmptyAddress, errs.RPC("eth_call", err)
+	}
+	values, err := parsed.Methods[method].Outputs.UnpackValues(enc)
+	if err != nil {
+		return utils.EmptyAddress, fmt.Errorf("unpack %s failed, err: %v", method, err)
+	}
+	if len(values) == 0 {
+		return utils.EmptyAddress, fmt.Errorf("%s returns nothing", method)
+	}
+	var out common.Address
+	if err := batch.DecodeAddress(values[0], &out); err != nil {
+		return utils.EmptyAddress, err
+	}
+	return out, nil
+}
@@ -0,0 +1,26 @@
+package eth
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseContractBin(t *testing.T) {
+	expect := []byte{0x60, 0x80, 0x60, 0x40}
+	for _, artifact := range []string{
+		"0x60806040\n",
+		"60806040",
+		`{"abi": [], "bytecode": "0x60806040"}`,
+		`{"abi": [], "bytecode": {"object": "0x60806040"}}`,
+	} {
+		bin, err := ParseContractBin([]byte(artifact))
+		assert.NoError(t, err)
+		assert.Equal(t, expect, bin)
+	}
+
+	for _, artifact := range []string{"", "0x", "0xzz", `{"abi": []}`, `{"bytecode": 1}`} {
+		_, err := ParseContractBin([]byte(artifact))
+		assert.Error(t, err)
+	}
+}
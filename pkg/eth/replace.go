@@ -0,0 +1,187 @@
+/*
+* Copyright (C) 2020 The poly network Authors
+* This file is part of The poly network library.
+*
+* The poly network is free software: you can redistribute it and/or modify
+* it under the terms of the GNU Lesser General Public License as published by
+* the Free Software Foundation, either version 3 of the License, or
+* (at your option) any later version.
+*
+* The poly network is distributed in the hope that it will be useful,
+* but WITHOUT ANY WARRANTY; without even the implied warranty of
+* MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+* GNU Lesser General Public License for more details.
+* You should have received a copy of the GNU Lesser General Public License
+* along with The poly network . If not, see <http://www.gnu.org/licenses/>.
+ */
+package eth
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/palettechain/deploy-tool/pkg/errs"
+)
+
+const (
+	// FeeBumpPercent is the percent of fees bumped by replacement, nodes accept the replacement with
+	// at least 10 percent higher fees.
+	FeeBumpPercent = 20
+
+	transferGas = 21000
+)
+
+// RPCCaller is the json-rpc client, which is implemented by `rpc.Client`.
+type RPCCaller interface {
+	CallContext(ctx context.Context, result interface{}, method string, args ...interface{}) error
+}
+
+// RPCTransaction is the tx returned by `eth_getTransactionByHash`, both legacy and EIP-1559 txs can
+// be decoded, and the fee caps are nil for legacy tx.
+type RPCTransaction struct {
+	Hash        common.Hash     `json:"hash"`
+	BlockNumber *hexutil.Big    `json:"blockNumber"`
+	From        common.Address  `json:"from"`
+	Nonce       hexutil.Uint64  `json:"nonce"`
+	To          *common.Address `json:"to"`
+	Value       *hexutil.Big    `json:"value"`
+	Input       hexutil.Bytes   `json:"input"`
+	Gas         hexutil.Uint64  `json:"gas"`
+	GasPrice    *hexutil.Big    `json:"gasPrice"`
+	FeeCap      *hexutil.Big    `json:"maxFeePerGas"`
+	TipCap      *hexutil.Big    `json:"maxPriorityFeePerGas"`
+}
+
+// GetTransaction fetch the tx by hash, and returns `ethereum.NotFound` if it is unknown to the node.
+func GetTransaction(ctx context.Context, caller RPCCaller, hash common.Hash) (*RPCTransaction, error) {
+	var tx *RPCTransaction
+	if err := caller.CallContext(ctx, &tx, "eth_getTransactionByHash", hash); err != nil {
+		return nil, errs.RPC("eth_getTransactionByHash", err)
+	}
+	if tx == nil {
+		return nil, ethereum.NotFound
+	}
+	return tx, nil
+}
+
+// Replace re-sign the pending tx with the same nonce and fees bumped by `FeeBumpPercent`, or the
+// fees suggested by node if they are higher, and send it. the tx is replaced by a zero value self
+// transfer if cancel is true. the fee cap of replacement can not exceed maxFee unless it is nil.
+func Replace(ctx context.Context, caller RPCCaller, key *ecdsa.PrivateKey, hash common.Hash, cancel bool, maxFee *big.Int) (common.Hash, error) {
+	tx, err := GetTransaction(ctx, caller, hash)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	if tx.BlockNumber != nil {
+		return common.Hash{}, fmt.Errorf("tx %s already mined at block %d", hash.Hex(), tx.BlockNumber.ToInt().Uint64())
+	}
+	from := crypto.PubkeyToAddress(key.PublicKey)
+	if tx.From != from {
+		return common.Hash{}, fmt.Errorf("tx %s sent by %s, can not be replaced by %s", hash.Hex(), tx.From.Hex(), from.Hex())
+	}
+
+	var chainID hexutil.Big
+	if err := caller.CallContext(ctx, &chainID, "eth_chainId"); err != nil {
+		return common.Hash{}, errs.RPC("eth_chainId", err)
+	}
+
+	to, value, data, gas := tx.To, tx.Value.ToInt(), []byte(tx.Input), uint64(tx.Gas)
+	if cancel {
+		to, value, data, gas = &from, new(big.Int), nil, transferGas
+	}
+
+	var raw []byte
+	if tx.FeeCap != nil && tx.TipCap != nil {
+		fees, err := bumpFees(ctx, caller, tx, maxFee)
+		if err != nil {
+			return common.Hash{}, err
+		}
+		dtx := &dynamicFeeTx{
+			ChainID: chainID.ToInt(),
+			Nonce:   uint64(tx.Nonce),
+			TipCap:  fees.TipCap,
+			FeeCap:  fees.FeeCap,
+			Gas:     gas,
+			To:      to,
+			Value:   value,
+			Data:    data,
+		}
+		if raw, _, err = dtx.sign(key); err != nil {
+			return common.Hash{}, err
+		}
+	} else {
+		gasPrice, err := bumpGasPrice(ctx, caller, tx, maxFee)
+		if err != nil {
+			return common.Hash{}, err
+		}
+		var ltx *types.Transaction
+		if to == nil {
+			ltx = types.NewContractCreation(uint64(tx.Nonce), value, gas, gasPrice, data)
+		} else {
+			ltx = types.NewTransaction(uint64(tx.Nonce), *to, value, gas, gasPrice, data)
+		}
+		signed, err := types.SignTx(ltx, types.NewEIP155Signer(chainID.ToInt()), key)
+		if err != nil {
+			return common.Hash{}, err
+		}
+		if raw, err = rlp.EncodeToBytes(signed); err != nil {
+			return common.Hash{}, err
+		}
+	}
+
+	var sent common.Hash
+	if err := caller.CallContext(ctx, &sent, "eth_sendRawTransaction", hexutil.Bytes(raw)); err != nil {
+		return common.Hash{}, errs.RPC("eth_sendRawTransaction", err)
+	}
+	return sent, nil
+}
+
+// bumpFees returns the higher one of the bumped fees and the fees suggested by node.
+func bumpFees(ctx context.Context, caller RPCCaller, tx *RPCTransaction, maxFee *big.Int) (*Fees, error) {
+	tip, feeCap := bump(tx.TipCap.ToInt()), bump(tx.FeeCap.ToInt())
+	if suggested, err := suggestFees(ctx, caller); err == nil && suggested != nil {
+		tip, feeCap = maxBig(tip, suggested.TipCap), maxBig(feeCap, suggested.FeeCap)
+	}
+	if maxFee != nil && feeCap.Cmp(maxFee) > 0 {
+		return nil, fmt.Errorf("replacement fee cap %s exceeds the max fee %s", feeCap, maxFee)
+	}
+	if tip.Cmp(feeCap) > 0 {
+		tip = feeCap
+	}
+	return &Fees{TipCap: tip, FeeCap: feeCap}, nil
+}
+
+// bumpGasPrice returns the higher one of the bumped gas price and the gas price suggested by node.
+func bumpGasPrice(ctx context.Context, caller RPCCaller, tx *RPCTransaction, maxFee *big.Int) (*big.Int, error) {
+	gasPrice := bump(tx.GasPrice.ToInt())
+	var suggested hexutil.Big
+	if err := caller.CallContext(ctx, &suggested, "eth_gasPrice"); err == nil {
+		gasPrice = maxBig(gasPrice, suggested.ToInt())
+	}
+	if maxFee != nil && gasPrice.Cmp(maxFee) > 0 {
+		return nil, fmt.Errorf("replacement gas price %s exceeds the max fee %s", gasPrice, maxFee)
+	}
+	return gasPrice, nil
+}
+
+func bump(v *big.Int) *big.Int {
+	bumped := new(big.Int).Mul(v, big.NewInt(100+FeeBumpPercent))
+	bumped.Div(bumped, big.NewInt(100))
+	// at least 1 wei higher for tiny fees
+	return bumped.Add(bumped, big.NewInt(1))
+}
+
+func maxBig(a, b *big.Int) *big.Int {
+	if a.Cmp(b) >= 0 {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,136 @@
+/*
+* Copyright (C) 2020 The poly network Authors
+* This file is part of The poly network library.
+*
+* The poly network is free software: you can redistribute it and/or modify
+* it under the terms of the GNU Lesser General Public License as published by
+* the Free Software Foundation, either version 3 of the License, or
+* (at your option) any later version.
+*
+* The poly network is distributed in the hope that it will be useful,
+* but WITHOUT ANY WARRANTY; without even the implied warranty of
+* MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+* GNU Lesser General Public License for more details.
+* You should have received a copy of the GNU Lesser General Public License
+* along with The poly network . If not, see <http://www.gnu.org/licenses/>.
+ */
+package eth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/palettechain/deploy-tool/pkg/abis"
+	"github.com/palettechain/deploy-tool/pkg/errs"
+	"github.com/palettechain/deploy-tool/pkg/log"
+)
+
+// PendingCaller execute `eth_call` at the pending block, which is implemented by `ethclient.Client`.
+type PendingCaller interface {
+	PendingCallContract(ctx context.Context, msg ethereum.CallMsg) ([]byte, error)
+}
+
+// ReplayBackend fetch and re-execute mined transactions, which is implemented by `ethclient.Client`.
+type ReplayBackend interface {
+	TransactionByHash(ctx context.Context, hash common.Hash) (*types.Transaction, bool, error)
+	CallContract(ctx context.Context, msg ethereum.CallMsg, blockNumber *big.Int) ([]byte, error)
+}
+
+// Simulate execute the state-changing call with `eth_call` at the pending block before it is signed,
+// and returns `errs.SimulationError` with the decoded reason if it reverted.
+func Simulate(ctx context.Context, caller PendingCaller, msg ethereum.CallMsg) error {
+	if _, err := caller.PendingCallContract(ctx, msg); err != nil {
+		reason, ok := revertReason(err)
+		if !ok {
+			return fmt.Errorf("simulate tx failed, err: %v", err)
+		}
+		return &errs.SimulationError{From: msg.From, To: msg.To, Reason: reason}
+	}
+	return nil
+}
+
+func CallMsg(from common.Address, tx *types.Transaction) ethereum.CallMsg {
+	return ethereum.CallMsg{
+		From:     from,
+		To:       tx.To(),
+		Gas:      tx.Gas(),
+		GasPrice: tx.GasPrice(),
+		Value:    tx.Value(),
+		Data:     tx.Data(),
+	}
+}
+
+// FillRevertReason replay the reverted transaction with `eth_call` on the state of its parent block,
+// and fill the reason of `errs.RevertedError`. the error is returned as it is.
+func FillRevertReason(ctx context.Context, backend ReplayBackend, signer types.Signer, err error) error {
+	var reverted *errs.RevertedError
+	if !errors.As(err, &reverted) || reverted.Reason != "" {
+		return err
+	}
+
+	tx, _, e := backend.TransactionByHash(ctx, reverted.Hash)
+	if e != nil {
+		log.Debugf("failed to get reverted tx %s, err: %v", reverted.Hash.Hex(), e)
+		return err
+	}
+	from, e := types.Sender(signer, tx)
+	if e != nil {
+		log.Debugf("failed to recover sender of reverted tx %s, err: %v", reverted.Hash.Hex(), e)
+		return err
+	}
+
+	var blockNumber *big.Int
+	if reverted.BlockNumber > 0 {
+		blockNumber = new(big.Int).SetUint64(reverted.BlockNumber - 1)
+	}
+	if _, e = backend.CallContract(ctx, CallMsg(from, tx), blockNumber); e == nil {
+		log.Debugf("replay reverted tx %s succeed, reason unknown", reverted.Hash.Hex())
+		return err
+	}
+	if reason, ok := revertReason(e); ok {
+		reverted.Reason = reason
+	}
+	return err
+}
+
+// dataError is the rpc error carrying the revert data, same as `rpc.DataError` of newer geth.
+type dataError interface {
+	Error() string
+	ErrorData() interface{}
+}
+
+// revertReason extract the reason from the error of `eth_call`, the error carries the revert data
+// since geth 1.9.15, and the message is used for nodes without revert data.
+func revertReason(err error) (string, bool) {
+	var dataErr dataError
+	if errors.As(err, &dataErr) {
+		if data := revertData(dataErr.ErrorData()); len(data) > 0 {
+			return abis.RevertReason(data), true
+		}
+	}
+	if msg := err.Error(); strings.Contains(strings.ToLower(msg), "revert") {
+		return msg, true
+	}
+	return "", false
+}
+
+func revertData(v interface{}) []byte {
+	switch data := v.(type) {
+	case string:
+		enc, err := hexutil.Decode(data)
+		if err != nil {
+			return nil
+		}
+		return enc
+	case []byte:
+		return data
+	}
+	return nil
+}
@@ -0,0 +1,62 @@
+package frame
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/palettechain/deploy-tool/pkg/log"
+)
+
+// Command is the sub command with arguments, e.g: `deploy-tool wallet derive --from 0 --count 10`,
+// the command should parse it's own flags from args.
+type Command func(args []string) bool
+
+// RegCommand register command with group and name, and the command will be run as `group name args...`.
+func (pt *PaletteTool) RegCommand(group, name string, cmd Command) {
+	if pt.commandsMap == nil {
+		pt.commandsMap = make(map[string]map[string]Command)
+	}
+	if _, ok := pt.commandsMap[group]; !ok {
+		pt.commandsMap[group] = make(map[string]Command)
+	}
+	pt.commandsMap[group][name] = cmd
+}
+
+// RunCommand run command with cmdline args, the first two args are command group and name.
+func (pt *PaletteTool) RunCommand(args []string) bool {
+	if len(args) < 2 {
+		pt.usage(args)
+		return false
+	}
+
+	group, name := args[0], args[1]
+	cmd, ok := pt.commandsMap[group][name]
+	if !ok {
+		pt.usage(args)
+		return false
+	}
+
+	ok = cmd(args[2:])
+	if ok {
+		log.Infof("Run Command:%s %s success.", group, name)
+	} else {
+		log.Infof("Run Command:%s %s failed.", group, name)
+	}
+	return ok
+}
+
+func (pt *PaletteTool) usage(args []string) {
+	list := make([]string, 0)
+	for group, cmds := range pt.commandsMap {
+		for name := range cmds {
+			list = append(list, fmt.Sprintf("%s %s", group, name))
+		}
+	}
+	sort.Strings(list)
+
+	log.Errorf("invalid command %s, supported commands:", strings.Join(args, " "))
+	for i, cmd := range list {
+		log.Errorf("%d.\t%s", i+1, cmd)
+	}
+}
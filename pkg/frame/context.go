@@ -0,0 +1,68 @@
+package frame
+
+import (
+	"context"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/palettechain/deploy-tool/pkg/log"
+)
+
+// methodTimeout bound every method run by frame, 0 means no timeout.
+var methodTimeout time.Duration
+
+// SetMethodTimeout set the timeout of every method, methods are not bounded if it is 0.
+func SetMethodTimeout(timeout time.Duration) {
+	methodTimeout = timeout
+}
+
+// Context returns the context of the running method, it is canceled if the method timeout or
+// the tool interrupted, and the background context returned if there is no method running.
+func (pt *PaletteTool) Context() context.Context {
+	if pt.ctx != nil {
+		return pt.ctx
+	}
+	return context.Background()
+}
+
+// signalContext returns the root context of method list which canceled on SIGINT or SIGTERM,
+// so that the running method stop waiting and the rest methods are skipped.
+func signalContext() (context.Context, context.CancelFunc) {
+	ctx, cancel := context.WithCancel(context.Background())
+	sig := make(chan os.Signal, 1)
+	signal.Notify(sig, syscall.SIGINT, syscall.SIGTERM)
+	go func() {
+		select {
+		case s := <-sig:
+			log.Warnf("receive signal %s, cancel the running method and skip the rest", s)
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+	return ctx, func() {
+		signal.Stop(sig)
+		cancel()
+	}
+}
+
+// methodContext derive the per method context from root.
+func methodContext(root context.Context) (context.Context, context.CancelFunc) {
+	if methodTimeout > 0 {
+		return context.WithTimeout(root, methodTimeout)
+	}
+	return context.WithCancel(root)
+}
+
+// sleep returns false if the context done before the duration elapsed.
+func sleep(ctx context.Context, d time.Duration) bool {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return false
+	case <-timer.C:
+		return true
+	}
+}
@@ -1,8 +1,10 @@
 package frame
 
 import (
+	"context"
 	"time"
 
+	"github.com/palettechain/deploy-tool/pkg/gas"
 	"github.com/palettechain/deploy-tool/pkg/log"
 )
 
@@ -19,14 +21,23 @@ type PaletteTool struct {
 	methodsMap map[string]Method
 	//Map method result
 	methodsRes map[string]bool
+	//Map method expected transaction cost
+	methodsCost map[string][]*gas.Cost
+	//Map group and name to command
+	commandsMap map[string]map[string]Command
 	//gc func
 	gc GcFunc
+	//root context of method list and the context of running method
+	root context.Context
+	ctx  context.Context
 }
 
 func NewPaletteTool() *PaletteTool {
 	return &PaletteTool{
-		methodsMap: make(map[string]Method, 0),
-		methodsRes: make(map[string]bool, 0),
+		methodsMap:  make(map[string]Method, 0),
+		methodsRes:  make(map[string]bool, 0),
+		methodsCost: make(map[string][]*gas.Cost, 0),
+		commandsMap: make(map[string]map[string]Command, 0),
 	}
 }
 
@@ -52,14 +63,21 @@ func (pt *PaletteTool) runMethodList(methodsList []string) {
 	pt.onStart()
 	defer pt.onFinish(methodsList)
 
+	root, stop := signalContext()
+	defer stop()
+	pt.root = root
+
 	var rest = func(index int) {
 		n := len(methodsList)
 		if n > 1 && index < n-1 {
-			time.Sleep(5 * time.Second)
+			sleep(root, 5*time.Second)
 		}
 	}
 
 	for i, method := range methodsList {
+		if root.Err() != nil {
+			break
+		}
 		pt.runMethod(i+1, method)
 		rest(i)
 	}
@@ -69,7 +87,13 @@ func (pt *PaletteTool) runMethod(index int, methodName string) {
 	pt.onBeforeMethodStart(index, methodName)
 	method := pt.getMethodByName(methodName)
 	if method != nil {
+		ctx, cancel := methodContext(pt.root)
+		pt.ctx = ctx
+		gas.Drain()
 		ok := method()
+		cancel()
+		pt.ctx = nil
+		pt.methodsCost[methodName] = gas.Drain()
 		pt.onAfterMethodFinish(index, methodName, ok)
 		pt.methodsRes[methodName] = ok
 	}
@@ -135,9 +159,36 @@ func (pt *PaletteTool) onFinish(methodsList []string) {
 			log.Infof("%d.\t%s", i+1, skip)
 		}
 	}
+	pt.logCost(methodsList)
 	log.Info("===============================================================")
 }
 
+// logCost print the expected transaction cost of every method and the total cost.
+func (pt *PaletteTool) logCost(methodsList []string) {
+	all := make([]*gas.Cost, 0)
+	for _, method := range methodsList {
+		all = append(all, pt.methodsCost[method]...)
+	}
+	if len(all) == 0 {
+		return
+	}
+
+	log.Info("---------------------------------------------------------------")
+	if gas.IsDryRun() {
+		log.Info("Expected cost list(dry run, no transaction sent, every method stops at its first transaction):")
+	} else {
+		log.Info("Expected cost list:")
+	}
+	for i, method := range methodsList {
+		for _, sum := range gas.Summarize(pt.methodsCost[method]) {
+			log.Infof("%d.\t%s\t%s", i+1, method, sum)
+		}
+	}
+	for _, sum := range gas.Summarize(all) {
+		log.Infof("Total\t%s", sum)
+	}
+}
+
 func (pt *PaletteTool) onBeforeMethodStart(index int, methodName string) {
 	log.Info("===============================================================")
 	log.Infof("%d. Start Method:%s", index, methodName)
@@ -145,6 +196,9 @@ func (pt *PaletteTool) onBeforeMethodStart(index int, methodName string) {
 }
 
 func (pt *PaletteTool) onAfterMethodFinish(index int, methodName string, res bool) {
+	for _, cost := range pt.methodsCost[methodName] {
+		log.Infof("expected cost: %s", cost)
+	}
 	if res {
 		log.Infof("Run Method:%s success.", methodName)
 	} else {
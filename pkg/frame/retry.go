@@ -0,0 +1,80 @@
+package frame
+
+import (
+	"time"
+
+	"github.com/palettechain/deploy-tool/pkg/errs"
+	"github.com/palettechain/deploy-tool/pkg/log"
+	"github.com/palettechain/deploy-tool/pkg/txwait"
+)
+
+// MethodE is the method returns error, the frame classify the error to decide whether to retry it.
+// the method should be idempotent, e.g: check the binding before sending the bind tx. the retried
+// method runs with `txwait.WithRetry` context, and it fails again with `errs.PendingError` instead of
+// sending while the txs of the failed attempt are still pending.
+type MethodE func() error
+
+const (
+	DefaultRetryTimes    = 3
+	DefaultRetryInterval = 10 * time.Second
+)
+
+var (
+	retryTimes    = DefaultRetryTimes
+	retryInterval = DefaultRetryInterval
+)
+
+// SetRetry set the max retry times and interval of methods failed with retryable errors, default
+// values used if they are 0.
+func SetRetry(times int, interval time.Duration) {
+	retryTimes, retryInterval = DefaultRetryTimes, DefaultRetryInterval
+	if times > 0 {
+		retryTimes = times
+	}
+	if interval > 0 {
+		retryInterval = interval
+	}
+}
+
+// RegMethodE register the method returns error. methods failed with rpc unavailable or timeout errors
+// are retried, and `errs.AlreadyDoneError` is treated as success.
+func (pt *PaletteTool) RegMethodE(name string, method MethodE) {
+	pt.methodsMap[name] = pt.retry(name, method)
+}
+
+func (pt *PaletteTool) retry(name string, method MethodE) Method {
+	return func() bool {
+		ctx, base := pt.ctx, pt.Context()
+		defer func() { pt.ctx = ctx }()
+
+		for i := 0; ; i++ {
+			err := method()
+			if err == nil {
+				return true
+			}
+			// deadline exceeded looks like a timeout net error, the method should not be retried
+			// after its context done.
+			if ctxErr := pt.Context().Err(); ctxErr != nil {
+				log.Errorf("%s failed with context %v, err: %v", name, ctxErr, err)
+				return false
+			}
+
+			kind := errs.Classify(err)
+			switch {
+			case kind == errs.KindAlreadyDone:
+				log.Infof("%s skipped, %v", name, err)
+				return true
+			case errs.Retryable(err) && i < retryTimes:
+				log.Warnf("%s failed with %s error, retry %d/%d after %s, err: %v", name, kind, i+1, retryTimes, retryInterval, err)
+				if !sleep(pt.Context(), retryInterval) {
+					log.Errorf("%s canceled while waiting to retry, err: %v", name, pt.Context().Err())
+					return false
+				}
+				pt.ctx = txwait.WithRetry(base)
+			default:
+				log.Errorf("%s failed with %s error, err: %v", name, kind, err)
+				return false
+			}
+		}
+	}
+}
@@ -0,0 +1,130 @@
+package gas
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"sort"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// ErrDryRun returned instead of sending the transaction in dry-run mode.
+var ErrDryRun = errors.New("dry run, transaction not sent")
+
+var dryRun bool
+
+func SetDryRun(enable bool) {
+	dryRun = enable
+}
+
+func IsDryRun() bool {
+	return dryRun
+}
+
+// Cost is the expected cost of transaction, which is gas limit * gas price.
+type Cost struct {
+	Chain    string
+	To       *common.Address
+	Gas      uint64
+	GasPrice *big.Int
+	// the tx is not sent in dry-run mode, and the method stops at it, so the later txs of the
+	// method are not estimated.
+	DryRun bool
+}
+
+func (c *Cost) Fee() *big.Int {
+	return new(big.Int).Mul(new(big.Int).SetUint64(c.Gas), c.GasPrice)
+}
+
+func (c *Cost) String() string {
+	to := "deploy"
+	if c.To != nil {
+		to = c.To.Hex()
+	}
+	if c.DryRun {
+		return fmt.Sprintf("%s to %s gas %d price %s fee %s, not sent in dry run and the later txs are not estimated",
+			c.Chain, to, c.Gas, c.GasPrice, c.Fee())
+	}
+	return fmt.Sprintf("%s to %s gas %d price %s fee %s", c.Chain, to, c.Gas, c.GasPrice, c.Fee())
+}
+
+var (
+	costs []*Cost
+	lock  sync.Mutex
+)
+
+// Record the expected cost of transaction before it is sent, and returns `ErrDryRun` in dry-run mode
+// so that the caller never send it.
+func Record(chain string, to *common.Address, gas uint64, gasPrice *big.Int) error {
+	lock.Lock()
+	defer lock.Unlock()
+
+	if gasPrice == nil {
+		gasPrice = new(big.Int)
+	}
+	costs = append(costs, &Cost{
+		Chain:    chain,
+		To:       to,
+		Gas:      gas,
+		GasPrice: gasPrice,
+		DryRun:   dryRun,
+	})
+	if dryRun {
+		return ErrDryRun
+	}
+	return nil
+}
+
+// Drain returns the costs recorded since the last drain.
+func Drain() []*Cost {
+	lock.Lock()
+	defer lock.Unlock()
+
+	list := costs
+	costs = nil
+	return list
+}
+
+// Summary is the total expected cost of transactions on one chain.
+type Summary struct {
+	Chain string
+	Txs   int
+	Gas   uint64
+	Fee   *big.Int
+	// some methods stopped at their first tx in dry-run mode, and their later txs are not counted.
+	Partial bool
+}
+
+func (s *Summary) String() string {
+	if s.Partial {
+		return fmt.Sprintf("%s txs %d gas %d fee %s, first tx of each method only in dry run", s.Chain, s.Txs, s.Gas, s.Fee)
+	}
+	return fmt.Sprintf("%s txs %d gas %d fee %s", s.Chain, s.Txs, s.Gas, s.Fee)
+}
+
+// Summarize sum up the costs by chain, and the summaries are sorted by chain name.
+func Summarize(list []*Cost) []*Summary {
+	m := make(map[string]*Summary)
+	for _, c := range list {
+		sum, ok := m[c.Chain]
+		if !ok {
+			sum = &Summary{Chain: c.Chain, Fee: new(big.Int)}
+			m[c.Chain] = sum
+		}
+		sum.Txs++
+		sum.Partial = sum.Partial || c.DryRun
+		sum.Gas += c.Gas
+		sum.Fee.Add(sum.Fee, c.Fee())
+	}
+
+	res := make([]*Summary, 0, len(m))
+	for _, sum := range m {
+		res = append(res, sum)
+	}
+	sort.Slice(res, func(i, j int) bool {
+		return res[i].Chain < res[j].Chain
+	})
+	return res
+}
@@ -0,0 +1,36 @@
+package gas
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRecord(t *testing.T) {
+	defer SetDryRun(false)
+
+	to := common.HexToAddress("0x01")
+	assert.NoError(t, Record("palette", &to, 21000, big.NewInt(2)))
+	assert.NoError(t, Record("palette", nil, 100000, big.NewInt(1)))
+
+	SetDryRun(true)
+	assert.Equal(t, ErrDryRun, Record("ethereum", &to, 50000, nil))
+
+	list := Drain()
+	assert.Equal(t, 3, len(list))
+	assert.Equal(t, 0, len(Drain()))
+
+	sums := Summarize(list)
+	assert.Equal(t, 2, len(sums))
+	assert.Equal(t, "ethereum", sums[0].Chain)
+	assert.Equal(t, uint64(0), sums[0].Fee.Uint64())
+	assert.True(t, sums[0].Partial)
+	assert.Contains(t, sums[0].String(), "first tx of each method only")
+	assert.Equal(t, "palette", sums[1].Chain)
+	assert.Equal(t, 2, sums[1].Txs)
+	assert.Equal(t, uint64(121000), sums[1].Gas)
+	assert.Equal(t, uint64(142000), sums[1].Fee.Uint64())
+	assert.False(t, sums[1].Partial)
+}
@@ -1,30 +1,15 @@
 package hdwallet
 
 import (
+	"crypto/ecdsa"
 	"fmt"
+	"strings"
 
 	"github.com/ethereum/go-ethereum/accounts"
 )
 
-const mnemonic = "tag volcano eight thank tide danger coast health above argue embrace heavy"
-
-var wallet *Wallet
-
-func init() {
-	w, err := NewFromMnemonic(mnemonic)
-	if err != nil {
-		panic(err)
-	}
-	wallet = w
-}
-
-func Drive(hdIndex int) (accounts.Account, error) {
-	path := derivationPath(hdIndex)
-	return wallet.Derive(path, false)
-}
-
-// DerivationPath represents the computer friendly version of a hierarchical
-// deterministic wallet account derivaion path.
+// DefaultDerivationTemplate is the ethereum derivation path template, and the `%d` will be replaced
+// by the account index.
 //
 // The BIP-32 spec https://github.com/bitcoin/bips/blob/master/bip-0032.mediawiki
 // defines derivation paths to be of the form:
@@ -40,7 +25,62 @@ func Drive(hdIndex int) (accounts.Account, error) {
 // from https://github.com/ethereum/EIPs/issues/84, albeit it's not set in stone
 // yet whether accounts should increment the last component or the children of
 // that. We will go with the simpler approach of incrementing the last component.
-func derivationPath(idx int) accounts.DerivationPath {
-	format := fmt.Sprintf("m/44'/60'/0'/0/%d", idx)
-	return MustParseDerivationPath(format)
+// other wallets such as ledger live increment the account component, e.g: m/44'/60'/%d'/0/0.
+const DefaultDerivationTemplate = "m/44'/60'/0'/0/%d"
+
+// Driver derives accounts from an operator supplied mnemonic with derivation template.
+type Driver struct {
+	wallet   *Wallet
+	template string
+}
+
+func NewDriver(mnemonic string, template string) (*Driver, error) {
+	if template == "" {
+		template = DefaultDerivationTemplate
+	}
+	if err := checkDerivationTemplate(template); err != nil {
+		return nil, err
+	}
+
+	w, err := NewFromMnemonic(mnemonic)
+	if err != nil {
+		return nil, err
+	}
+	return &Driver{
+		wallet:   w,
+		template: template,
+	}, nil
+}
+
+func (d *Driver) Template() string {
+	return d.template
+}
+
+func (d *Driver) Drive(hdIndex int) (accounts.Account, error) {
+	path, err := d.derivationPath(hdIndex)
+	if err != nil {
+		return accounts.Account{}, err
+	}
+	return d.wallet.Derive(path, false)
+}
+
+func (d *Driver) PrivateKey(account accounts.Account) (*ecdsa.PrivateKey, error) {
+	return d.wallet.PrivateKey(account)
+}
+
+func (d *Driver) derivationPath(idx int) (accounts.DerivationPath, error) {
+	if idx < 0 {
+		return nil, fmt.Errorf("invalid account index %d", idx)
+	}
+	return ParseDerivationPath(fmt.Sprintf(d.template, idx))
+}
+
+func checkDerivationTemplate(template string) error {
+	if strings.Count(template, "%d") != 1 || strings.Count(template, "%") != 1 {
+		return fmt.Errorf("derivation template %s should contain exactly one `%%d`", template)
+	}
+	if _, err := ParseDerivationPath(fmt.Sprintf(template, 0)); err != nil {
+		return fmt.Errorf("invalid derivation template %s, err: %v", template, err)
+	}
+	return nil
 }
@@ -1,20 +1,60 @@
 package hdwallet
 
 import (
+	"testing"
+
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/stretchr/testify/assert"
-	"testing"
 )
 
+const testMnemonic = "tag volcano eight thank tide danger coast health above argue embrace heavy"
+
 func TestDrive(t *testing.T) {
+	driver, err := NewDriver(testMnemonic, "")
+	assert.NoError(t, err)
+
 	data := make(map[common.Address]struct{})
 
 	N := 10000
 	for i := 0; i < N; i++ {
-		acc, err := Drive(i)
+		acc, err := driver.Drive(i)
 		assert.NoError(t, err)
 		data[acc.Address] = struct{}{}
 	}
 
 	assert.Equal(t, N, len(data))
+
+	acc, err := driver.Drive(0)
+	assert.NoError(t, err)
+	assert.Equal(t, "0xC49926C4124cEe1cbA0Ea94Ea31a6c12318df947", acc.Address.Hex())
+}
+
+func TestDriveTemplate(t *testing.T) {
+	driver, err := NewDriver(testMnemonic, "m/44'/60'/%d'/0/0")
+	assert.NoError(t, err)
+
+	acc, err := driver.Drive(1)
+	assert.NoError(t, err)
+	assert.Equal(t, "m/44'/60'/1'/0/0", acc.URL.Path)
+
+	for _, template := range []string{
+		"m/44'/60'/0'/0/0",
+		"m/44'/60'/%d'/0/%d",
+		"m/44'/60'/%s'/0/0",
+	} {
+		_, err := NewDriver(testMnemonic, template)
+		assert.Error(t, err, template)
+	}
+}
+
+func TestEncryptMnemonic(t *testing.T) {
+	enc, err := EncryptMnemonic(testMnemonic, "pwd")
+	assert.NoError(t, err)
+
+	mnemonic, err := DecryptMnemonic(enc, "pwd")
+	assert.NoError(t, err)
+	assert.Equal(t, testMnemonic, mnemonic)
+
+	_, err = DecryptMnemonic(enc, "invalid")
+	assert.Error(t, err)
 }
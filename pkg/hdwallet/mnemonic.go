@@ -0,0 +1,51 @@
+package hdwallet
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/tyler-smith/go-bip39"
+)
+
+const mnemonicFileVersion = 1
+
+// mnemonicJSON is the encrypted mnemonic file, the mnemonic is encrypted with the same
+// scrypt and aes-128-ctr scheme as the go-ethereum keystore.
+type mnemonicJSON struct {
+	Crypto  keystore.CryptoJSON `json:"crypto"`
+	Version int                 `json:"version"`
+}
+
+// EncryptMnemonic encrypt the mnemonic with password and returns the json encoded file content.
+func EncryptMnemonic(mnemonic, password string) ([]byte, error) {
+	mnemonic = strings.TrimSpace(mnemonic)
+	if !bip39.IsMnemonicValid(mnemonic) {
+		return nil, errors.New("mnemonic is invalid")
+	}
+	cryptoJSON, err := keystore.EncryptDataV3([]byte(mnemonic), []byte(password), keystore.StandardScryptN, keystore.StandardScryptP)
+	if err != nil {
+		return nil, err
+	}
+	return json.MarshalIndent(&mnemonicJSON{
+		Crypto:  cryptoJSON,
+		Version: mnemonicFileVersion,
+	}, "", "\t")
+}
+
+// DecryptMnemonic decrypt the mnemonic file content generated by `EncryptMnemonic`.
+func DecryptMnemonic(enc []byte, password string) (string, error) {
+	data := new(mnemonicJSON)
+	if err := json.Unmarshal(enc, data); err != nil {
+		return "", err
+	}
+	if data.Version != mnemonicFileVersion {
+		return "", errors.New("mnemonic file version not supported")
+	}
+	raw, err := keystore.DecryptDataV3(data.Crypto, password)
+	if err != nil {
+		return "", err
+	}
+	return string(raw), nil
+}
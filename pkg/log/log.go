@@ -123,7 +123,7 @@ func (l *Logger) Output(level int, a ...interface{}) error {
 		gidStr := strconv.FormatUint(gid, 10)
 
 		a = append([]interface{}{LevelName(level), "GID",
-			gidStr + ","}, a...)
+			gidStr + ","}, redactArgs(a)...)
 
 		return l.logger.Output(CALL_DEPTH, fmt.Sprintln(a...))
 	}
@@ -134,7 +134,7 @@ func (l *Logger) Outputf(level int, format string, v ...interface{}) error {
 	if level >= l.level {
 		gid := GetGID()
 		v = append([]interface{}{LevelName(level), "GID",
-			gid}, v...)
+			gid}, redactArgs(v)...)
 
 		return l.logger.Output(CALL_DEPTH, fmt.Sprintf("%s %s %d, "+format+"\n", v...))
 	}
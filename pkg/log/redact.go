@@ -0,0 +1,316 @@
+package log
+
+import (
+	"crypto/ecdsa"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// Redacted is the placeholder of masked secrets in log output.
+const Redacted = "******"
+
+// Secret wraps the sensitive string such as password or mnemonic, and it is always
+// formatted as `Redacted` whatever the verb is.
+type Secret string
+
+func (s Secret) String() string {
+	return Redacted
+}
+
+func (s Secret) GoString() string {
+	return Redacted
+}
+
+func (s Secret) Format(f fmt.State, verb rune) {
+	_, _ = f.Write([]byte(Redacted))
+}
+
+// defaultSensitiveFields are the lower case struct field or map key names masked by default,
+// e.g: `keystore.Key.PrivateKey`, `poly-go-sdk.Account.PrivateKey`.
+var defaultSensitiveFields = []string{
+	"privatekey",
+	"private_key",
+	"privkey",
+	"password",
+	"passwd",
+	"pwd",
+	"mnemonic",
+	"seed",
+	"secret",
+}
+
+var (
+	sensitiveMu     sync.RWMutex
+	sensitiveFields = newFieldSet(defaultSensitiveFields)
+	// cache of whether the type contains sensitive fields, reset while sensitive fields changed.
+	sensitiveTypes sync.Map
+)
+
+var (
+	ecdsaKeyType = reflect.TypeOf(ecdsa.PrivateKey{})
+	secretType   = reflect.TypeOf(Secret(""))
+)
+
+// maxRedactDeep limit the recursive depth of nested and cyclic values, the values with secrets are
+// masked entirely past it.
+const maxRedactDeep = 16
+
+func newFieldSet(fields []string) map[string]struct{} {
+	set := make(map[string]struct{}, len(fields))
+	for _, field := range fields {
+		set[strings.ToLower(field)] = struct{}{}
+	}
+	return set
+}
+
+// SetSensitiveFields add the configured field names to the default sensitive fields, the struct
+// fields and string map keys matching these names case-insensitively are masked in log output.
+func SetSensitiveFields(fields ...string) {
+	sensitiveMu.Lock()
+	defer sensitiveMu.Unlock()
+
+	sensitiveFields = newFieldSet(append(defaultSensitiveFields, fields...))
+	sensitiveTypes.Range(func(key, _ interface{}) bool {
+		sensitiveTypes.Delete(key)
+		return true
+	})
+}
+
+func isSensitiveField(name string) bool {
+	sensitiveMu.RLock()
+	defer sensitiveMu.RUnlock()
+
+	_, ok := sensitiveFields[strings.ToLower(name)]
+	return ok
+}
+
+// Redact returns the value which is safe to be formatted, values without secrets are returned as it is.
+// the values with secrets are wrapped as `fmt.Formatter`, which formats the value with the verb of the
+// caller, e.g: `%d` and `%x`, and only the secrets are replaced with `Redacted`.
+func Redact(v interface{}) interface{} {
+	if v == nil {
+		return nil
+	}
+	rv := reflect.ValueOf(v)
+	if !containsSecret(rv.Type()) {
+		return v
+	}
+	return redacted{rv}
+}
+
+func redactArgs(args []interface{}) []interface{} {
+	list := make([]interface{}, len(args))
+	for i, arg := range args {
+		list[i] = Redact(arg)
+	}
+	return list
+}
+
+// containsSecret check whether the type has secret types or sensitive fields. the interface types
+// may hold secrets, so that their dynamic values are checked while formatting.
+func containsSecret(typ reflect.Type) bool {
+	if cached, ok := sensitiveTypes.Load(typ); ok {
+		return cached.(bool)
+	}
+	res := checkType(typ, make(map[reflect.Type]struct{}))
+	sensitiveTypes.Store(typ, res)
+	return res
+}
+
+// checkType walks the nested types, and the visited types are skipped so that cyclic types end.
+func checkType(typ reflect.Type, visited map[reflect.Type]struct{}) bool {
+	if typ == ecdsaKeyType || typ == secretType {
+		return true
+	}
+	if _, ok := visited[typ]; ok {
+		return false
+	}
+	visited[typ] = struct{}{}
+
+	switch typ.Kind() {
+	case reflect.Interface:
+		return true
+	case reflect.Ptr, reflect.Slice, reflect.Array:
+		return checkType(typ.Elem(), visited)
+	case reflect.Map:
+		// string keys checked with value
+		return typ.Key().Kind() == reflect.String || checkType(typ.Elem(), visited)
+	case reflect.Struct:
+		for i := 0; i < typ.NumField(); i++ {
+			field := typ.Field(i)
+			if isSensitiveField(field.Name) || checkType(field.Type, visited) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+var (
+	errorType     = reflect.TypeOf((*error)(nil)).Elem()
+	stringerType  = reflect.TypeOf((*fmt.Stringer)(nil)).Elem()
+	formatterType = reflect.TypeOf((*fmt.Formatter)(nil)).Elem()
+)
+
+// redacted formats the value like fmt with the same verb, flags, width and precision, and the
+// secrets in it are replaced with `Redacted`.
+type redacted struct {
+	rv reflect.Value
+}
+
+func (r redacted) Format(f fmt.State, verb rune) {
+	p := &redactPrinter{
+		format: formatDirective(f, verb),
+		plus:   verb == 'v' && f.Flag('+'),
+		sharp:  verb == 'v' && f.Flag('#'),
+	}
+	p.print(r.rv, 0)
+	_, _ = f.Write([]byte(p.buf.String()))
+}
+
+// formatDirective rebuild the directive such as `%-08.2x` from the state.
+func formatDirective(f fmt.State, verb rune) string {
+	var b strings.Builder
+	b.WriteByte('%')
+	for _, flag := range "+-# 0" {
+		if f.Flag(int(flag)) {
+			b.WriteRune(flag)
+		}
+	}
+	if width, ok := f.Width(); ok {
+		b.WriteString(strconv.Itoa(width))
+	}
+	if prec, ok := f.Precision(); ok {
+		b.WriteByte('.')
+		b.WriteString(strconv.Itoa(prec))
+	}
+	b.WriteRune(verb)
+	return b.String()
+}
+
+type redactPrinter struct {
+	buf    strings.Builder
+	format string
+	// `%+v` prints struct field names, and `%#v` prints go syntax.
+	plus, sharp bool
+}
+
+// print format the value like fmt, the values without secrets and the values formatted by their own
+// methods, e.g: `error` and `fmt.Stringer`, are formatted by fmt directly.
+func (p *redactPrinter) print(rv reflect.Value, deep int) {
+	if !rv.IsValid() {
+		p.buf.WriteString("<nil>")
+		return
+	}
+	typ := rv.Type()
+	if typ == ecdsaKeyType || typ == secretType {
+		p.buf.WriteString(Redacted)
+		return
+	}
+	if !containsSecret(typ) || (rv.Kind() != reflect.Interface && rv.CanInterface() &&
+		(typ.Implements(errorType) || typ.Implements(stringerType) || typ.Implements(formatterType))) {
+		_, _ = fmt.Fprintf(&p.buf, p.format, rv)
+		return
+	}
+	if deep > maxRedactDeep {
+		p.buf.WriteString(Redacted)
+		return
+	}
+
+	switch rv.Kind() {
+	case reflect.Ptr:
+		if rv.IsNil() {
+			p.buf.WriteString("<nil>")
+			return
+		}
+		if elem := typ.Elem(); elem == ecdsaKeyType || elem == secretType {
+			p.buf.WriteString(Redacted)
+			return
+		}
+		p.buf.WriteByte('&')
+		p.print(rv.Elem(), deep+1)
+
+	case reflect.Interface:
+		if rv.IsNil() {
+			p.buf.WriteString("<nil>")
+			return
+		}
+		p.print(rv.Elem(), deep+1)
+
+	case reflect.Slice, reflect.Array:
+		p.open(typ, "[")
+		for i := 0; i < rv.Len(); i++ {
+			p.sep(i)
+			p.print(rv.Index(i), deep+1)
+		}
+		p.close("]")
+
+	case reflect.Map:
+		p.open(typ, "map[")
+		keys := rv.MapKeys()
+		sort.Slice(keys, func(i, j int) bool {
+			return fmt.Sprint(keys[i]) < fmt.Sprint(keys[j])
+		})
+		for i, key := range keys {
+			p.sep(i)
+			p.print(key, deep+1)
+			p.buf.WriteByte(':')
+			if key.Kind() == reflect.String && isSensitiveField(key.String()) {
+				p.buf.WriteString(Redacted)
+			} else {
+				p.print(rv.MapIndex(key), deep+1)
+			}
+		}
+		p.close("]")
+
+	case reflect.Struct:
+		p.open(typ, "{")
+		for i := 0; i < typ.NumField(); i++ {
+			p.sep(i)
+			name := typ.Field(i).Name
+			if p.plus || p.sharp {
+				p.buf.WriteString(name + ":")
+			}
+			if isSensitiveField(name) {
+				p.buf.WriteString(Redacted)
+			} else {
+				p.print(rv.Field(i), deep+1)
+			}
+		}
+		p.close("}")
+
+	default:
+		_, _ = fmt.Fprintf(&p.buf, p.format, rv)
+	}
+}
+
+func (p *redactPrinter) open(typ reflect.Type, prefix string) {
+	if p.sharp {
+		p.buf.WriteString(typ.String() + "{")
+	} else {
+		p.buf.WriteString(prefix)
+	}
+}
+
+func (p *redactPrinter) sep(i int) {
+	if i == 0 {
+		return
+	}
+	if p.sharp {
+		p.buf.WriteString(", ")
+	} else {
+		p.buf.WriteByte(' ')
+	}
+}
+
+func (p *redactPrinter) close(suffix string) {
+	if p.sharp {
+		p.buf.WriteByte('}')
+	} else {
+		p.buf.WriteString(suffix)
+	}
+}
@@ -0,0 +1,196 @@
+package log
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+)
+
+type testKey struct {
+	Address    string
+	PrivateKey *ecdsa.PrivateKey
+}
+
+type testAccount struct {
+	Name     string
+	Password string
+	Key      testKey
+}
+
+func newTestLogger(buf *bytes.Buffer) *Logger {
+	return New(buf, "", 0, InfoLog, nil)
+}
+
+func TestRedact(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NoError(t, err)
+	secret := key.D.String()
+	secretHex := key.D.Text(16)
+
+	buf := new(bytes.Buffer)
+	logger := newTestLogger(buf)
+
+	acc := &testAccount{
+		Name:     "owner",
+		Password: "pwd-123456",
+		Key:      testKey{Address: "0x1234", PrivateKey: key},
+	}
+	logger.Infof("key %v %+v %s %x", key, *key, key, key)
+	logger.Infof("key %v, account %+v", &testKey{Address: "0x1234", PrivateKey: key}, acc)
+	logger.Info("account", acc, []*testAccount{acc})
+	logger.Infof("password %s %v", Secret("pwd-123456"), map[string]string{"password": "pwd-123456", "name": "owner"})
+
+	out := buf.String()
+	assert.NotContains(t, out, secret)
+	assert.NotContains(t, out, secretHex)
+	assert.NotContains(t, out, "pwd-123456")
+	assert.Contains(t, out, Redacted)
+	assert.Contains(t, out, "0x1234")
+	assert.Contains(t, out, "owner")
+}
+
+func TestRedactNonSecret(t *testing.T) {
+	type header struct {
+		Number uint64
+		Hash   string
+	}
+
+	h := &header{Number: 1, Hash: "0xabcd"}
+	assert.Equal(t, h, Redact(h))
+	assert.Equal(t, "0xabcd", Redact("0xabcd"))
+	assert.Equal(t, 1, Redact(1))
+	assert.Nil(t, Redact(nil))
+}
+
+func TestSetSensitiveFields(t *testing.T) {
+	type rpc struct {
+		URL   string
+		Token string
+	}
+	defer SetSensitiveFields()
+
+	data := &rpc{URL: "http://localhost:8545", Token: "token-123456"}
+	assert.Equal(t, data, Redact(data))
+
+	SetSensitiveFields("token")
+	buf := new(bytes.Buffer)
+	newTestLogger(buf).Infof("rpc %v", data)
+	assert.NotContains(t, buf.String(), "token-123456")
+	assert.Contains(t, buf.String(), "http://localhost:8545")
+}
+
+func TestRedactKeepVerb(t *testing.T) {
+	type tx struct {
+		Nonce uint64
+		Value int64
+		Key   *ecdsa.PrivateKey
+	}
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NoError(t, err)
+
+	buf := new(bytes.Buffer)
+	logger := newTestLogger(buf)
+	logger.Infof("nonce %d, tx %d %x %+v", Redact(uint64(10)), &tx{Nonce: 10, Value: 255, Key: key}, tx{Value: 255, Key: key}, tx{Nonce: 10, Key: key})
+
+	out := buf.String()
+	assert.NotContains(t, out, "%!")
+	assert.Contains(t, out, "nonce 10, tx &{10 255 ******} {0 ff ******} {Nonce:10 Value:0 Key:******}")
+	assert.NotContains(t, out, key.D.String())
+}
+
+func TestRedactInterface(t *testing.T) {
+	type event struct {
+		Name string
+		Data interface{}
+		Err  error
+	}
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NoError(t, err)
+
+	buf := new(bytes.Buffer)
+	newTestLogger(buf).Infof("event %v %+v", &event{Name: "deploy", Data: key}, event{Data: []interface{}{*key, "0x1234"}, Err: errors.New("reverted")})
+
+	out := buf.String()
+	assert.NotContains(t, out, key.D.String())
+	assert.Contains(t, out, "event &{deploy ****** <nil>} {Name: Data:[****** 0x1234] Err:reverted}")
+}
+
+func TestRedactDeepNested(t *testing.T) {
+	type level5 struct{ Key *ecdsa.PrivateKey }
+	type level4 struct{ Next level5 }
+	type level3 struct{ Next *level4 }
+	type level2 struct{ Next []level3 }
+	type level1 struct{ Next map[int]level2 }
+	type root struct {
+		Name string
+		Next level1
+	}
+	// cyclic type without secrets
+	type node struct {
+		Value int
+		Next  *node
+	}
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NoError(t, err)
+
+	v := &root{Name: "deep", Next: level1{Next: map[int]level2{1: {Next: []level3{{Next: &level4{Next: level5{Key: key}}}}}}}}
+	assert.True(t, containsSecret(reflect.TypeOf(v)))
+	assert.False(t, containsSecret(reflect.TypeOf(&node{})))
+
+	buf := new(bytes.Buffer)
+	newTestLogger(buf).Infof("%+v %v", v, &node{Value: 1, Next: &node{Value: 2}})
+	out := buf.String()
+	assert.NotContains(t, out, key.D.String())
+	assert.Contains(t, out, "Name:deep")
+	assert.Contains(t, out, "Key:"+Redacted)
+}
+
+// secretValues returns the values of the known secret types, and the plain secret which should never
+// be found in log output.
+func secretValues(t *testing.T) ([]interface{}, []string) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NoError(t, err)
+	ksKey := &keystore.Key{Address: common.HexToAddress("0x1234"), PrivateKey: key}
+
+	values := []interface{}{
+		key,
+		*key,
+		ksKey,
+		*ksKey,
+		Secret("pwd-123456"),
+		[]*keystore.Key{ksKey},
+		map[string]interface{}{"key": ksKey, "password": "pwd-123456"},
+		struct{ Data interface{} }{Data: key},
+		struct{ Pwd string }{Pwd: "pwd-123456"},
+	}
+	return values, []string{key.D.String(), key.D.Text(16), "pwd-123456"}
+}
+
+// TestSecretTypesRedacted fails if the known secret types reach the logger unmasked with any verb.
+func TestSecretTypesRedacted(t *testing.T) {
+	values, secrets := secretValues(t)
+	for _, v := range values {
+		typ := reflect.TypeOf(v)
+		assert.True(t, containsSecret(typ), "%s is not redacted", typ)
+		assert.Implements(t, (*fmt.Formatter)(nil), Redact(v), "%s is not redacted", typ)
+
+		for _, verb := range []string{"%v", "%+v", "%#v", "%s", "%d", "%x", "%X", "%q"} {
+			buf := new(bytes.Buffer)
+			newTestLogger(buf).Infof(verb, v)
+			newTestLogger(buf).Info(v)
+			for _, secret := range secrets {
+				assert.NotContains(t, strings.ToLower(buf.String()), strings.ToLower(secret), "%s leaked with %s", typ, verb)
+			}
+		}
+	}
+}
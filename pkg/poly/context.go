@@ -0,0 +1,73 @@
+package poly
+
+import (
+	"context"
+
+	"github.com/ethereum/go-ethereum/common"
+	polysdk "github.com/polynetwork/poly-go-sdk"
+	polycm "github.com/polynetwork/poly/common"
+	polytype "github.com/polynetwork/poly/core/types"
+)
+
+// context variants of client methods, poly transactions are not sent after the context is done,
+// and the waits of them are canceled.
+
+func (c *PolyClient) RegNodeContext(ctx context.Context, node *polysdk.Account) error {
+	return c.WithContext(ctx).RegNode(node)
+}
+
+func (c *PolyClient) QuitNodeContext(ctx context.Context, acc *polysdk.Account) error {
+	return c.WithContext(ctx).QuitNode(acc)
+}
+
+func (c *PolyClient) SyncGenesisBlockContext(ctx context.Context, selfChainID uint64, genesisHeader []byte) error {
+	return c.WithContext(ctx).SyncGenesisBlock(selfChainID, genesisHeader)
+}
+
+func (c *PolyClient) RegisterSideChainContext(ctx context.Context, chainID uint64, eccdAddr common.Address, sideChainRouter uint64, sideChainName string) error {
+	return c.WithContext(ctx).RegisterSideChain(chainID, eccdAddr, sideChainRouter, sideChainName)
+}
+
+func (c *PolyClient) QuitSideChainContext(ctx context.Context, chainID uint64) error {
+	return c.WithContext(ctx).QuitSideChain(chainID)
+}
+
+func (c *PolyClient) UpdateSideChainContext(ctx context.Context, chainID uint64, eccdAddr common.Address, sideChainRouter uint64, sideChainName string) error {
+	return c.WithContext(ctx).UpdateSideChain(chainID, eccdAddr, sideChainRouter, sideChainName)
+}
+
+func (c *PolyClient) ApproveRegisterSideChainContext(ctx context.Context, chainID uint64) error {
+	return c.WithContext(ctx).ApproveRegisterSideChain(chainID)
+}
+
+func (c *PolyClient) ApproveQuitSideChainContext(ctx context.Context, chainID uint64) error {
+	return c.WithContext(ctx).ApproveQuitSideChain(chainID)
+}
+
+func (c *PolyClient) ApproveUpdateSideChainContext(ctx context.Context, chainID uint64) error {
+	return c.WithContext(ctx).ApproveUpdateSideChain(chainID)
+}
+
+func (c *PolyClient) RegisterCandidateContext(ctx context.Context, peer string, validator *polysdk.Account) error {
+	return c.WithContext(ctx).RegisterCandidate(peer, validator)
+}
+
+func (c *PolyClient) ApproveCandidateContext(ctx context.Context, peer string, validators []*polysdk.Account) error {
+	return c.WithContext(ctx).ApproveCandidate(peer, validators)
+}
+
+func (c *PolyClient) CommitPolyDposContext(ctx context.Context, accArr []*polysdk.Account) error {
+	return c.WithContext(ctx).CommitPolyDpos(accArr)
+}
+
+func (c *PolyClient) GetBlockByHeightContext(ctx context.Context, height uint32) (*polytype.Block, error) {
+	return c.WithContext(ctx).GetBlockByHeight(height)
+}
+
+func (c *PolyClient) GetCurrentBlockHeightContext(ctx context.Context) (uint32, error) {
+	return c.WithContext(ctx).GetCurrentBlockHeight()
+}
+
+func (c *PolyClient) WaitPolyTxContext(ctx context.Context, hash polycm.Uint256) error {
+	return c.WithContext(ctx).WaitPolyTx(hash)
+}
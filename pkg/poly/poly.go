@@ -2,10 +2,12 @@ package poly
 
 import (
 	"bytes"
+	"context"
 	"crypto/ed25519"
 	"crypto/elliptic"
 	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"strings"
 	"time"
@@ -16,7 +18,9 @@ import (
 	"github.com/ontio/ontology-crypto/ec"
 	"github.com/ontio/ontology-crypto/keypair"
 	"github.com/ontio/ontology-crypto/sm2"
+	"github.com/palettechain/deploy-tool/pkg/errs"
 	"github.com/palettechain/deploy-tool/pkg/log"
+	"github.com/palettechain/deploy-tool/pkg/rpcpool"
 	polysdk "github.com/polynetwork/poly-go-sdk"
 	polycm "github.com/polynetwork/poly/common"
 	vconfig "github.com/polynetwork/poly/consensus/vbft/config"
@@ -26,11 +30,33 @@ import (
 type PolyClient struct {
 	sdk    *polysdk.PolySdk
 	accArr []*polysdk.Account
+	quorum int
+	ctx    context.Context
 }
 
 func NewPolyClient(rpcAddr string, accArr []*polysdk.Account) (*PolyClient, error) {
 	sdk := polysdk.NewPolySdk()
 	sdk.NewRpcClient().SetAddress(rpcAddr)
+	return newPolyClient(sdk, accArr)
+}
+
+// NewPolyClientPool connect to the healthy poly endpoint in pool, every request of the client, e.g:
+// transactions, queries and waiting, is sent through the pool transport, so that it fails over to
+// another healthy endpoint if the current one can not be reached.
+func NewPolyClientPool(pool *rpcpool.Pool, accArr []*polysdk.Account) (*PolyClient, error) {
+	urls := pool.URLs()
+	if len(urls) == 0 {
+		return nil, fmt.Errorf("no poly endpoint")
+	}
+	if pool.Single() {
+		return NewPolyClient(urls[0], accArr)
+	}
+	sdk := polysdk.NewPolySdk()
+	sdk.NewRpcClient().SetAddress(urls[0]).SetHttpClient(pool.HTTPClient())
+	return newPolyClient(sdk, accArr)
+}
+
+func newPolyClient(sdk *polysdk.PolySdk, accArr []*polysdk.Account) (*PolyClient, error) {
 	hdr, err := sdk.GetHeaderByHeight(0)
 	if err != nil {
 		return nil, err
@@ -42,6 +68,67 @@ func NewPolyClient(rpcAddr string, accArr []*polysdk.Account) (*PolyClient, erro
 	}, nil
 }
 
+// Probe check the health of poly endpoint by current height and the chain id of genesis header,
+// it is the probe of poly endpoints pool.
+func Probe(ctx context.Context, url string) (height, chainID uint64, err error) {
+	type result struct {
+		height, chainID uint64
+		err             error
+	}
+	ch := make(chan result, 1)
+	go func() {
+		sdk := polysdk.NewPolySdk()
+		sdk.NewRpcClient().SetAddress(url)
+		hdr, err := sdk.GetHeaderByHeight(0)
+		if err != nil {
+			ch <- result{err: err}
+			return
+		}
+		curr, err := sdk.GetCurrentBlockHeight()
+		ch <- result{height: uint64(curr), chainID: hdr.ChainID, err: err}
+	}()
+
+	select {
+	case <-ctx.Done():
+		return 0, 0, ctx.Err()
+	case r := <-ch:
+		return r.height, r.chainID, r.err
+	}
+}
+
+// SetQuorum set the minimum number of validators signatures needed by multi-party approval.
+func (c *PolyClient) SetQuorum(quorum int) *PolyClient {
+	c.quorum = quorum
+	return c
+}
+
+// WithContext returns a shallow copy of client bound to the context. poly sdk requests can not be
+// canceled, so the context is checked before sending transactions and while waiting them.
+func (c *PolyClient) WithContext(ctx context.Context) *PolyClient {
+	cp := *c
+	cp.ctx = ctx
+	return &cp
+}
+
+// Context returns the context bound to client, and the background context if it is not bound.
+func (c *PolyClient) Context() context.Context {
+	if c.ctx != nil {
+		return c.ctx
+	}
+	return context.Background()
+}
+
+// checkQuorum should be called before any multi-party approval transaction sent.
+func (c *PolyClient) checkQuorum() error {
+	if len(c.accArr) == 0 {
+		return fmt.Errorf("no poly validator loaded")
+	}
+	if len(c.accArr) < c.quorum {
+		return fmt.Errorf("only %d poly validators, quorum %d not reached", len(c.accArr), c.quorum)
+	}
+	return nil
+}
+
 // client的账户列表就是poly共识节点账户列表，可以通过注册和取消账户的方式实现bookKeeper的变更
 func (c *PolyClient) RegNode(node *polysdk.Account) error {
 	validators := c.accArr
@@ -78,8 +165,12 @@ func (c *PolyClient) SyncGenesisBlock(
 	genesisHeader []byte,
 ) error {
 
+	if err := c.checkQuorum(); err != nil {
+		return err
+	}
+	total := len(c.accArr)
 	for idx, acc := range c.accArr {
-		log.Infof("acc-%d %s", idx, acc.Address.ToBase58())
+		log.Infof("[%d/%d] validator %s sign genesis header", idx+1, total, acc.Address.ToBase58())
 	}
 
 	if txhash, err := c.sdk.Native.Hs.SyncGenesisHeader(
@@ -176,13 +267,20 @@ func (c *PolyClient) ApproveRegisterSideChain(chainID uint64) error {
 		txhash polycm.Uint256
 		err    error
 	)
+	if err := c.checkQuorum(); err != nil {
+		return err
+	}
+	total := len(c.accArr)
 	for i, acc := range c.accArr {
+		if err := c.Context().Err(); err != nil {
+			return err
+		}
 		txhash, err = c.sdk.Native.Scm.ApproveRegisterSideChain(chainID, acc)
 		if err != nil {
-			return fmt.Errorf("no%d - failed to approve %d: %v", i, chainID, err)
+			return fmt.Errorf("[%d/%d] validator %s failed to approve %d: %v", i+1, total, acc.Address.ToBase58(), chainID, err)
 		}
-		log.Infof("No%d: successful to approve register side chain %d: ( acc: %s, txhash: %s )",
-			i, chainID, acc.Address.ToHexString(), txhash.ToHexString())
+		log.Infof("[%d/%d] validator %s successful to approve register side chain %d, txhash: %s",
+			i+1, total, acc.Address.ToBase58(), chainID, txhash.ToHexString())
 	}
 	return c.WaitPolyTx(txhash)
 }
@@ -192,13 +290,20 @@ func (c *PolyClient) ApproveQuitSideChain(chainID uint64) error {
 		txhash polycm.Uint256
 		err    error
 	)
+	if err := c.checkQuorum(); err != nil {
+		return err
+	}
+	total := len(c.accArr)
 	for i, acc := range c.accArr {
+		if err := c.Context().Err(); err != nil {
+			return err
+		}
 		txhash, err = c.sdk.Native.Scm.ApproveQuitSideChain(chainID, acc)
 		if err != nil {
-			return fmt.Errorf("no%d - failed to approve %d: %v", i, chainID, err)
+			return fmt.Errorf("[%d/%d] validator %s failed to approve %d: %v", i+1, total, acc.Address.ToBase58(), chainID, err)
 		}
-		log.Infof("No%d: successful to approve quit side chain %d: ( acc: %s, txhash: %s )",
-			i, chainID, acc.Address.ToHexString(), txhash.ToHexString())
+		log.Infof("[%d/%d] validator %s successful to approve quit side chain %d, txhash: %s",
+			i+1, total, acc.Address.ToBase58(), chainID, txhash.ToHexString())
 	}
 	return c.WaitPolyTx(txhash)
 }
@@ -208,13 +313,20 @@ func (c *PolyClient) ApproveUpdateSideChain(chainID uint64) error {
 		txhash polycm.Uint256
 		err    error
 	)
+	if err := c.checkQuorum(); err != nil {
+		return err
+	}
+	total := len(c.accArr)
 	for i, acc := range c.accArr {
+		if err := c.Context().Err(); err != nil {
+			return err
+		}
 		txhash, err = c.sdk.Native.Scm.ApproveUpdateSideChain(chainID, acc)
 		if err != nil {
-			return fmt.Errorf("no%d - failed to approve %d: %v", i, chainID, err)
+			return fmt.Errorf("[%d/%d] validator %s failed to approve %d: %v", i+1, total, acc.Address.ToBase58(), chainID, err)
 		}
-		log.Infof("No%d: successful to approve update side chain %d: ( acc: %s, txhash: %s )",
-			i, chainID, acc.Address.ToHexString(), txhash.ToHexString())
+		log.Infof("[%d/%d] validator %s successful to approve update side chain %d, txhash: %s",
+			i+1, total, acc.Address.ToBase58(), chainID, txhash.ToHexString())
 	}
 	return c.WaitPolyTx(txhash)
 }
@@ -238,6 +350,9 @@ func (c *PolyClient) ApproveCandidate(peer string, validators []*polysdk.Account
 	)
 
 	for index, validator := range validators {
+		if err := c.Context().Err(); err != nil {
+			return err
+		}
 		txhash, err = c.sdk.Native.Nm.ApproveCandidate(peer, validator)
 		if err != nil {
 			return fmt.Errorf("node-%d sendTransaction error: %v", index, err)
@@ -299,24 +414,37 @@ func AssembleNoCompressBookeeper(bookeepers []keypair.PublicKey) []byte {
 	return publickeys
 }
 
+const polyTxTimeout = 300 * time.Second
+
+// WaitPolyTx wait until the poly block height exceed the tx block height, and returns
+// `errs.TimeoutError` if it is not confirmed in 300 seconds or the client context deadline.
 func (c *PolyClient) WaitPolyTx(hash polycm.Uint256) error {
+	ctx, cancel := context.WithTimeout(c.Context(), polyTxTimeout)
+	defer cancel()
+
 	tick := time.NewTicker(100 * time.Millisecond)
-	var h uint32
-	startTime := time.Now()
-	for range tick.C {
-		h, _ = c.sdk.GetBlockHeightByTxHash(hash.ToHexString())
-		curr, _ := c.sdk.GetCurrentBlockHeight()
-		if h > 0 && curr > h {
-			break
+	defer tick.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+				return &errs.TimeoutError{Hash: HashConvertUp(hash), Timeout: polyTxTimeout}
+			}
+			return ctx.Err()
+		case <-tick.C:
 		}
 
-		if startTime.Add(100 * time.Millisecond); startTime.Second() > 300 {
-			return fmt.Errorf("tx( %s ) is not confirm for a long time ( over %d sec )",
-				hash.ToHexString(), 300)
+		h, _ := c.sdk.GetBlockHeightByTxHash(hash.ToHexString())
+		curr, err := c.sdk.GetCurrentBlockHeight()
+		if err != nil {
+			log.Warnf("get poly current height failed, err: %v", err)
+			continue
+		}
+		if h > 0 && curr > h {
+			return nil
 		}
 	}
-
-	return nil
 }
 
 func (c *PolyClient) removeAccount(accIndex int) {
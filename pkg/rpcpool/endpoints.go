@@ -0,0 +1,40 @@
+package rpcpool
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// Endpoints is the rpc urls of one chain, it is configured as a single url or a list of urls, e.g:
+// `"http://127.0.0.1:22000"` or `["http://127.0.0.1:22000", "http://127.0.0.2:22000"]`.
+type Endpoints []string
+
+func (e *Endpoints) UnmarshalJSON(data []byte) error {
+	var url string
+	if err := json.Unmarshal(data, &url); err == nil {
+		*e = Endpoints{}
+		if url != "" {
+			*e = Endpoints{url}
+		}
+		return nil
+	}
+	var urls []string
+	if err := json.Unmarshal(data, &urls); err != nil {
+		return fmt.Errorf("endpoints should be a url or a list of urls, got %s", data)
+	}
+	*e = urls
+	return nil
+}
+
+// MarshalJSON keeps the single url as string, so that the saved config is compatible with old versions.
+func (e Endpoints) MarshalJSON() ([]byte, error) {
+	if len(e) == 1 {
+		return json.Marshal(e[0])
+	}
+	return json.Marshal([]string(e))
+}
+
+func (e Endpoints) String() string {
+	return strings.Join(e, ",")
+}
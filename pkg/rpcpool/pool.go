@@ -0,0 +1,342 @@
+package rpcpool
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/palettechain/deploy-tool/pkg/errs"
+	"github.com/palettechain/deploy-tool/pkg/log"
+)
+
+const (
+	DefaultMaxLag        = 5
+	DefaultCheckTimeout  = 5 * time.Second
+	DefaultCheckInterval = time.Minute
+)
+
+type Options struct {
+	// expected chain id, endpoints of other chains are unhealthy. 0 means the chain id of the first
+	// healthy endpoint in order.
+	ChainID uint64
+	// endpoints fall behind the highest one more than MaxLag blocks are unhealthy, default 5.
+	MaxLag uint64
+	// timeout of every health check request, default 5 seconds.
+	CheckTimeout time.Duration
+	// interval to check the health of the current endpoint, default 1 minute.
+	CheckInterval time.Duration
+	// send reads to another endpoint too and warn if the results mismatch.
+	CompareReads bool
+}
+
+func (o Options) withDefault() Options {
+	if o.MaxLag == 0 {
+		o.MaxLag = DefaultMaxLag
+	}
+	if o.CheckTimeout == 0 {
+		o.CheckTimeout = DefaultCheckTimeout
+	}
+	if o.CheckInterval == 0 {
+		o.CheckInterval = DefaultCheckInterval
+	}
+	return o
+}
+
+// Probe returns the latest height and chain id of the endpoint.
+type Probe func(ctx context.Context, url string) (height, chainID uint64, err error)
+
+// Health is the result of health check of one endpoint.
+type Health struct {
+	URL     string
+	Height  uint64
+	ChainID uint64
+	Err     error
+}
+
+func (h *Health) String() string {
+	if h.Err != nil {
+		return fmt.Sprintf("%s unhealthy, err: %v", h.URL, h.Err)
+	}
+	return fmt.Sprintf("%s height %d chain id %d", h.URL, h.Height, h.ChainID)
+}
+
+// Pool is the endpoints of one chain. requests are routed to the current healthy endpoint, and fail
+// over to another healthy one if the current endpoint can not be reached. the receipt and tx queries
+// are routed to the endpoint which the tx sent to, as long as it is alive and the receipt is not returned.
+type Pool struct {
+	name  string
+	urls  []string
+	opts  Options
+	probe Probe
+	http  *http.Client
+
+	mu      sync.Mutex
+	current string
+	checked time.Time
+	sticky  map[string]string
+}
+
+// New creates the pool of endpoints, health of endpoints is checked on the first request.
+func New(name string, urls []string, opts Options) *Pool {
+	p := &Pool{
+		name:   name,
+		urls:   urls,
+		opts:   opts.withDefault(),
+		http:   &http.Client{Transport: http.DefaultTransport},
+		sticky: make(map[string]string),
+	}
+	p.probe = p.ethProbe
+	return p
+}
+
+// SetProbe replace the health check of eth json-rpc, e.g: poly nodes have their own rpc.
+func (p *Pool) SetProbe(probe Probe) *Pool {
+	p.probe = probe
+	return p
+}
+
+func (p *Pool) Name() string {
+	return p.name
+}
+
+func (p *Pool) URLs() []string {
+	return p.urls
+}
+
+// Single returns true if failover is useless, e.g: only one endpoint or websocket endpoints, and
+// clients should dial the first url directly.
+func (p *Pool) Single() bool {
+	if len(p.urls) < 2 {
+		return true
+	}
+	for _, url := range p.urls {
+		if !strings.HasPrefix(url, "http://") && !strings.HasPrefix(url, "https://") {
+			return true
+		}
+	}
+	return false
+}
+
+// HTTPClient returns the http client which route requests to the healthy endpoint, clients should
+// dial the first url with it.
+func (p *Pool) HTTPClient() *http.Client {
+	return &http.Client{Transport: p}
+}
+
+// Current returns the endpoint in use, and check health of endpoints to pick one if there is not.
+func (p *Pool) Current(ctx context.Context) (string, error) {
+	p.mu.Lock()
+	current, checked := p.current, p.checked
+	p.mu.Unlock()
+
+	if current != "" && time.Since(checked) < p.opts.CheckInterval {
+		return current, nil
+	}
+	return p.pick(ctx, nil)
+}
+
+// Failover pick another healthy endpoint instead of the bad one, and returns the current endpoint
+// directly if it has been switched by others.
+func (p *Pool) Failover(ctx context.Context, bad string) (string, error) {
+	return p.failover(ctx, map[string]bool{bad: true})
+}
+
+// failover pick another healthy endpoint which is not in the bad ones.
+func (p *Pool) failover(ctx context.Context, bad map[string]bool) (string, error) {
+	p.mu.Lock()
+	current := p.current
+	p.mu.Unlock()
+
+	if current != "" && !bad[current] {
+		return current, nil
+	}
+	return p.pick(ctx, bad)
+}
+
+// Check probe all endpoints concurrently.
+func (p *Pool) Check(ctx context.Context) []*Health {
+	list := make([]*Health, len(p.urls))
+	wg := new(sync.WaitGroup)
+	for i, url := range p.urls {
+		wg.Add(1)
+		go func(i int, url string) {
+			defer wg.Done()
+			cctx, cancel := context.WithTimeout(ctx, p.opts.CheckTimeout)
+			defer cancel()
+			h := &Health{URL: url}
+			h.Height, h.ChainID, h.Err = p.probe(cctx, url)
+			list[i] = h
+		}(i, url)
+	}
+	wg.Wait()
+	return list
+}
+
+// pick check health of endpoints and keep the current one if it is still healthy, otherwise pick the
+// first healthy endpoint in order. endpoints in exclude are never picked.
+func (p *Pool) pick(ctx context.Context, exclude map[string]bool) (string, error) {
+	list := p.Check(ctx)
+	healthy := p.filter(list, exclude)
+	if len(healthy) == 0 {
+		reasons := make([]string, 0, len(list))
+		for _, h := range list {
+			reasons = append(reasons, h.String())
+		}
+		return "", errs.RPC(p.name+" endpoints", fmt.Errorf("no healthy endpoint, %s", strings.Join(reasons, "; ")))
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	next := healthy[0]
+	for _, h := range healthy {
+		if h.URL == p.current {
+			next = h
+			break
+		}
+	}
+	if next.URL != p.current {
+		log.Infof("%s endpoint switched from %q to %s", p.name, p.current, next)
+	}
+	p.current = next.URL
+	p.checked = time.Now()
+	return p.current, nil
+}
+
+// filter returns endpoints which reachable, on the expected chain and not lagged, in order.
+func (p *Pool) filter(list []*Health, exclude map[string]bool) []*Health {
+	chainID := p.opts.ChainID
+	alive := make([]*Health, 0, len(list))
+	for _, h := range list {
+		if h.Err != nil || exclude[h.URL] {
+			continue
+		}
+		if chainID == 0 {
+			chainID = h.ChainID
+		}
+		if h.ChainID != chainID {
+			h.Err = fmt.Errorf("chain id %d mismatch, expected %d", h.ChainID, chainID)
+			log.Warnf("%s endpoint %s", p.name, h)
+			continue
+		}
+		alive = append(alive, h)
+	}
+
+	var highest uint64
+	for _, h := range alive {
+		if h.Height > highest {
+			highest = h.Height
+		}
+	}
+	healthy := make([]*Health, 0, len(alive))
+	for _, h := range alive {
+		if h.Height+p.opts.MaxLag < highest {
+			h.Err = fmt.Errorf("height %d lags behind %d", h.Height, highest)
+			log.Warnf("%s endpoint %s", p.name, h)
+			continue
+		}
+		healthy = append(healthy, h)
+	}
+	return healthy
+}
+
+// other returns another endpoint to compare reads, it is not health checked.
+func (p *Pool) other(url string) string {
+	for _, u := range p.urls {
+		if u != url {
+			return u
+		}
+	}
+	return ""
+}
+
+func (p *Pool) stick(hash, url string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.sticky[strings.ToLower(hash)] = url
+}
+
+// unstick drop the endpoint of tx, the tx queries are routed to the current endpoint after that.
+func (p *Pool) unstick(hash string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	delete(p.sticky, strings.ToLower(hash))
+}
+
+func (p *Pool) stuck(hash string) string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.sticky[strings.ToLower(hash)]
+}
+
+type rpcRequest struct {
+	JsonRpc string        `json:"jsonrpc"`
+	Method  string        `json:"method"`
+	Params  []interface{} `json:"params"`
+	Id      uint          `json:"id"`
+}
+
+type rpcResponse struct {
+	Result json.RawMessage `json:"result"`
+	Error  *struct {
+		Code    int    `json:"code"`
+		Message string `json:"message"`
+	} `json:"error"`
+}
+
+// call send the json-rpc request to the endpoint directly.
+func (p *Pool) call(ctx context.Context, url, method string, result interface{}) error {
+	data, err := json.Marshal(&rpcRequest{JsonRpc: "2.0", Method: method, Params: []interface{}{}, Id: 1})
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := p.http.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("%s %s", method, resp.Status)
+	}
+
+	rsp := new(rpcResponse)
+	if err := json.Unmarshal(body, rsp); err != nil {
+		return fmt.Errorf("%s unmarshal response failed, err: %v", method, err)
+	}
+	if rsp.Error != nil {
+		return fmt.Errorf("%s failed, code %d, err: %s", method, rsp.Error.Code, rsp.Error.Message)
+	}
+	return json.Unmarshal(rsp.Result, result)
+}
+
+// ethProbe check the health by `eth_blockNumber` and `eth_chainId`.
+func (p *Pool) ethProbe(ctx context.Context, url string) (height, chainID uint64, err error) {
+	var hexHeight, hexChainID string
+	if err = p.call(ctx, url, "eth_blockNumber", &hexHeight); err != nil {
+		return
+	}
+	if err = p.call(ctx, url, "eth_chainId", &hexChainID); err != nil {
+		return
+	}
+	if height, err = strconv.ParseUint(hexHeight, 0, 64); err != nil {
+		return
+	}
+	chainID, err = strconv.ParseUint(hexChainID, 0, 64)
+	return
+}
@@ -0,0 +1,261 @@
+package rpcpool
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeNode responds `eth_blockNumber`, `eth_chainId` and `eth_sendRawTransaction`, and record
+// the methods it received.
+type fakeNode struct {
+	*httptest.Server
+	lock    sync.Mutex
+	height  uint64
+	chainID uint64
+	methods []string
+	// status and json-rpc error responded to `eth_sendRawTransaction`
+	sendStatus int
+	sendErr    string
+	// `eth_getTransactionByHash` responds null
+	noTx bool
+}
+
+func newFakeNode(height, chainID uint64) *fakeNode {
+	n := &fakeNode{height: height, chainID: chainID}
+	n.Server = httptest.NewServer(http.HandlerFunc(n.serve))
+	return n
+}
+
+func (n *fakeNode) serve(w http.ResponseWriter, r *http.Request) {
+	body, _ := ioutil.ReadAll(r.Body)
+	msg := new(message)
+	_ = json.Unmarshal(body, msg)
+
+	n.lock.Lock()
+	n.methods = append(n.methods, msg.Method)
+	sendStatus, sendErr, noTx := n.sendStatus, n.sendErr, n.noTx
+	n.lock.Unlock()
+
+	var result interface{}
+	switch msg.Method {
+	case "eth_blockNumber":
+		result = fmt.Sprintf("0x%x", n.height)
+	case "eth_chainId":
+		result = fmt.Sprintf("0x%x", n.chainID)
+	case "eth_sendRawTransaction":
+		if sendStatus != 0 {
+			w.WriteHeader(sendStatus)
+			return
+		}
+		if sendErr != "" {
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"jsonrpc": "2.0", "id": 1,
+				"error": map[string]interface{}{"code": -32000, "message": sendErr}})
+			return
+		}
+		result = "0xAB"
+	case "eth_getTransactionByHash":
+		if noTx {
+			result = nil
+		} else {
+			result = n.URL
+		}
+	default:
+		result = n.URL
+	}
+	_ = json.NewEncoder(w).Encode(map[string]interface{}{"jsonrpc": "2.0", "id": 1, "result": result})
+}
+
+func (n *fakeNode) received(method string) int {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+	cnt := 0
+	for _, m := range n.methods {
+		if m == method {
+			cnt++
+		}
+	}
+	return cnt
+}
+
+// post send the json-rpc request through pool, and returns the result.
+func post(t *testing.T, p *Pool, method string, params ...interface{}) string {
+	data, _ := json.Marshal(map[string]interface{}{"jsonrpc": "2.0", "id": 1, "method": method, "params": params})
+	resp, err := p.HTTPClient().Post(p.URLs()[0], "application/json", bytes.NewReader(data))
+	assert.NoError(t, err)
+	defer resp.Body.Close()
+
+	rsp := new(rpcResponse)
+	assert.NoError(t, json.NewDecoder(resp.Body).Decode(rsp))
+	var result string
+	assert.NoError(t, json.Unmarshal(rsp.Result, &result))
+	return result
+}
+
+func TestEndpointsJSON(t *testing.T) {
+	var list Endpoints
+	assert.NoError(t, json.Unmarshal([]byte(`"http://a"`), &list))
+	assert.Equal(t, Endpoints{"http://a"}, list)
+	enc, err := json.Marshal(list)
+	assert.NoError(t, err)
+	assert.Equal(t, `"http://a"`, string(enc))
+
+	assert.NoError(t, json.Unmarshal([]byte(`["http://a","http://b"]`), &list))
+	assert.Equal(t, Endpoints{"http://a", "http://b"}, list)
+	enc, err = json.Marshal(list)
+	assert.NoError(t, err)
+	assert.Equal(t, `["http://a","http://b"]`, string(enc))
+
+	assert.Error(t, json.Unmarshal([]byte(`1`), &list))
+}
+
+func TestPickHealthy(t *testing.T) {
+	wrongChain := newFakeNode(100, 2)
+	defer wrongChain.Close()
+	lagged := newFakeNode(90, 1)
+	defer lagged.Close()
+	healthy := newFakeNode(100, 1)
+	defer healthy.Close()
+
+	p := New("test", []string{wrongChain.URL, lagged.URL, healthy.URL}, Options{ChainID: 1})
+	current, err := p.Current(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, healthy.URL, current)
+
+	// chain id of the first healthy endpoint is expected if not configured
+	p = New("test", []string{lagged.URL, wrongChain.URL}, Options{MaxLag: 20})
+	current, err = p.Current(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, lagged.URL, current)
+
+	wrongChain.Close()
+	p = New("test", []string{wrongChain.URL}, Options{})
+	_, err = p.Current(context.Background())
+	assert.Error(t, err)
+}
+
+func TestFailover(t *testing.T) {
+	first := newFakeNode(100, 1)
+	second := newFakeNode(100, 1)
+	defer second.Close()
+
+	p := New("test", []string{first.URL, second.URL}, Options{})
+	assert.Equal(t, first.URL, post(t, p, "eth_getCode"))
+
+	first.Close()
+	assert.Equal(t, second.URL, post(t, p, "eth_getCode"))
+	// keep the new endpoint
+	assert.Equal(t, second.URL, post(t, p, "eth_getCode"))
+}
+
+func TestFailoverAllFailed(t *testing.T) {
+	first := newFakeNode(100, 1)
+	defer first.Close()
+	second := newFakeNode(100, 1)
+	defer second.Close()
+	first.sendStatus, second.sendStatus = http.StatusBadGateway, http.StatusBadGateway
+
+	// healthy endpoints respond 5xx are tried once, instead of switching between them forever
+	p := New("test", []string{first.URL, second.URL}, Options{})
+	data, _ := json.Marshal(map[string]interface{}{"jsonrpc": "2.0", "id": 1, "method": "eth_sendRawTransaction", "params": []string{"0x00"}})
+	_, err := p.HTTPClient().Post(first.URL, "application/json", bytes.NewReader(data))
+	assert.Error(t, err)
+	assert.Equal(t, 1, first.received("eth_sendRawTransaction"))
+	assert.Equal(t, 1, second.received("eth_sendRawTransaction"))
+}
+
+func TestStickyTx(t *testing.T) {
+	first := newFakeNode(100, 1)
+	defer first.Close()
+	second := newFakeNode(100, 1)
+	defer second.Close()
+
+	p := New("test", []string{first.URL, second.URL}, Options{})
+	assert.Equal(t, "0xAB", post(t, p, "eth_sendRawTransaction", "0x00"))
+
+	// switch current endpoint, the receipt query of the tx is still sent to the first one
+	_, err := p.Failover(context.Background(), first.URL)
+	assert.NoError(t, err)
+	assert.Equal(t, second.URL, post(t, p, "eth_getCode"))
+	assert.Equal(t, first.URL, post(t, p, "eth_getTransactionReceipt", "0xab"))
+	assert.Equal(t, second.URL, post(t, p, "eth_getTransactionReceipt", "0xcd"))
+}
+
+func TestCompareReads(t *testing.T) {
+	first := newFakeNode(100, 1)
+	defer first.Close()
+	second := newFakeNode(100, 1)
+	defer second.Close()
+
+	p := New("test", []string{first.URL, second.URL}, Options{CompareReads: true})
+	assert.Equal(t, first.URL, post(t, p, "eth_call"))
+	assert.Equal(t, 1, second.received("eth_call"))
+
+	post(t, p, "eth_getTransactionCount")
+	assert.Equal(t, 0, second.received("eth_getTransactionCount"))
+}
+
+func TestResendKnownTx(t *testing.T) {
+	raw := "0x00"
+	hash := crypto.Keccak256Hash([]byte{0x00}).Hex()
+
+	for _, c := range []struct {
+		sendErr string
+		noTx    bool
+		known   bool
+	}{
+		{"already known", true, true},
+		{"nonce too low", false, true},
+		{"nonce too low", true, false},
+	} {
+		first := newFakeNode(100, 1)
+		first.sendStatus = http.StatusBadGateway
+		second := newFakeNode(100, 1)
+		second.sendErr, second.noTx = c.sendErr, c.noTx
+
+		p := New("test", []string{first.URL, second.URL}, Options{})
+		data, _ := json.Marshal(map[string]interface{}{"jsonrpc": "2.0", "id": 7, "method": "eth_sendRawTransaction", "params": []string{raw}})
+		resp, err := p.HTTPClient().Post(first.URL, "application/json", bytes.NewReader(data))
+		assert.NoError(t, err)
+		rsp := new(rpcResponse)
+		assert.NoError(t, json.NewDecoder(resp.Body).Decode(rsp))
+		resp.Body.Close()
+
+		if c.known {
+			assert.Nil(t, rsp.Error)
+			assert.Equal(t, `"`+hash+`"`, string(rsp.Result))
+			assert.Equal(t, second.URL, p.stuck(hash))
+		} else {
+			assert.NotNil(t, rsp.Error)
+			assert.Equal(t, "", p.stuck(hash))
+		}
+		first.Close()
+		second.Close()
+	}
+}
+
+func TestUnstickAfterReceipt(t *testing.T) {
+	first := newFakeNode(100, 1)
+	defer first.Close()
+	second := newFakeNode(100, 1)
+	defer second.Close()
+
+	p := New("test", []string{first.URL, second.URL}, Options{})
+	assert.Equal(t, "0xAB", post(t, p, "eth_sendRawTransaction", "0x00"))
+	_, err := p.Failover(context.Background(), first.URL)
+	assert.NoError(t, err)
+
+	// the receipt returned by the endpoint which the tx sent to, and the next query is routed to the current one
+	assert.Equal(t, first.URL, post(t, p, "eth_getTransactionReceipt", "0xab"))
+	assert.Equal(t, "", p.stuck("0xab"))
+	assert.Equal(t, second.URL, post(t, p, "eth_getTransactionReceipt", "0xab"))
+}
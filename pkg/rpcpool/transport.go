@@ -0,0 +1,280 @@
+package rpcpool
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/palettechain/deploy-tool/pkg/log"
+)
+
+// message is the single json-rpc request, batch requests are routed to the current endpoint as it is.
+type message struct {
+	ID     json.RawMessage   `json:"id"`
+	Method string            `json:"method"`
+	Params []json.RawMessage `json:"params"`
+}
+
+// hash returns the first param as tx hash.
+func (m *message) hash() string {
+	if m == nil || len(m.Params) == 0 {
+		return ""
+	}
+	var hash string
+	if err := json.Unmarshal(m.Params[0], &hash); err != nil {
+		return ""
+	}
+	return hash
+}
+
+// txHash returns the hash of the raw tx in the first param, which is the keccak256 of the raw tx.
+func (m *message) txHash() string {
+	raw, err := hexutil.Decode(m.hash())
+	if err != nil || len(raw) == 0 {
+		return ""
+	}
+	return crypto.Keccak256Hash(raw).Hex()
+}
+
+// methods of tx queries routed to the endpoint which the tx sent to.
+var stickyMethods = map[string]bool{
+	"eth_getTransactionByHash":  true,
+	"eth_getTransactionReceipt": true,
+}
+
+// methods of reads compared with another endpoint if `CompareReads` enabled.
+var readMethods = map[string]bool{
+	"eth_chainId":      true,
+	"eth_getCode":      true,
+	"eth_getBalance":   true,
+	"eth_getStorageAt": true,
+	"eth_call":         true,
+}
+
+// RoundTrip implements `http.RoundTripper`, the request is sent to the routed endpoint, and sent to
+// another healthy endpoint again if the endpoint can not be reached or responds 5xx. every endpoint is
+// tried once at most in one round trip, and the last error is returned if all of them failed. the raw tx sent
+// again may have been accepted by the failed endpoint, so the error of the next endpoint is turned into
+// the tx hash if the tx is known by it.
+func (p *Pool) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+	body, err := readBody(req)
+	if err != nil {
+		return nil, err
+	}
+	msg := new(message)
+	if err := json.Unmarshal(body, msg); err != nil {
+		msg = nil
+	}
+
+	target, err := p.route(req, msg)
+	if err != nil {
+		return nil, err
+	}
+	resent := false
+	tried := map[string]bool{target: true}
+	for {
+		resp, err := p.send(req, target, body)
+		if err == nil && resp.StatusCode < http.StatusInternalServerError {
+			if resent && msg != nil && msg.Method == "eth_sendRawTransaction" {
+				if resp, err = p.knownTx(req, msg, target, resp); err != nil {
+					return nil, err
+				}
+			}
+			return p.after(req, msg, target, body, resp)
+		}
+		if ctx.Err() != nil {
+			return resp, err
+		}
+		if err == nil {
+			err = fmt.Errorf("http status %s", resp.Status)
+			resp.Body.Close()
+		}
+
+		next, ferr := p.failover(ctx, tried)
+		if ferr != nil {
+			return nil, fmt.Errorf("%s endpoint %s failed, err: %v, and %v", p.name, target, err, ferr)
+		}
+		log.Warnf("%s endpoint %s failed, err: %v, fail over to %s", p.name, target, err, next)
+		target = next
+		tried[target] = true
+		resent = true
+	}
+}
+
+// knownTx returns the response with tx hash if the raw tx sent again is rejected by the endpoint, e.g:
+// `already known` or `nonce too low`, but the tx can be found in it.
+func (p *Pool) knownTx(req *http.Request, msg *message, target string, resp *http.Response) (*http.Response, error) {
+	data, err := ioutil.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = ioutil.NopCloser(bytes.NewReader(data))
+
+	rsp := new(rpcResponse)
+	if err := json.Unmarshal(data, rsp); err != nil || rsp.Error == nil {
+		return resp, nil
+	}
+	hash := msg.txHash()
+	if hash == "" {
+		return resp, nil
+	}
+	if !alreadyKnown(rsp.Error.Message) && !p.hasTx(req, target, hash) {
+		return resp, nil
+	}
+	log.Warnf("%s tx %s sent again to %s, err: %s, the tx is known", p.name, hash, target, rsp.Error.Message)
+
+	id := msg.ID
+	if len(id) == 0 {
+		id = json.RawMessage("null")
+	}
+	enc, err := json.Marshal(map[string]interface{}{"jsonrpc": "2.0", "id": id, "result": hash})
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = ioutil.NopCloser(bytes.NewReader(enc))
+	resp.ContentLength = int64(len(enc))
+	return resp, nil
+}
+
+// alreadyKnown returns true if the node rejects the same raw tx in its mempool.
+func alreadyKnown(message string) bool {
+	message = strings.ToLower(message)
+	return strings.Contains(message, "already known") || strings.Contains(message, "known transaction")
+}
+
+// hasTx returns true if the tx can be found in the endpoint.
+func (p *Pool) hasTx(req *http.Request, target, hash string) bool {
+	body, err := json.Marshal(&rpcRequest{JsonRpc: "2.0", Method: "eth_getTransactionByHash", Params: []interface{}{hash}, Id: 1})
+	if err != nil {
+		return false
+	}
+	resp, err := p.send(req, target, body)
+	if err != nil {
+		return false
+	}
+	defer resp.Body.Close()
+	data, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return false
+	}
+	rsp := new(rpcResponse)
+	if err := json.Unmarshal(data, rsp); err != nil || rsp.Error != nil {
+		return false
+	}
+	return len(rsp.Result) > 0 && string(rsp.Result) != "null"
+}
+
+// route returns the endpoint which the tx sent to for tx queries, otherwise the current endpoint.
+func (p *Pool) route(req *http.Request, msg *message) (string, error) {
+	if msg != nil && stickyMethods[msg.Method] {
+		if sticky := p.stuck(msg.hash()); sticky != "" {
+			return sticky, nil
+		}
+	}
+	return p.Current(req.Context())
+}
+
+// after record the endpoint which the tx sent to, drop it once the receipt returned, and compare reads
+// if needed.
+func (p *Pool) after(req *http.Request, msg *message, target string, body []byte, resp *http.Response) (*http.Response, error) {
+	if msg == nil {
+		return resp, nil
+	}
+	switch {
+	case msg.Method == "eth_sendRawTransaction", msg.Method == "eth_getTransactionReceipt":
+	case p.opts.CompareReads && readMethods[msg.Method]:
+	default:
+		return resp, nil
+	}
+
+	data, err := ioutil.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = ioutil.NopCloser(bytes.NewReader(data))
+
+	rsp := new(rpcResponse)
+	if err := json.Unmarshal(data, rsp); err != nil || rsp.Error != nil {
+		return resp, nil
+	}
+	switch msg.Method {
+	case "eth_sendRawTransaction":
+		var hash string
+		if err := json.Unmarshal(rsp.Result, &hash); err == nil {
+			p.stick(hash, target)
+		}
+		return resp, nil
+	case "eth_getTransactionReceipt":
+		if len(rsp.Result) > 0 && string(rsp.Result) != "null" {
+			p.unstick(msg.hash())
+		}
+		return resp, nil
+	}
+	p.compare(req, msg, target, body, rsp.Result)
+	return resp, nil
+}
+
+// compare send the read to another endpoint, and warn if the results mismatch.
+func (p *Pool) compare(req *http.Request, msg *message, target string, body []byte, result json.RawMessage) {
+	other := p.other(target)
+	if other == "" {
+		return
+	}
+	resp, err := p.send(req, other, body)
+	if err != nil {
+		log.Debugf("%s compare %s with %s failed, err: %v", p.name, msg.Method, other, err)
+		return
+	}
+	defer resp.Body.Close()
+	data, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return
+	}
+	rsp := new(rpcResponse)
+	if err := json.Unmarshal(data, rsp); err != nil || rsp.Error != nil {
+		log.Debugf("%s compare %s with %s failed, response %s", p.name, msg.Method, other, data)
+		return
+	}
+	if !bytes.Equal(compact(result), compact(rsp.Result)) {
+		log.Warnf("%s %s results mismatch, %s: %s, %s: %s", p.name, msg.Method, target, result, other, rsp.Result)
+	}
+}
+
+// send the request body to the endpoint.
+func (p *Pool) send(req *http.Request, target string, body []byte) (*http.Response, error) {
+	u, err := url.Parse(target)
+	if err != nil {
+		return nil, err
+	}
+	r := req.Clone(req.Context())
+	r.URL = u
+	r.Host = u.Host
+	r.Body = ioutil.NopCloser(bytes.NewReader(body))
+	r.ContentLength = int64(len(body))
+	return p.http.Transport.RoundTrip(r)
+}
+
+func readBody(req *http.Request) ([]byte, error) {
+	if req.Body == nil {
+		return nil, nil
+	}
+	defer req.Body.Close()
+	return ioutil.ReadAll(req.Body)
+}
+
+func compact(data []byte) []byte {
+	buf := new(bytes.Buffer)
+	if err := json.Compact(buf, data); err != nil {
+		return data
+	}
+	return buf.Bytes()
+}
@@ -0,0 +1,139 @@
+package scan
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math/big"
+)
+
+var errEOF = errors.New("unexpected end of payload")
+
+// TxParam is the cross chain tx param serialized by ECCM, which is the `rawdata` of `CrossChainEvent`
+// and relayed to the target chain by poly.
+type TxParam struct {
+	TxHash       []byte
+	CrossChainID []byte
+	FromContract []byte
+	ToChainID    uint64
+	ToContract   []byte
+	Method       string
+	Args         []byte
+}
+
+// TxArgs is the args of lock proxy `unlock` method in tx param. Amount is the token id of NFT, and the
+// TokenURI is only set by NFT lock proxy.
+type TxArgs struct {
+	ToAsset   []byte
+	ToAddress []byte
+	Amount    *big.Int
+	TokenURI  []byte
+}
+
+// DecodeTxParam decode the tx param serialized by ECCM with poly zero copy sink.
+func DecodeTxParam(raw []byte) (*TxParam, error) {
+	s := &source{data: raw}
+	param := &TxParam{
+		TxHash:       s.varBytes(),
+		CrossChainID: s.varBytes(),
+		FromContract: s.varBytes(),
+		ToChainID:    s.uint64(),
+		ToContract:   s.varBytes(),
+		Method:       string(s.varBytes()),
+		Args:         s.varBytes(),
+	}
+	if s.err != nil {
+		return nil, fmt.Errorf("decode tx param failed, err: %v", s.err)
+	}
+	return param, nil
+}
+
+// DecodeTxArgs decode the args of lock proxy and NFT lock proxy, the amount is serialized as uint256 in
+// little endian.
+func DecodeTxArgs(raw []byte) (*TxArgs, error) {
+	s := &source{data: raw}
+	args := &TxArgs{
+		ToAsset:   s.varBytes(),
+		ToAddress: s.varBytes(),
+		Amount:    s.uint256(),
+	}
+	if s.err == nil && s.len() > 0 {
+		args.TokenURI = s.varBytes()
+	}
+	if s.err != nil {
+		return nil, fmt.Errorf("decode tx args failed, err: %v", s.err)
+	}
+	return args, nil
+}
+
+// source reads the poly zero copy serialization, the first error is kept and the following reads
+// return zero values.
+type source struct {
+	data []byte
+	off  int
+	err  error
+}
+
+func (s *source) len() int {
+	return len(s.data) - s.off
+}
+
+func (s *source) next(n uint64) []byte {
+	if s.err != nil {
+		return nil
+	}
+	if n > uint64(s.len()) {
+		s.err = errEOF
+		return nil
+	}
+	b := s.data[s.off : s.off+int(n)]
+	s.off += int(n)
+	return b
+}
+
+func (s *source) uint64() uint64 {
+	b := s.next(8)
+	if b == nil {
+		return 0
+	}
+	return binary.LittleEndian.Uint64(b)
+}
+
+func (s *source) uint256() *big.Int {
+	b := s.next(32)
+	if b == nil {
+		return nil
+	}
+	be := make([]byte, len(b))
+	for i := range b {
+		be[len(b)-1-i] = b[i]
+	}
+	return new(big.Int).SetBytes(be)
+}
+
+func (s *source) varUint() uint64 {
+	b := s.next(1)
+	if b == nil {
+		return 0
+	}
+	switch b[0] {
+	case 0xfd:
+		if b = s.next(2); b != nil {
+			return uint64(binary.LittleEndian.Uint16(b))
+		}
+	case 0xfe:
+		if b = s.next(4); b != nil {
+			return uint64(binary.LittleEndian.Uint32(b))
+		}
+	case 0xff:
+		return s.uint64()
+	default:
+		return uint64(b[0])
+	}
+	return 0
+}
+
+func (s *source) varBytes() []byte {
+	n := s.varUint()
+	return s.next(n)
+}
@@ -0,0 +1,75 @@
+package scan
+
+import (
+	"encoding/binary"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// sink writes the poly zero copy serialization like ECCM and lock proxies.
+type sink []byte
+
+func (s *sink) varBytes(b []byte) *sink {
+	switch n := len(b); {
+	case n < 0xfd:
+		*s = append(*s, byte(n))
+	case n <= 0xffff:
+		*s = append(*s, 0xfd, byte(n), byte(n>>8))
+	default:
+		*s = append(*s, 0xfe)
+		*s = append(*s, make([]byte, 4)...)
+		binary.LittleEndian.PutUint32((*s)[len(*s)-4:], uint32(n))
+	}
+	*s = append(*s, b...)
+	return s
+}
+
+func (s *sink) uint64(v uint64) *sink {
+	*s = append(*s, make([]byte, 8)...)
+	binary.LittleEndian.PutUint64((*s)[len(*s)-8:], v)
+	return s
+}
+
+func (s *sink) uint256(v *big.Int) *sink {
+	be := make([]byte, 32)
+	v.FillBytes(be)
+	for i := 31; i >= 0; i-- {
+		*s = append(*s, be[i])
+	}
+	return s
+}
+
+func TestDecodeTxParam(t *testing.T) {
+	args := new(sink).varBytes([]byte{0x01, 0x03}).varBytes([]byte{0xaa, 0xbb}).uint256(big.NewInt(1000))
+	long := make([]byte, 300)
+	raw := new(sink).varBytes([]byte{0x01}).varBytes(long).varBytes([]byte{0x02}).uint64(101).
+		varBytes([]byte{0x03}).varBytes([]byte("unlock")).varBytes(*args)
+
+	param, err := DecodeTxParam(*raw)
+	assert.NoError(t, err)
+	assert.Equal(t, []byte{0x01}, param.TxHash)
+	assert.Equal(t, long, param.CrossChainID)
+	assert.Equal(t, uint64(101), param.ToChainID)
+	assert.Equal(t, "unlock", param.Method)
+
+	decoded, err := DecodeTxArgs(param.Args)
+	assert.NoError(t, err)
+	assert.Equal(t, []byte{0x01, 0x03}, decoded.ToAsset)
+	assert.Equal(t, []byte{0xaa, 0xbb}, decoded.ToAddress)
+	assert.Equal(t, big.NewInt(1000), decoded.Amount)
+	assert.Nil(t, decoded.TokenURI)
+
+	// truncated payload
+	_, err = DecodeTxParam((*raw)[:len(*raw)-1])
+	assert.Error(t, err)
+}
+
+func TestDecodeNFTTxArgs(t *testing.T) {
+	args := new(sink).varBytes([]byte{0x01}).varBytes([]byte{0x02}).uint256(big.NewInt(7)).varBytes([]byte("ipfs://7"))
+	decoded, err := DecodeTxArgs(*args)
+	assert.NoError(t, err)
+	assert.Equal(t, big.NewInt(7), decoded.Amount)
+	assert.Equal(t, []byte("ipfs://7"), decoded.TokenURI)
+}
@@ -0,0 +1,167 @@
+package scan
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/palettechain/deploy-tool/pkg/errs"
+	"github.com/palettechain/deploy-tool/pkg/log"
+)
+
+const (
+	// DefaultBatchSize is the number of blocks in one `eth_getLogs` request.
+	DefaultBatchSize = 1000
+	// DefaultConfirmations is the number of blocks including the scanned block, the blocks with less
+	// confirmations may be reorged, so they are never saved in checkpoint.
+	DefaultConfirmations = 12
+)
+
+// Backend is the chain reader used to scan logs, which is implemented by `ethclient.Client`.
+type Backend interface {
+	FilterLogs(ctx context.Context, q ethereum.FilterQuery) ([]types.Log, error)
+	HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error)
+}
+
+// Checkpoint stores the last scanned block number, so that the interrupted scan is resumed after it.
+type Checkpoint interface {
+	Load() (height uint64, ok bool, err error)
+	Save(height uint64) error
+}
+
+// Scanner fetch logs of contracts over a block range batch by batch.
+type Scanner struct {
+	backend    Backend
+	addresses  []common.Address
+	checkpoint Checkpoint
+	batchSize  uint64
+	confirms   uint64
+}
+
+func New(backend Backend, addresses ...common.Address) *Scanner {
+	return &Scanner{backend: backend, addresses: addresses, batchSize: DefaultBatchSize, confirms: DefaultConfirmations}
+}
+
+// SetBatchSize set the number of blocks in one request, the batch is halved if the node refuses it.
+func (s *Scanner) SetBatchSize(size uint64) *Scanner {
+	if size > 0 {
+		s.batchSize = size
+	}
+	return s
+}
+
+// SetConfirmations set the number of blocks including the scanned block, 1 means the latest block.
+func (s *Scanner) SetConfirmations(confirmations uint64) *Scanner {
+	if confirmations > 0 {
+		s.confirms = confirmations
+	}
+	return s
+}
+
+func (s *Scanner) SetCheckpoint(checkpoint Checkpoint) *Scanner {
+	s.checkpoint = checkpoint
+	return s
+}
+
+// Head returns the latest block number with enough confirmations, the blocks after it may be reorged.
+func (s *Scanner) Head(ctx context.Context) (uint64, error) {
+	head, err := s.backend.HeaderByNumber(ctx, nil)
+	if err != nil {
+		return 0, errs.RPC("eth_getBlockByNumber", err)
+	}
+	latest := head.Number.Uint64()
+	if latest+1 < s.confirms {
+		return 0, nil
+	}
+	return latest + 1 - s.confirms, nil
+}
+
+// Resume returns the next block of the checkpoint, ok is false if there is no checkpoint.
+func (s *Scanner) Resume() (next uint64, ok bool, err error) {
+	if s.checkpoint == nil {
+		return 0, false, nil
+	}
+	height, ok, err := s.checkpoint.Load()
+	if err != nil {
+		return 0, false, fmt.Errorf("load checkpoint failed, err: %v", err)
+	}
+	if !ok {
+		return 0, false, nil
+	}
+	return height + 1, true, nil
+}
+
+// Scan fetch logs of the contracts in blocks [from, to], and handle is called with the logs of every
+// batch in order. the checkpoint is saved after the batch handled, so the scan can be resumed from
+// the failed batch. the blocks after `Head` are scanned if to is after it, but they are not saved in
+// checkpoint, so they are scanned again on resume.
+func (s *Scanner) Scan(ctx context.Context, from, to uint64, handle func(logs []types.Log) error) error {
+	var safe uint64
+	if s.checkpoint != nil {
+		var err error
+		if safe, err = s.Head(ctx); err != nil {
+			return err
+		}
+	}
+
+	size := s.batchSize
+	for start := from; start <= to; {
+		end := start + size - 1
+		if end > to || end < start {
+			end = to
+		}
+
+		logs, err := s.backend.FilterLogs(ctx, ethereum.FilterQuery{
+			FromBlock: new(big.Int).SetUint64(start),
+			ToBlock:   new(big.Int).SetUint64(end),
+			Addresses: s.addresses,
+		})
+		if err != nil {
+			if ctx.Err() == nil && size > 1 && rangeTooLarge(err) {
+				size /= 2
+				log.Debugf("logs of blocks [%d, %d] too many, scan %d blocks in batch, err: %v", start, end, size, err)
+				continue
+			}
+			return fmt.Errorf("filter logs of blocks [%d, %d] failed, err: %w", start, end, errs.RPC("eth_getLogs", err))
+		}
+		if err := handle(logs); err != nil {
+			return err
+		}
+		if saved := min(end, safe); s.checkpoint != nil && saved >= start {
+			if err := s.checkpoint.Save(saved); err != nil {
+				return fmt.Errorf("save checkpoint %d failed, err: %v", saved, err)
+			}
+		}
+		log.Debugf("scanned blocks [%d, %d], %d logs", start, end, len(logs))
+
+		if end == to {
+			break
+		}
+		start = end + 1
+	}
+	return nil
+}
+
+func min(a, b uint64) uint64 {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+// rangeTooLarge returns true if the node refuses the query of too many blocks or logs, the messages
+// differ between node implementations, e.g: `query returned more than 10000 results`, `block range
+// too large`, `exceed maximum block range` and `log response size exceeded`.
+func rangeTooLarge(err error) bool {
+	msg := strings.ToLower(err.Error())
+	for _, s := range []string{"more than", "too large", "too many", "too wide", "limit exceeded", "size exceeded", "block range"} {
+		if strings.Contains(msg, s) {
+			return true
+		}
+	}
+	return false
+}
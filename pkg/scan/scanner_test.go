@@ -0,0 +1,129 @@
+package scan
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeBackend returns one log per block, and refuses the ranges larger than limit.
+type fakeBackend struct {
+	limit  uint64
+	ranges [][2]uint64
+	// latest block, default 100
+	head int64
+}
+
+func (b *fakeBackend) FilterLogs(ctx context.Context, q ethereum.FilterQuery) ([]types.Log, error) {
+	from, to := q.FromBlock.Uint64(), q.ToBlock.Uint64()
+	if to-from+1 > b.limit {
+		return nil, errors.New("query returned more than 10000 results")
+	}
+	b.ranges = append(b.ranges, [2]uint64{from, to})
+	logs := make([]types.Log, 0)
+	for n := from; n <= to; n++ {
+		logs = append(logs, types.Log{BlockNumber: n})
+	}
+	return logs, nil
+}
+
+func (b *fakeBackend) HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error) {
+	if b.head == 0 {
+		return &types.Header{Number: big.NewInt(100)}, nil
+	}
+	return &types.Header{Number: big.NewInt(b.head)}, nil
+}
+
+type memCheckpoint struct {
+	height uint64
+	ok     bool
+}
+
+func (c *memCheckpoint) Load() (uint64, bool, error) { return c.height, c.ok, nil }
+func (c *memCheckpoint) Save(height uint64) error    { c.height, c.ok = height, true; return nil }
+
+func TestScan(t *testing.T) {
+	backend := &fakeBackend{limit: 4}
+	checkpoint := &memCheckpoint{}
+	scanner := New(backend).SetBatchSize(10).SetCheckpoint(checkpoint)
+
+	_, ok, err := scanner.Resume()
+	assert.NoError(t, err)
+	assert.False(t, ok)
+
+	var blocks []uint64
+	err = scanner.Scan(context.Background(), 1, 9, func(logs []types.Log) error {
+		for _, l := range logs {
+			blocks = append(blocks, l.BlockNumber)
+		}
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []uint64{1, 2, 3, 4, 5, 6, 7, 8, 9}, blocks)
+	// batch halved from 10 to 5 and 2 blocks
+	assert.Equal(t, [][2]uint64{{1, 2}, {3, 4}, {5, 6}, {7, 8}, {9, 9}}, backend.ranges)
+
+	next, ok, err := scanner.Resume()
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, uint64(10), next)
+}
+
+func TestScanHandleFailed(t *testing.T) {
+	checkpoint := &memCheckpoint{}
+	scanner := New(&fakeBackend{limit: 10}).SetBatchSize(2).SetCheckpoint(checkpoint)
+
+	err := scanner.Scan(context.Background(), 1, 6, func(logs []types.Log) error {
+		if logs[0].BlockNumber == 3 {
+			return errors.New("handle failed")
+		}
+		return nil
+	})
+	assert.Error(t, err)
+	// resumed from the failed batch
+	next, _, _ := scanner.Resume()
+	assert.Equal(t, uint64(3), next)
+}
+
+func TestScanConfirmations(t *testing.T) {
+	checkpoint := &memCheckpoint{}
+	scanner := New(&fakeBackend{limit: 10, head: 20}).SetBatchSize(4).SetConfirmations(12).SetCheckpoint(checkpoint)
+
+	head, err := scanner.Head(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(9), head)
+
+	// the blocks after 9 are scanned but not saved in checkpoint
+	var blocks int
+	err = scanner.Scan(context.Background(), 1, 20, func(logs []types.Log) error {
+		blocks += len(logs)
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, 20, blocks)
+	next, _, _ := scanner.Resume()
+	assert.Equal(t, uint64(10), next)
+
+	// the checkpoint is not moved if no confirmed block scanned
+	err = scanner.Scan(context.Background(), 15, 20, func(logs []types.Log) error { return nil })
+	assert.NoError(t, err)
+	next, _, _ = scanner.Resume()
+	assert.Equal(t, uint64(10), next)
+
+	head, err = New(&fakeBackend{head: 5}).Head(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(0), head)
+}
+
+func TestRangeTooLarge(t *testing.T) {
+	assert.True(t, rangeTooLarge(errors.New("query returned more than 10000 results")))
+	assert.True(t, rangeTooLarge(errors.New("exceed maximum block range: 5000")))
+	assert.True(t, rangeTooLarge(errors.New("Log response size exceeded")))
+	assert.False(t, rangeTooLarge(errors.New("index out of range")))
+	assert.False(t, rangeTooLarge(errors.New("connection refused")))
+}
@@ -0,0 +1,73 @@
+package sdk
+
+import (
+	"context"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/palettechain/deploy-tool/pkg/errs"
+	"github.com/palettechain/deploy-tool/pkg/eth"
+	"github.com/palettechain/deploy-tool/pkg/gas"
+)
+
+const (
+	paletteChain = "palette"
+
+	// DefaultGasMargin is the percent added to the estimated gas limit.
+	DefaultGasMargin uint64 = 20
+)
+
+var (
+	confGasPrice *big.Int
+	gasMargin    = DefaultGasMargin
+)
+
+// SetGasPolicy set the gas price used by all palette transactions, and the gas price is fetched from
+// node if it is nil. margin is the percent added to the estimated gas, default margin used if it is 0.
+func SetGasPolicy(gasPrice *big.Int, margin uint64) {
+	confGasPrice = gasPrice
+	if margin > 0 {
+		gasMargin = margin
+	} else {
+		gasMargin = DefaultGasMargin
+	}
+}
+
+// backend simulate the call before estimating gas, estimate gas with margin and take gas price from config or node for both raw transactions
+// and contract bindings, and record the expected cost before the transaction sent.
+type backend struct {
+	*ethclient.Client
+}
+
+func newBackend(cli *ethclient.Client) *backend {
+	return &backend{Client: cli}
+}
+
+// EstimateGas simulate the call at pending block first, raw transactions and contract bindings always
+// estimate gas before signing, so the reverted calls are aborted with the decoded reason.
+func (b *backend) EstimateGas(ctx context.Context, msg ethereum.CallMsg) (uint64, error) {
+	if err := eth.Simulate(ctx, b.Client, msg); err != nil {
+		return 0, err
+	}
+	gasLimit, err := b.Client.EstimateGas(ctx, msg)
+	if err != nil {
+		return 0, err
+	}
+	return gasLimit + gasLimit*gasMargin/100, nil
+}
+
+func (b *backend) SuggestGasPrice(ctx context.Context) (*big.Int, error) {
+	if confGasPrice != nil {
+		return new(big.Int).Set(confGasPrice), nil
+	}
+	return b.Client.SuggestGasPrice(ctx)
+}
+
+func (b *backend) SendTransaction(ctx context.Context, tx *types.Transaction) error {
+	if err := gas.Record(paletteChain, tx.To(), tx.Gas(), tx.GasPrice()); err != nil {
+		return err
+	}
+	return errs.RPC("eth_sendRawTransaction", b.Client.SendTransaction(ctx, tx))
+}
@@ -20,8 +20,12 @@ import (
 	"github.com/ethereum/go-ethereum/contracts/native/plt"
 	"github.com/ethereum/go-ethereum/contracts/native/utils"
 	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/ethclient"
 	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/palettechain/deploy-tool/pkg/abis"
+	"github.com/palettechain/deploy-tool/pkg/errs"
+	"github.com/palettechain/deploy-tool/pkg/gas"
 	"github.com/palettechain/deploy-tool/pkg/log"
 )
 
@@ -31,15 +35,9 @@ var (
 	PLTAddress              = common.HexToAddress(native.PLTContractAddress)
 	NFTMangerAddress        = common.HexToAddress(native.NFTContractCreateAddress)
 	GovernanceAddress        = common.HexToAddress(native.GovernanceContractAddress)
-	gasLimit         uint64 = 2100000
-	deployGasLimit   uint64 = 10000000000
 	blockPeriod             = 6 * time.Second
 )
 
-const (
-	gasPrice = 0
-)
-
 func Init() {
 	PLTABI = plt.GetABI()
 	GovernanceABI = governance.GetABI()
@@ -47,23 +45,21 @@ func Init() {
 	NFTManagerABI = nftmanager.GetABI()
 }
 
-func (c *Client) GetBlockNumber() uint64 {
-	var raw string
-	if err := c.Call(
+func (c *Client) GetBlockNumber() (uint64, error) {
+	var raw hexutil.Uint64
+	if err := c.CallContext(
+		c.Context(),
 		&raw,
 		"eth_blockNumber",
 	); err != nil {
-		panic(fmt.Errorf("failed to get nonce: [%v]", err))
+		return 0, errs.RPC("eth_blockNumber", err)
 	}
-
-	without0xStr := strings.Replace(raw, "0x", "", -1)
-	bigNonce, _ := new(big.Int).SetString(without0xStr, 16)
-	return bigNonce.Uint64()
+	return uint64(raw), nil
 }
 
 func (c *Client) DumpBlock(height uint64) error {
 	cli := ethclient.NewClient(c.Client)
-	block, err := cli.BlockByNumber(context.Background(), new(big.Int).SetUint64(height))
+	block, err := cli.BlockByNumber(c.Context(), new(big.Int).SetUint64(height))
 	if err != nil {
 		return err
 	}
@@ -78,32 +74,32 @@ func (c *Client) DumpBlock(height uint64) error {
 func (c *Client) GetBlockByNumber(height uint64) (*types.Block, error) {
 	cli := ethclient.NewClient(c.Client)
 	data := new(big.Int).SetUint64(height)
-	ctx := context.Background()
+	ctx := c.Context()
 	return cli.BlockByNumber(ctx, data)
 }
 
-func (c *Client) GetNonce(address string) uint64 {
-	var raw string
-
-	if err := c.Call(
+func (c *Client) GetNonce(address string) (uint64, error) {
+	var raw hexutil.Uint64
+	if err := c.CallContext(
+		c.Context(),
 		&raw,
 		"eth_getTransactionCount",
 		address,
 		"latest",
 	); err != nil {
-		panic(fmt.Errorf("failed to get nonce: [%v]", err))
+		return 0, errs.RPC("eth_getTransactionCount", err)
 	}
-
-	without0xStr := strings.Replace(raw, "0x", "", -1)
-	bigNonce, _ := new(big.Int).SetString(without0xStr, 16)
-	return bigNonce.Uint64()
+	return uint64(raw), nil
 }
 
 func (c *Client) GetCurrentBlockHeader() (uint64, *types.Header, error) {
-	curr := c.GetBlockNumber()
+	curr, err := c.GetBlockNumber()
+	if err != nil {
+		return 0, nil, err
+	}
 	block, err := c.GetBlockByNumber(curr)
 	if err != nil {
-		return 0, nil, fmt.Errorf("getBlockByNumber err: %s", err)
+		return 0, nil, fmt.Errorf("getBlockByNumber err: %w", err)
 	}
 	if block == nil {
 		return 0, nil, fmt.Errorf("getBlockByNumber, block is nil")
@@ -114,28 +110,72 @@ func (c *Client) GetCurrentBlockHeader() (uint64, *types.Header, error) {
 }
 
 func (c *Client) SendTransaction(contractAddr common.Address, payload []byte) (common.Hash, error) {
-	addr := c.Address()
+	nonce, err := c.reserveNonce()
+	if err != nil {
+		return utils.EmptyHash, err
+	}
+	hash, err := c.SendTransactionWithNonce(nonce, contractAddr, payload)
+	if err != nil {
+		c.releaseNonce(nonce, err)
+	}
+	return hash, err
+}
 
-	nonce := c.GetNonce(addr.Hex())
-	if c.currentNonce < nonce {
-		c.currentNonce = nonce
+// SendTransactionWithNonce send tx with the nonce reserved by `ReserveNonces`, the nonce is settled
+// if the tx sent, and the caller should release it otherwise.
+func (c *Client) SendTransactionWithNonce(nonce uint64, contractAddr common.Address, payload []byte) (common.Hash, error) {
+	hash, err := c.sendTransactionWithNonce(nonce, contractAddr, big.NewInt(0), payload)
+	if err == nil {
+		c.sentNonce()
 	}
-	log.Debugf("%s current nonce %d, valid nonce %d", addr.Hex(), c.currentNonce, nonce)
+	return hash, err
+}
+
+// SendValueWithNonce send native currency with the nonce reserved by `ReserveNonces`, the nonce is
+// settled the same as `SendTransactionWithNonce`.
+func (c *Client) SendValueWithNonce(nonce uint64, to common.Address, value *big.Int) (common.Hash, error) {
+	hash, err := c.sendTransactionWithNonce(nonce, to, value, nil)
+	if err == nil {
+		c.sentNonce()
+	}
+	return hash, err
+}
+
+func (c *Client) sendTransactionWithNonce(nonce uint64, contractAddr common.Address, value *big.Int, payload []byte) (common.Hash, error) {
+	ctx := c.Context()
+	gasPrice, err := c.backend.SuggestGasPrice(ctx)
+	if err != nil {
+		return utils.EmptyHash, fmt.Errorf("failed to get gas price: [%w]", err)
+	}
+	gasLimit, err := c.backend.EstimateGas(ctx, ethereum.CallMsg{
+		From:     c.Address(),
+		To:       &contractAddr,
+		GasPrice: gasPrice,
+		Value:    value,
+		Data:     payload,
+	})
+	if err != nil {
+		return utils.EmptyHash, fmt.Errorf("failed to estimate gas: [%w]", err)
+	}
+
+	log.Debugf("%s send tx with nonce %d, gas limit %d, gas price %s", c.Address().Hex(), nonce, gasLimit, gasPrice)
 	tx := types.NewTransaction(
-		c.currentNonce,
+		nonce,
 		contractAddr,
-		big.NewInt(0),
+		value,
 		gasLimit,
-		big.NewInt(gasPrice),
+		gasPrice,
 		payload,
 	)
 	hash := tx.Hash()
 
+	if err := gas.Record(paletteChain, tx.To(), tx.Gas(), tx.GasPrice()); err != nil {
+		return hash, err
+	}
 	signedTx, err := c.SignTransaction(tx)
 	if err != nil {
 		return hash, err
 	}
-	c.currentNonce += 1
 	return c.SendRawTransaction(hash, signedTx)
 }
 
@@ -151,9 +191,17 @@ func (c *Client) SendTransactionAndDumpEvent(contract common.Address, payload []
 func (c *Client) RepeatSendTransactionAndDumpEvent(contract common.Address, payload []byte, repeat int) error {
 	hashList := make([]common.Hash, repeat)
 
+	first, err := c.ReserveNonces(repeat)
+	if err != nil {
+		return err
+	}
 	for i := 0; i < repeat; i++ {
-		hash, err := c.SendTransaction(contract, payload)
+		hash, err := c.SendTransactionWithNonce(first+uint64(i), contract, payload)
 		if err != nil {
+			// release the unsent nonces from the last one, so that they are rolled back
+			for j := repeat - 1; j >= i; j-- {
+				c.releaseNonce(first+uint64(j), err)
+			}
 			return err
 		}
 		hashList[i] = hash
@@ -171,45 +219,48 @@ func (c *Client) RepeatSendTransactionAndDumpEvent(contract common.Address, payl
 }
 
 func (c *Client) SignTransaction(tx *types.Transaction) (string, error) {
-
-	signer := types.HomesteadSigner{}
+	signer, err := c.Signer()
+	if err != nil {
+		return "", err
+	}
 	signedTx, err := types.SignTx(
 		tx,
 		signer,
 		c.Key,
 	)
 	if err != nil {
-		return "", fmt.Errorf("failed to sign tx: [%v]", err)
+		return "", fmt.Errorf("failed to sign tx: [%w]", err)
 	}
 
 	bz, err := rlp.EncodeToBytes(signedTx)
 	if err != nil {
-		return "", fmt.Errorf("failed to rlp encode bytes: [%v]", err)
+		return "", fmt.Errorf("failed to rlp encode bytes: [%w]", err)
 	}
 	return "0x" + hex.EncodeToString(bz), nil
 }
 
 func (c *Client) SendRawTransaction(hash common.Hash, signedTx string) (common.Hash, error) {
 	var result common.Hash
-	if err := c.Client.Call(&result, "eth_sendRawTransaction", signedTx); err != nil {
-		return hash, fmt.Errorf("failed to send raw transaction: [%v]", err)
+	if err := c.Client.CallContext(c.Context(), &result, "eth_sendRawTransaction", signedTx); err != nil {
+		return hash, fmt.Errorf("failed to send raw transaction: [%w]", errs.RPC("eth_sendRawTransaction", err))
 	}
 
 	return result, nil
 }
 
 func (c *Client) DeployContract(abiStr, binStr string, params ...interface{}) (common.Address, *bind.BoundContract, error) {
-	auth := c.makeDeployAuth()
-
 	parsedABI, err := abi.JSON(strings.NewReader(abiStr))
 	if err != nil {
 		log.Errorf("failed to read abi json, err: %v", err)
 		return utils.EmptyAddress, nil, err
 	}
 	parsedBin := common.FromHex(binStr)
-	backend := ethclient.NewClient(c.Client)
 
-	address, tx, contract, err := bind.DeployContract(auth, parsedABI, parsedBin, backend, params...)
+	var contract *bind.BoundContract
+	address, tx, err := c.deploy(c.makeDeployAuth(), func(auth *bind.TransactOpts) (addr common.Address, tx *types.Transaction, err error) {
+		addr, tx, contract, err = bind.DeployContract(auth, parsedABI, parsedBin, c.backend, params...)
+		return
+	})
 	if err != nil {
 		return utils.EmptyAddress, nil, err
 	}
@@ -222,17 +273,17 @@ func (c *Client) DeployContract(abiStr, binStr string, params ...interface{}) (c
 	return address, contract, nil
 }
 
+// makeDeployAuth returns the transact opts without nonce and signer, which are set in `deploy` or `transact`.
+// the gas limit is estimated with margin and gas price taken from config or node by the backend.
 func (c *Client) makeDeployAuth() *bind.TransactOpts {
-	auth := bind.NewKeyedTransactor(c.Key)
-	auth.GasLimit = 1e7
-	auth.Nonce = new(big.Int).SetUint64(c.GetNonce(c.Address().Hex()))
-	return auth
+	return &bind.TransactOpts{
+		From:    crypto.PubkeyToAddress(c.Key.PublicKey),
+		Context: c.Context(),
+	}
 }
 
 func (c *Client) makeAuth() *bind.TransactOpts {
-	auth := bind.NewKeyedTransactor(c.Key)
-	auth.GasLimit = 2100000
-	auth.Nonce = new(big.Int).SetUint64(c.GetNonce(c.Address().Hex()))
+	auth := c.makeDeployAuth()
 	auth.Value = big.NewInt(0)
 	return auth
 }
@@ -240,12 +291,36 @@ func (c *Client) makeAuth() *bind.TransactOpts {
 func (c *Client) getCallOpts() *bind.CallOpts {
 	auth := new(bind.CallOpts)
 	auth.From = c.Address()
-	auth.Context = context.Background()
+	auth.Context = c.Context()
 	return auth
 }
 
+// HasCode returns true if the contract code exists at the latest block.
+func (c *Client) HasCode(addr common.Address) (bool, error) {
+	code, err := c.backend.CodeAt(c.Context(), addr, nil)
+	if err != nil {
+		return false, errs.RPC("eth_getCode", err)
+	}
+	return len(code) > 0, nil
+}
+
+// ResumeDeploy wait the deployment tx sent before, and returns the contract address once the tx confirmed.
+// the tx is not waited if the contract code exists, e.g: the tx has been replaced by a speed up.
+func (c *Client) ResumeDeploy(addr common.Address, hash common.Hash) (common.Address, error) {
+	deployed, err := c.HasCode(addr)
+	if err != nil {
+		return utils.EmptyAddress, err
+	}
+	if !deployed {
+		if err := c.WaitTransaction(hash); err != nil {
+			return utils.EmptyAddress, err
+		}
+	}
+	return addr, nil
+}
+
 func (c *Client) DumpContractCode(addr common.Address) error {
-	bz, err := c.backend.PendingCodeAt(context.Background(), addr)
+	bz, err := c.backend.PendingCodeAt(c.Context(), addr)
 	if err != nil {
 		return err
 	}
@@ -265,19 +340,15 @@ func (c *Client) DumpEventLog(hash common.Hash) error {
 
 	log.Infof("txhash %s, block height %d", hash.Hex(), raw.BlockNumber.Uint64())
 	for _, event := range raw.Logs {
-		log.Infof("eventlog address %s", event.Address.Hex())
-		log.Infof("eventlog data %s", new(big.Int).SetBytes(event.Data).String())
-		for i, topic := range event.Topics {
-			log.Infof("eventlog topic[%d] %s", i, topic.String())
-		}
+		log.Infof("eventlog %s", abis.FormatLog(event))
 	}
 	return nil
 }
 
 func (c *Client) GetReceipt(hash common.Hash) (*types.Receipt, error) {
 	raw := &types.Receipt{}
-	if err := c.Call(raw, "eth_getTransactionReceipt", hash.Hex()); err != nil {
-		return nil, err
+	if err := c.CallContext(c.Context(), raw, "eth_getTransactionReceipt", hash.Hex()); err != nil {
+		return nil, errs.RPC("eth_getTransactionReceipt", err)
 	}
 	return raw, nil
 }
@@ -313,7 +384,7 @@ type JsonError struct {
 
 func (c *Client) GetProof(contractAddr common.Address, key string, blockNum string) (*PaletteProof, error) {
 	res := new(ProofRsp)
-	if err := c.Call(res, "eth_getProof", contractAddr, []string{key}, blockNum); err != nil {
+	if err := c.CallContext(c.Context(), res, "eth_getProof", contractAddr, []string{key}, blockNum); err != nil {
 		return nil, err
 	}
 
@@ -331,9 +402,9 @@ func (c *Client) CallContract(caller, contractAddr common.Address, payload []byt
 		To:   &contractAddr,
 		Data: payload,
 	}
-	err := c.CallContext(context.Background(), &res, "eth_call", toCallArg(arg), blockNum)
+	err := c.CallContext(c.Context(), &res, "eth_call", toCallArg(arg), blockNum)
 	if err != nil {
-		return nil, err
+		return nil, errs.RPC("eth_call", err)
 	}
 	return res, nil
 }
@@ -0,0 +1,72 @@
+package sdk
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/contracts/native/plt"
+	"github.com/palettechain/deploy-tool/pkg/batch"
+	nftlp "github.com/polynetwork/nft-contracts/go_abi/nft_lock_proxy_abi"
+)
+
+// Batch packs view calls of client into json-rpc batch requests, e.g: check the bindings of many assets
+// or chain ids in one round trip. results are decoded into the out params after `Send`, and errors of
+// single calls are set in the returned `batch.Call`.
+type Batch struct {
+	*batch.Batch
+	c *Client
+}
+
+func (c *Client) NewBatch() *Batch {
+	return &Batch{Batch: batch.New(), c: c}
+}
+
+// Send the calls at block number, e.g: "latest", the returned error is the error of batch request.
+func (b *Batch) Send(blockNum string) error {
+	return b.Batch.Send(b.c.Context(), b.c.Client, blockNum)
+}
+
+// CallContract append the raw `eth_call`, and the returned data is set in the call result.
+func (b *Batch) CallContract(contractAddr common.Address, payload []byte) *batch.Call {
+	return b.Add(b.c.Address(), contractAddr, payload, nil)
+}
+
+func (b *Batch) GetBindPLTProxy(targetChainID uint64, out *common.Address) *batch.Call {
+	return b.pltAddress(plt.MethodGetBindedProxy, out, targetChainID)
+}
+
+func (b *Batch) GetBindPLTAsset(targetChainID uint64, out *common.Address) *batch.Call {
+	return b.pltAddress(plt.MethodGetBindedAsset, out, targetChainID)
+}
+
+func (b *Batch) GetBoundNFTProxy(localLockProxy common.Address, targetSideChainID uint64, out *common.Address) *batch.Call {
+	return b.AddAddress(b.c.Address(), localLockProxy, nftlp.PolyNFTLockProxyABI, "proxyHashMap", out, targetSideChainID)
+}
+
+func (b *Batch) GetBoundNFTAsset(lockProxy, fromAsset common.Address, toChainID uint64, out *common.Address) *batch.Call {
+	return b.AddAddress(b.c.Address(), lockProxy, nftlp.PolyNFTLockProxyABI, "assetHashMap", out, fromAsset, toChainID)
+}
+
+func (b *Batch) GetNFTCCMP(proxyAddr common.Address, out *common.Address) *batch.Call {
+	return b.AddAddress(b.c.Address(), proxyAddr, nftlp.PolyNFTLockProxyABI, "managerProxyContract", out)
+}
+
+func (b *Batch) NFTProxyOwnership(proxyAddr common.Address, out *common.Address) *batch.Call {
+	return b.AddAddress(b.c.Address(), proxyAddr, nftlp.PolyNFTLockProxyABI, "owner", out)
+}
+
+// pltAddress append the call of native plt method which returns address bytes.
+func (b *Batch) pltAddress(method string, out *common.Address, args ...interface{}) *batch.Call {
+	payload, err := b.c.packPLT(method, args...)
+	if err != nil {
+		payload = nil
+	}
+	call := b.Add(b.c.Address(), PLTAddress, payload, func(enc []byte) error {
+		var bz []byte
+		if err := b.c.unpackPLT(method, &bz, enc); err != nil {
+			return err
+		}
+		*out = common.BytesToAddress(bz)
+		return nil
+	})
+	call.Err = err
+	return call
+}
@@ -1,32 +1,64 @@
 package sdk
 
 import (
+	"context"
 	"crypto/ecdsa"
 	"fmt"
+	"math/big"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/contracts/native/utils"
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/ethclient"
 	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/palettechain/deploy-tool/pkg/errs"
+	"github.com/palettechain/deploy-tool/pkg/eth"
+	"github.com/palettechain/deploy-tool/pkg/rpcpool"
 )
 
 type Client struct {
 	*rpc.Client
-	backend      *ethclient.Client
-	url          string
-	caller       common.Address
-	Key          *ecdsa.PrivateKey
-	currentNonce uint64
+	backend *backend
+	url     string
+	caller  common.Address
+	Key     *ecdsa.PrivateKey
+	chainID *big.Int
+	ctx     context.Context
+	// called after the deployment tx sent, see `WithDeploySent`
+	deploySent eth.DeploySentFn
 }
 
-func NewSender(url string, key *ecdsa.PrivateKey) *Client {
-	cli := dialNode(url)
+func NewSender(url string, key *ecdsa.PrivateKey) (*Client, error) {
+	cli, err := dialNode(url)
+	if err != nil {
+		return nil, err
+	}
+	return newClient(url, cli, key), nil
+}
+
+// NewSenderPool dial the palette endpoints in pool, requests fail over to another healthy endpoint
+// if the current one can not be reached.
+func NewSenderPool(pool *rpcpool.Pool, key *ecdsa.PrivateKey) (*Client, error) {
+	urls := pool.URLs()
+	if len(urls) == 0 {
+		return nil, fmt.Errorf("no palette endpoint")
+	}
+	if pool.Single() {
+		return NewSender(urls[0], key)
+	}
+	cli, err := rpc.DialHTTPWithClient(urls[0], pool.HTTPClient())
+	if err != nil {
+		return nil, errs.RPC(fmt.Sprintf("dial %s", rpcpool.Endpoints(urls)), err)
+	}
+	return newClient(rpcpool.Endpoints(urls).String(), cli, key), nil
+}
+
+func newClient(url string, cli *rpc.Client, key *ecdsa.PrivateKey) *Client {
 	return &Client{
 		url:     url,
 		Client:  cli,
 		Key:     key,
-		backend: ethclient.NewClient(cli),
+		backend: newBackend(ethclient.NewClient(cli)),
 	}
 }
 
@@ -46,15 +78,38 @@ func (c *Client) Reset(key *ecdsa.PrivateKey) *Client {
 	return c
 }
 
+// WithContext returns a shallow copy of client bound to the context, all rpc requests and
+// transaction waits of the copy are canceled with the context.
+func (c *Client) WithContext(ctx context.Context) *Client {
+	cp := *c
+	cp.ctx = ctx
+	return &cp
+}
+
+// WithDeploySent returns a shallow copy of client which calls fn after every deployment tx sent, e.g:
+// record the tx so that the retried step resumes it instead of deploying again.
+func (c *Client) WithDeploySent(fn eth.DeploySentFn) *Client {
+	cp := *c
+	cp.deploySent = fn
+	return &cp
+}
+
+// Context returns the context bound to client, and the background context if it is not bound.
+func (c *Client) Context() context.Context {
+	if c.ctx != nil {
+		return c.ctx
+	}
+	return context.Background()
+}
+
 func PubKey2Address(pub ecdsa.PublicKey) common.Address {
 	return crypto.PubkeyToAddress(pub)
 }
 
-func dialNode(url string) *rpc.Client {
+func dialNode(url string) (*rpc.Client, error) {
 	client, err := rpc.Dial(url)
 	if err != nil {
-		panic(fmt.Sprintf("failed to dial geth rpc [%v]", err))
+		return nil, errs.RPC(fmt.Sprintf("dial %s", url), err)
 	}
-
-	return client
+	return client, nil
 }
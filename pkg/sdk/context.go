@@ -0,0 +1,317 @@
+package sdk
+
+import (
+	"context"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// context variants of client methods, the rpc requests and transaction waits of them are canceled
+// with the context, e.g: the per-step context supplied by frame.
+
+func (c *Client) GetBlockNumberContext(ctx context.Context) (uint64, error) {
+	return c.WithContext(ctx).GetBlockNumber()
+}
+
+func (c *Client) DumpBlockContext(ctx context.Context, height uint64) error {
+	return c.WithContext(ctx).DumpBlock(height)
+}
+
+func (c *Client) GetBlockByNumberContext(ctx context.Context, height uint64) (*types.Block, error) {
+	return c.WithContext(ctx).GetBlockByNumber(height)
+}
+
+func (c *Client) GetNonceContext(ctx context.Context, address string) (uint64, error) {
+	return c.WithContext(ctx).GetNonce(address)
+}
+
+func (c *Client) GetCurrentBlockHeaderContext(ctx context.Context) (uint64, *types.Header, error) {
+	return c.WithContext(ctx).GetCurrentBlockHeader()
+}
+
+func (c *Client) SendTransactionContext(ctx context.Context, contractAddr common.Address, payload []byte) (common.Hash, error) {
+	return c.WithContext(ctx).SendTransaction(contractAddr, payload)
+}
+
+func (c *Client) SendTransactionWithNonceContext(ctx context.Context, nonce uint64, contractAddr common.Address, payload []byte) (common.Hash, error) {
+	return c.WithContext(ctx).SendTransactionWithNonce(nonce, contractAddr, payload)
+}
+
+func (c *Client) SendValueWithNonceContext(ctx context.Context, nonce uint64, to common.Address, value *big.Int) (common.Hash, error) {
+	return c.WithContext(ctx).SendValueWithNonce(nonce, to, value)
+}
+
+func (c *Client) SendTransactionAndDumpEventContext(ctx context.Context, contract common.Address, payload []byte) error {
+	return c.WithContext(ctx).SendTransactionAndDumpEvent(contract, payload)
+}
+
+func (c *Client) RepeatSendTransactionAndDumpEventContext(ctx context.Context, contract common.Address, payload []byte, repeat int) error {
+	return c.WithContext(ctx).RepeatSendTransactionAndDumpEvent(contract, payload, repeat)
+}
+
+func (c *Client) SignTransactionContext(ctx context.Context, tx *types.Transaction) (string, error) {
+	return c.WithContext(ctx).SignTransaction(tx)
+}
+
+func (c *Client) SendRawTransactionContext(ctx context.Context, hash common.Hash, signedTx string) (common.Hash, error) {
+	return c.WithContext(ctx).SendRawTransaction(hash, signedTx)
+}
+
+func (c *Client) DeployContractContext(ctx context.Context, abiStr, binStr string, params ...interface{}) (common.Address, *bind.BoundContract, error) {
+	return c.WithContext(ctx).DeployContract(abiStr, binStr, params...)
+}
+
+func (c *Client) HasCodeContext(ctx context.Context, addr common.Address) (bool, error) {
+	return c.WithContext(ctx).HasCode(addr)
+}
+
+func (c *Client) ResumeDeployContext(ctx context.Context, addr common.Address, hash common.Hash) (common.Address, error) {
+	return c.WithContext(ctx).ResumeDeploy(addr, hash)
+}
+
+func (c *Client) DumpContractCodeContext(ctx context.Context, addr common.Address) error {
+	return c.WithContext(ctx).DumpContractCode(addr)
+}
+
+func (c *Client) DumpEventLogContext(ctx context.Context, hash common.Hash) error {
+	return c.WithContext(ctx).DumpEventLog(hash)
+}
+
+func (c *Client) GetReceiptContext(ctx context.Context, hash common.Hash) (*types.Receipt, error) {
+	return c.WithContext(ctx).GetReceipt(hash)
+}
+
+func (c *Client) GetProofContext(ctx context.Context, contractAddr common.Address, key string, blockNum string) (*PaletteProof, error) {
+	return c.WithContext(ctx).GetProof(contractAddr, key, blockNum)
+}
+
+func (c *Client) CallContractContext(ctx context.Context, caller, contractAddr common.Address, payload []byte, blockNum string) ([]byte, error) {
+	return c.WithContext(ctx).CallContract(caller, contractAddr, payload, blockNum)
+}
+
+func (c *Client) DeployECCDContext(ctx context.Context) (common.Address, error) {
+	return c.WithContext(ctx).DeployECCD()
+}
+
+func (c *Client) DeployECCMContext(ctx context.Context, eccd common.Address, sideChainID uint64, whiteList []common.Address, bookeeperBytes []byte) (common.Address, error) {
+	return c.WithContext(ctx).DeployECCM(eccd, sideChainID, whiteList, bookeeperBytes)
+}
+
+func (c *Client) RecoverECCMContext(ctx context.Context, eccmAddr common.Address, bookeeperBytes []byte) (common.Hash, error) {
+	return c.WithContext(ctx).RecoverECCM(eccmAddr, bookeeperBytes)
+}
+
+func (c *Client) DeployCCMPContext(ctx context.Context, eccm common.Address) (common.Address, error) {
+	return c.WithContext(ctx).DeployCCMP(eccm)
+}
+
+func (c *Client) PauseCCMPContext(ctx context.Context, ccmpAddr common.Address) (common.Hash, error) {
+	return c.WithContext(ctx).PauseCCMP(ccmpAddr)
+}
+
+func (c *Client) UnPauseCCMPContext(ctx context.Context, ccmpAddr common.Address) (common.Hash, error) {
+	return c.WithContext(ctx).UnPauseCCMP(ccmpAddr)
+}
+
+func (c *Client) UpgradeECCMContext(ctx context.Context, newEccmAddr, ccmpAddr common.Address) (common.Hash, error) {
+	return c.WithContext(ctx).UpgradeECCM(newEccmAddr, ccmpAddr)
+}
+
+func (c *Client) ECCDTransferOwnerShipContext(ctx context.Context, eccdAddr, eccmAddr common.Address) (common.Hash, error) {
+	return c.WithContext(ctx).ECCDTransferOwnerShip(eccdAddr, eccmAddr)
+}
+
+func (c *Client) ECCDOwnershipContext(ctx context.Context, eccdAddr common.Address) (common.Address, error) {
+	return c.WithContext(ctx).ECCDOwnership(eccdAddr)
+}
+
+func (c *Client) ECCDCurEpochPubKeysContext(ctx context.Context, eccdAddr common.Address) ([]byte, error) {
+	return c.WithContext(ctx).ECCDCurEpochPubKeys(eccdAddr)
+}
+
+func (c *Client) ECCMTransferOwnerShipContext(ctx context.Context, eccmAddr, ccmpAddr common.Address) (common.Hash, error) {
+	return c.WithContext(ctx).ECCMTransferOwnerShip(eccmAddr, ccmpAddr)
+}
+
+func (c *Client) ECCMOwnershipContext(ctx context.Context, eccmAddr common.Address) (common.Address, error) {
+	return c.WithContext(ctx).ECCMOwnership(eccmAddr)
+}
+
+func (c *Client) CCMPTransferOwnerShipContext(ctx context.Context, ccmpAddr, newOwner common.Address) (common.Hash, error) {
+	return c.WithContext(ctx).CCMPTransferOwnerShip(ccmpAddr, newOwner)
+}
+
+func (c *Client) CCMPOwnershipContext(ctx context.Context, ccmpAddr common.Address) (common.Address, error) {
+	return c.WithContext(ctx).CCMPOwnership(ccmpAddr)
+}
+
+func (c *Client) SetPLTCCMPContext(ctx context.Context, ccmp common.Address) (common.Hash, error) {
+	return c.WithContext(ctx).SetPLTCCMP(ccmp)
+}
+
+func (c *Client) GetPLTCCMPContext(ctx context.Context, blockNum string) (common.Address, error) {
+	return c.WithContext(ctx).GetPLTCCMP(blockNum)
+}
+
+func (c *Client) BindPLTProxyContext(ctx context.Context, targetChainID uint64, targetProxy common.Address) (common.Hash, error) {
+	return c.WithContext(ctx).BindPLTProxy(targetChainID, targetProxy)
+}
+
+func (c *Client) GetBindPLTProxyContext(ctx context.Context, targetChainID uint64, blockNum string) (common.Address, error) {
+	return c.WithContext(ctx).GetBindPLTProxy(targetChainID, blockNum)
+}
+
+func (c *Client) BindPLTAssetContext(ctx context.Context, targetChainID uint64, targetAsset common.Address) (common.Hash, error) {
+	return c.WithContext(ctx).BindPLTAsset(targetChainID, targetAsset)
+}
+
+func (c *Client) GetBindPLTAssetContext(ctx context.Context, targetChainID uint64, blockNum string) (common.Address, error) {
+	return c.WithContext(ctx).GetBindPLTAsset(targetChainID, blockNum)
+}
+
+func (c *Client) PLTMintContext(ctx context.Context, to common.Address, val *big.Int) (common.Hash, error) {
+	return c.WithContext(ctx).PLTMint(to, val)
+}
+
+func (c *Client) PLTBurnContext(ctx context.Context, val *big.Int) (common.Hash, error) {
+	return c.WithContext(ctx).PLTBurn(val)
+}
+
+func (c *Client) LockPLTContext(ctx context.Context, targetChainID uint64, dstAddr common.Address, amount *big.Int) (common.Hash, error) {
+	return c.WithContext(ctx).LockPLT(targetChainID, dstAddr, amount)
+}
+
+func (c *Client) SetNFTCCMPContext(ctx context.Context, proxyAddr, ccmp common.Address) (common.Hash, error) {
+	return c.WithContext(ctx).SetNFTCCMP(proxyAddr, ccmp)
+}
+
+func (c *Client) GetNFTCCMPContext(ctx context.Context, proxyAddr common.Address) (common.Address, error) {
+	return c.WithContext(ctx).GetNFTCCMP(proxyAddr)
+}
+
+func (c *Client) DeployNFTProxyContext(ctx context.Context) (common.Address, error) {
+	return c.WithContext(ctx).DeployNFTProxy()
+}
+
+func (c *Client) BindNFTProxyContext(ctx context.Context, localLockProxy common.Address, targetLockProxy common.Address, targetSideChainID uint64) (common.Hash, error) {
+	return c.WithContext(ctx).BindNFTProxy(localLockProxy, targetLockProxy, targetSideChainID)
+}
+
+func (c *Client) GetBoundNFTProxyContext(ctx context.Context, localLockProxy common.Address, targetSideChainID uint64) (common.Address, error) {
+	return c.WithContext(ctx).GetBoundNFTProxy(localLockProxy, targetSideChainID)
+}
+
+func (c *Client) TransferNFTProxyOwnershipContext(ctx context.Context, proxyAddr, newOwner common.Address) (common.Hash, error) {
+	return c.WithContext(ctx).TransferNFTProxyOwnership(proxyAddr, newOwner)
+}
+
+func (c *Client) NFTProxyOwnershipContext(ctx context.Context, proxyAddr common.Address) (common.Address, error) {
+	return c.WithContext(ctx).NFTProxyOwnership(proxyAddr)
+}
+
+func (c *Client) BindNFTAssetContext(ctx context.Context, localLockProxy, fromAsset, toAsset common.Address, targetSideChainID uint64) (common.Hash, error) {
+	return c.WithContext(ctx).BindNFTAsset(localLockProxy, fromAsset, toAsset, targetSideChainID)
+}
+
+func (c *Client) GetBoundNFTAssetContext(ctx context.Context, lockProxy, fromAsset common.Address, toChainID uint64) (common.Address, error) {
+	return c.WithContext(ctx).GetBoundNFTAsset(lockProxy, fromAsset, toChainID)
+}
+
+func (c *Client) InitGenesisBlockContext(ctx context.Context, eccmAddr common.Address, rawHdr, publickeys []byte) (common.Hash, error) {
+	return c.WithContext(ctx).InitGenesisBlock(eccmAddr, rawHdr, publickeys)
+}
+
+func (c *Client) FundContext(ctx context.Context, to []common.Address, amount *big.Int, method string) ([]common.Hash, error) {
+	return c.WithContext(ctx).Fund(to, amount, method)
+}
+
+func (c *Client) GetDelegateFactorContext(ctx context.Context, validator common.Address, blockNum string) (*big.Int, error) {
+	return c.WithContext(ctx).GetDelegateFactor(validator, blockNum)
+}
+
+func (c *Client) SendGovernanceTxContext(ctx context.Context, payload []byte) (common.Hash, error) {
+	return c.WithContext(ctx).SendGovernanceTx(payload)
+}
+
+func (c *Client) CallGovernanceContext(ctx context.Context, payload []byte, blockNum string) ([]byte, error) {
+	return c.WithContext(ctx).CallGovernance(payload, blockNum)
+}
+
+func (c *Client) NFTDeployContext(ctx context.Context, name string, symbol string) (common.Hash, common.Address, error) {
+	return c.WithContext(ctx).NFTDeploy(name, symbol)
+}
+
+func (c *Client) NFTNameContext(ctx context.Context, asset common.Address, blockNum string) (string, error) {
+	return c.WithContext(ctx).NFTName(asset, blockNum)
+}
+
+func (c *Client) NFTSymbolContext(ctx context.Context, asset common.Address, blockNum string) (string, error) {
+	return c.WithContext(ctx).NFTSymbol(asset, blockNum)
+}
+
+func (c *Client) NFTAssetOwnerContext(ctx context.Context, asset common.Address, blockNum string) (common.Address, error) {
+	return c.WithContext(ctx).NFTAssetOwner(asset, blockNum)
+}
+
+func (c *Client) ReserveNoncesContext(ctx context.Context, n int) (uint64, error) {
+	return c.WithContext(ctx).ReserveNonces(n)
+}
+
+func (c *Client) BalanceOfContext(ctx context.Context, owner common.Address, blockNum string) (*big.Int, error) {
+	return c.WithContext(ctx).BalanceOf(owner, blockNum)
+}
+
+func (c *Client) WaitTransactionsContext(ctx context.Context, hashes []common.Hash) error {
+	return c.WithContext(ctx).WaitTransactions(hashes)
+}
+
+func (c *Client) SpeedUpContext(ctx context.Context, hash common.Hash) (common.Hash, error) {
+	return c.WithContext(ctx).SpeedUp(hash)
+}
+
+func (c *Client) CancelContext(ctx context.Context, hash common.Hash) (common.Hash, error) {
+	return c.WithContext(ctx).Cancel(hash)
+}
+
+func (c *Client) ChainIDContext(ctx context.Context) (*big.Int, error) {
+	return c.WithContext(ctx).ChainID()
+}
+
+func (c *Client) SignerContext(ctx context.Context) (types.Signer, error) {
+	return c.WithContext(ctx).Signer()
+}
+
+func (c *Client) DeployPalettePLTWrapperContext(ctx context.Context, owner, proxy common.Address, chainId *big.Int) (common.Address, error) {
+	return c.WithContext(ctx).DeployPalettePLTWrapper(owner, proxy, chainId)
+}
+
+func (c *Client) DeployPaletteNFTQueryContext(ctx context.Context, owner common.Address, limit uint64) (common.Address, error) {
+	return c.WithContext(ctx).DeployPaletteNFTQuery(owner, limit)
+}
+
+func (c *Client) DeployPaletteNFTWrapperContext(ctx context.Context, owner, feeToken common.Address, chainId *big.Int) (common.Address, error) {
+	return c.WithContext(ctx).DeployPaletteNFTWrapper(owner, feeToken, chainId)
+}
+
+func (c *Client) PaletteNFTWrapSetLockProxyContext(ctx context.Context, wrapAddr, proxyAddr common.Address) (common.Hash, error) {
+	return c.WithContext(ctx).PaletteNFTWrapSetLockProxy(wrapAddr, proxyAddr)
+}
+
+func (c *Client) GetPaletteNFTWrapLockProxyContext(ctx context.Context, wrapAddr common.Address) (common.Address, error) {
+	return c.WithContext(ctx).GetPaletteNFTWrapLockProxy(wrapAddr)
+}
+
+func (c *Client) PalettePLTWrapLockContext(ctx context.Context, wrapAddr, fromAsset, toAddr common.Address, toChainId uint64, amount, fee, id *big.Int) (common.Hash, error) {
+	return c.WithContext(ctx).PalettePLTWrapLock(wrapAddr, fromAsset, toAddr, toChainId, amount, fee, id)
+}
+
+func (c *Client) GetPaletteLockEventContext(ctx context.Context, hash common.Hash) (common.Address, common.Address, common.Address, common.Address, uint64, *big.Int, error) {
+	return c.WithContext(ctx).GetPaletteLockEvent(hash)
+}
+
+func (c *Client) GetPaletteUnlockEventContext(ctx context.Context, hash common.Hash) (common.Address, common.Address, *big.Int, error) {
+	return c.WithContext(ctx).GetPaletteUnlockEvent(hash)
+}
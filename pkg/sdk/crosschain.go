@@ -4,9 +4,11 @@ import (
 	"fmt"
 	"math/big"
 
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/contracts/native/plt"
 	"github.com/ethereum/go-ethereum/contracts/native/utils"
+	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/polynetwork/eth-contracts/go_abi/eccd_abi"
 	"github.com/polynetwork/eth-contracts/go_abi/eccm_abi"
 	"github.com/polynetwork/eth-contracts/go_abi/eccmp_abi"
@@ -14,8 +16,10 @@ import (
 )
 
 func (c *Client) DeployECCD() (common.Address, error) {
-	auth := c.makeDeployAuth()
-	addr, tx, _, err := eccd_abi.DeployEthCrossChainData(auth, c.backend)
+	addr, tx, err := c.deploy(c.makeDeployAuth(), func(auth *bind.TransactOpts) (common.Address, *types.Transaction, error) {
+		addr, tx, _, err := eccd_abi.DeployEthCrossChainData(auth, c.backend)
+		return addr, tx, err
+	})
 	if err != nil {
 		return utils.EmptyAddress, err
 	}
@@ -26,8 +30,10 @@ func (c *Client) DeployECCD() (common.Address, error) {
 }
 
 func (c *Client) DeployECCM(eccd common.Address, sideChainID uint64, whiteList []common.Address, bookeeperBytes []byte) (common.Address, error) {
-	auth := c.makeDeployAuth()
-	addr, tx, _, err := eccm_abi.DeployEthCrossChainManager(auth, c.backend, eccd, sideChainID, whiteList, bookeeperBytes)
+	addr, tx, err := c.deploy(c.makeDeployAuth(), func(auth *bind.TransactOpts) (common.Address, *types.Transaction, error) {
+		addr, tx, _, err := eccm_abi.DeployEthCrossChainManager(auth, c.backend, eccd, sideChainID, whiteList, bookeeperBytes)
+		return addr, tx, err
+	})
 	if err != nil {
 		return utils.EmptyAddress, err
 	}
@@ -42,8 +48,9 @@ func (c *Client) RecoverECCM(eccmAddr common.Address, bookeeperBytes []byte) (co
 	if err != nil {
 		return utils.EmptyHash, err
 	}
-	auth := c.makeAuth()
-	tx, err := eccm.RecoverEpochPk(auth, bookeeperBytes)
+	tx, err := c.transact(c.makeAuth(), func(auth *bind.TransactOpts) (*types.Transaction, error) {
+		return eccm.RecoverEpochPk(auth, bookeeperBytes)
+	})
 	if err != nil {
 		return utils.EmptyHash, err
 	}
@@ -55,8 +62,10 @@ func (c *Client) RecoverECCM(eccmAddr common.Address, bookeeperBytes []byte) (co
 }
 
 func (c *Client) DeployCCMP(eccm common.Address) (common.Address, error) {
-	auth := c.makeDeployAuth()
-	addr, tx, _, err := eccmp_abi.DeployEthCrossChainManagerProxy(auth, c.backend, eccm)
+	addr, tx, err := c.deploy(c.makeDeployAuth(), func(auth *bind.TransactOpts) (common.Address, *types.Transaction, error) {
+		addr, tx, _, err := eccmp_abi.DeployEthCrossChainManagerProxy(auth, c.backend, eccm)
+		return addr, tx, err
+	})
 	if err != nil {
 		return utils.EmptyAddress, err
 	}
@@ -69,13 +78,14 @@ func (c *Client) DeployCCMP(eccm common.Address) (common.Address, error) {
 func (c *Client) PauseCCMP(ccmpAddr common.Address) (common.Hash, error) {
 	ccmp, err := eccmp_abi.NewEthCrossChainManagerProxy(ccmpAddr, c.backend)
 	if err != nil {
-		return utils.EmptyHash, fmt.Errorf("new EthCrossChainManagerProxy err: %s", err)
+		return utils.EmptyHash, fmt.Errorf("new EthCrossChainManagerProxy err: %w", err)
 	}
 
-	auth := c.makeDeployAuth()
-	tx, err := ccmp.PauseEthCrossChainManager(auth)
+	tx, err := c.transact(c.makeDeployAuth(), func(auth *bind.TransactOpts) (*types.Transaction, error) {
+		return ccmp.PauseEthCrossChainManager(auth)
+	})
 	if err != nil {
-		return utils.EmptyHash, fmt.Errorf("call ccmp pause err: %s", err)
+		return utils.EmptyHash, fmt.Errorf("call ccmp pause err: %w", err)
 	}
 
 	if err := c.WaitTransaction(tx.Hash()); err != nil {
@@ -87,13 +97,14 @@ func (c *Client) PauseCCMP(ccmpAddr common.Address) (common.Hash, error) {
 func (c *Client) UnPauseCCMP(ccmpAddr common.Address) (common.Hash, error) {
 	ccmp, err := eccmp_abi.NewEthCrossChainManagerProxy(ccmpAddr, c.backend)
 	if err != nil {
-		return utils.EmptyHash, fmt.Errorf("new EthCrossChainManagerProxy err: %s", err)
+		return utils.EmptyHash, fmt.Errorf("new EthCrossChainManagerProxy err: %w", err)
 	}
 
-	auth := c.makeDeployAuth()
-	tx, err := ccmp.UnpauseEthCrossChainManager(auth)
+	tx, err := c.transact(c.makeDeployAuth(), func(auth *bind.TransactOpts) (*types.Transaction, error) {
+		return ccmp.UnpauseEthCrossChainManager(auth)
+	})
 	if err != nil {
-		return utils.EmptyHash, fmt.Errorf("call ccmp unpause err: %s", err)
+		return utils.EmptyHash, fmt.Errorf("call ccmp unpause err: %w", err)
 	}
 
 	if err := c.WaitTransaction(tx.Hash()); err != nil {
@@ -105,13 +116,14 @@ func (c *Client) UnPauseCCMP(ccmpAddr common.Address) (common.Hash, error) {
 func (c *Client) UpgradeECCM(newEccmAddr, ccmpAddr common.Address) (common.Hash, error) {
 	ccmp, err := eccmp_abi.NewEthCrossChainManagerProxy(ccmpAddr, c.backend)
 	if err != nil {
-		return utils.EmptyHash, fmt.Errorf("new EthCrossChainManagerProxy err: %s", err)
+		return utils.EmptyHash, fmt.Errorf("new EthCrossChainManagerProxy err: %w", err)
 	}
 
-	auth := c.makeDeployAuth()
-	tx, err := ccmp.UpgradeEthCrossChainManager(auth, newEccmAddr)
+	tx, err := c.transact(c.makeDeployAuth(), func(auth *bind.TransactOpts) (*types.Transaction, error) {
+		return ccmp.UpgradeEthCrossChainManager(auth, newEccmAddr)
+	})
 	if err != nil {
-		return utils.EmptyHash, fmt.Errorf("call upgradeEthCrossChainManager err: %s", err)
+		return utils.EmptyHash, fmt.Errorf("call upgradeEthCrossChainManager err: %w", err)
 	}
 
 	if err := c.WaitTransaction(tx.Hash()); err != nil {
@@ -123,13 +135,14 @@ func (c *Client) UpgradeECCM(newEccmAddr, ccmpAddr common.Address) (common.Hash,
 func (c *Client) ECCDTransferOwnerShip(eccdAddr, eccmAddr common.Address) (common.Hash, error) {
 	eccd, err := eccd_abi.NewEthCrossChainData(eccdAddr, c.backend)
 	if err != nil {
-		return utils.EmptyHash, fmt.Errorf("new EthCrossChainData err: %s", err)
+		return utils.EmptyHash, fmt.Errorf("new EthCrossChainData err: %w", err)
 	}
 
-	auth := c.makeAuth()
-	tx, err := eccd.TransferOwnership(auth, eccmAddr)
+	tx, err := c.transact(c.makeAuth(), func(auth *bind.TransactOpts) (*types.Transaction, error) {
+		return eccd.TransferOwnership(auth, eccmAddr)
+	})
 	if err != nil {
-		return utils.EmptyHash, fmt.Errorf("call transferOwnerShip err: %s", err)
+		return utils.EmptyHash, fmt.Errorf("call transferOwnerShip err: %w", err)
 	}
 	if err := c.WaitTransaction(tx.Hash()); err != nil {
 		return utils.EmptyHash, err
@@ -142,19 +155,30 @@ func (c *Client) ECCDOwnership(eccdAddr common.Address) (common.Address, error)
 	if err != nil {
 		return utils.EmptyAddress, err
 	}
-	return eccd.Owner(nil)
+	return eccd.Owner(c.getCallOpts())
+}
+
+// ECCDCurEpochPubKeys returns the poly consensus public keys of current epoch recorded in eccd, which
+// is empty before the poly genesis block initialized.
+func (c *Client) ECCDCurEpochPubKeys(eccdAddr common.Address) ([]byte, error) {
+	eccd, err := eccd_abi.NewEthCrossChainData(eccdAddr, c.backend)
+	if err != nil {
+		return nil, err
+	}
+	return eccd.GetCurEpochConPubKeyBytes(c.getCallOpts())
 }
 
 func (c *Client) ECCMTransferOwnerShip(eccmAddr, ccmpAddr common.Address) (common.Hash, error) {
 	eccm, err := eccm_abi.NewEthCrossChainManager(eccmAddr, c.backend)
 	if err != nil {
-		return utils.EmptyHash, fmt.Errorf("new EthCrossChainManager err: %s", err)
+		return utils.EmptyHash, fmt.Errorf("new EthCrossChainManager err: %w", err)
 	}
 
-	auth := c.makeAuth()
-	tx, err := eccm.TransferOwnership(auth, ccmpAddr)
+	tx, err := c.transact(c.makeAuth(), func(auth *bind.TransactOpts) (*types.Transaction, error) {
+		return eccm.TransferOwnership(auth, ccmpAddr)
+	})
 	if err != nil {
-		return utils.EmptyHash, fmt.Errorf("call transferOwnerShip err: %s", err)
+		return utils.EmptyHash, fmt.Errorf("call transferOwnerShip err: %w", err)
 	}
 	if err := c.WaitTransaction(tx.Hash()); err != nil {
 		return utils.EmptyHash, err
@@ -167,7 +191,7 @@ func (c *Client) ECCMOwnership(eccmAddr common.Address) (common.Address, error)
 	if err != nil {
 		return utils.EmptyAddress, err
 	}
-	return eccm.Owner(nil)
+	return eccm.Owner(c.getCallOpts())
 }
 
 func (c *Client) CCMPTransferOwnerShip(ccmpAddr, newOwner common.Address) (common.Hash, error) {
@@ -176,10 +200,11 @@ func (c *Client) CCMPTransferOwnerShip(ccmpAddr, newOwner common.Address) (commo
 		return utils.EmptyHash, err
 	}
 
-	auth := c.makeAuth()
-	tx, err := ccmp.TransferOwnership(auth, newOwner)
+	tx, err := c.transact(c.makeAuth(), func(auth *bind.TransactOpts) (*types.Transaction, error) {
+		return ccmp.TransferOwnership(auth, newOwner)
+	})
 	if err != nil {
-		return utils.EmptyHash, fmt.Errorf("call transferOwnerShip err: %s", err)
+		return utils.EmptyHash, fmt.Errorf("call transferOwnerShip err: %w", err)
 	}
 	if err := c.WaitTransaction(tx.Hash()); err != nil {
 		return utils.EmptyHash, err
@@ -192,7 +217,7 @@ func (c *Client) CCMPOwnership(ccmpAddr common.Address) (common.Address, error)
 	if err != nil {
 		return utils.EmptyAddress, err
 	}
-	return ccmp.Owner(nil)
+	return ccmp.Owner(c.getCallOpts())
 }
 
 //func (c *Client) TransferCrossChainAdminOwnership(newOwner common.Address) (common.Hash, error) {
@@ -343,8 +368,9 @@ func (c *Client) SetNFTCCMP(proxyAddr, ccmp common.Address) (common.Hash, error)
 		return utils.EmptyHash, err
 	}
 
-	auth := c.makeAuth()
-	tx, err := proxy.SetManagerProxy(auth, ccmp)
+	tx, err := c.transact(c.makeAuth(), func(auth *bind.TransactOpts) (*types.Transaction, error) {
+		return proxy.SetManagerProxy(auth, ccmp)
+	})
 	if err != nil {
 		return utils.EmptyHash, err
 	}
@@ -360,12 +386,14 @@ func (c *Client) GetNFTCCMP(proxyAddr common.Address) (common.Address, error) {
 		return utils.EmptyAddress, err
 	}
 
-	return proxy.ManagerProxyContract(nil)
+	return proxy.ManagerProxyContract(c.getCallOpts())
 }
 
 func (c *Client) DeployNFTProxy() (common.Address, error) {
-	auth := c.makeDeployAuth()
-	addr, tx, _, err := nftlp.DeployPolyNFTLockProxy(auth, c.backend)
+	addr, tx, err := c.deploy(c.makeDeployAuth(), func(auth *bind.TransactOpts) (common.Address, *types.Transaction, error) {
+		addr, tx, _, err := nftlp.DeployPolyNFTLockProxy(auth, c.backend)
+		return addr, tx, err
+	})
 	if err != nil {
 		return utils.EmptyAddress, err
 	}
@@ -386,8 +414,9 @@ func (c *Client) BindNFTProxy(
 		return utils.EmptyHash, err
 	}
 
-	auth := c.makeAuth()
-	tx, err := proxy.BindProxyHash(auth, targetSideChainID, targetLockProxy.Bytes())
+	tx, err := c.transact(c.makeAuth(), func(auth *bind.TransactOpts) (*types.Transaction, error) {
+		return proxy.BindProxyHash(auth, targetSideChainID, targetLockProxy.Bytes())
+	})
 	if err != nil {
 		return utils.EmptyHash, err
 	}
@@ -407,7 +436,7 @@ func (c *Client) GetBoundNFTProxy(
 		return utils.EmptyAddress, err
 	}
 
-	bz, err := proxy.ProxyHashMap(nil, targetSideChainID)
+	bz, err := proxy.ProxyHashMap(c.getCallOpts(), targetSideChainID)
 	if err != nil {
 		return utils.EmptyAddress, err
 	}
@@ -421,8 +450,9 @@ func (c *Client) TransferNFTProxyOwnership(proxyAddr, newOwner common.Address) (
 		return utils.EmptyHash, err
 	}
 
-	auth := c.makeAuth()
-	tx, err := proxy.TransferOwnership(auth, newOwner)
+	tx, err := c.transact(c.makeAuth(), func(auth *bind.TransactOpts) (*types.Transaction, error) {
+		return proxy.TransferOwnership(auth, newOwner)
+	})
 	if err != nil {
 		return utils.EmptyHash, err
 	}
@@ -437,7 +467,7 @@ func (c *Client) NFTProxyOwnership(proxyAddr common.Address) (common.Address, er
 	if err != nil {
 		return utils.EmptyAddress, err
 	}
-	return proxy.Owner(nil)
+	return proxy.Owner(c.getCallOpts())
 }
 
 func (c *Client) BindNFTAsset(
@@ -452,8 +482,9 @@ func (c *Client) BindNFTAsset(
 		return utils.EmptyHash, err
 	}
 
-	auth := c.makeAuth()
-	tx, err := proxy.BindAssetHash(auth, fromAsset, targetSideChainID, toAsset.Bytes())
+	tx, err := c.transact(c.makeAuth(), func(auth *bind.TransactOpts) (*types.Transaction, error) {
+		return proxy.BindAssetHash(auth, fromAsset, targetSideChainID, toAsset.Bytes())
+	})
 	if err != nil {
 		return utils.EmptyHash, err
 	}
@@ -469,7 +500,7 @@ func (c *Client) GetBoundNFTAsset(lockProxy, fromAsset common.Address, toChainID
 		return utils.EmptyAddress, err
 	}
 
-	bz, err := proxy.AssetHashMap(nil, fromAsset, toChainID)
+	bz, err := proxy.AssetHashMap(c.getCallOpts(), fromAsset, toChainID)
 	if err != nil {
 		return utils.EmptyAddress, err
 	}
@@ -479,13 +510,14 @@ func (c *Client) GetBoundNFTAsset(lockProxy, fromAsset common.Address, toChainID
 func (c *Client) InitGenesisBlock(eccmAddr common.Address, rawHdr, publickeys []byte) (common.Hash, error) {
 	eccm, err := eccm_abi.NewEthCrossChainManager(eccmAddr, c.backend)
 	if err != nil {
-		return utils.EmptyHash, fmt.Errorf("new EthCrossChainManager err: %s", err)
+		return utils.EmptyHash, fmt.Errorf("new EthCrossChainManager err: %w", err)
 	}
 
-	auth := c.makeDeployAuth()
-	tx, err := eccm.InitGenesisBlock(auth, rawHdr, publickeys)
+	tx, err := c.transact(c.makeDeployAuth(), func(auth *bind.TransactOpts) (*types.Transaction, error) {
+		return eccm.InitGenesisBlock(auth, rawHdr, publickeys)
+	})
 	if err != nil {
-		return utils.EmptyHash, fmt.Errorf("call eccm InitGenesisBlock err: %s", err)
+		return utils.EmptyHash, fmt.Errorf("call eccm InitGenesisBlock err: %w", err)
 	}
 
 	if err := c.WaitTransaction(tx.Hash()); err != nil {
@@ -32,7 +32,10 @@ var (
 )
 
 func TestMain(m *testing.M) {
-	cli = NewSender(url, nil)
+	var err error
+	if cli, err = NewSender(url, nil); err != nil {
+		panic(err)
+	}
 	cli.caller = common.HexToAddress("0x8B35064B158634458Fd53A861d68Eb84152E4106")
 	Init()
 	os.Exit(m.Run())
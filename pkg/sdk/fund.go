@@ -0,0 +1,80 @@
+package sdk
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/contracts/native/plt"
+	"github.com/ethereum/go-ethereum/contracts/native/utils"
+	"github.com/palettechain/deploy-tool/pkg/log"
+)
+
+// fund methods, native currency transfer, PLT transfer from the funder balance, or PLT mint which
+// requires the funder to be the PLT owner.
+const (
+	FundNative   = "native"
+	FundTransfer = "transfer"
+	FundMint     = "mint"
+)
+
+// Fund pay amount to every address with method, the nonces are reserved in one range and the txs are
+// waited after all of them sent. the tx hashes are returned in the order of addresses, and the hashes
+// sent before the failure are returned with the error.
+func (c *Client) Fund(to []common.Address, amount *big.Int, method string) ([]common.Hash, error) {
+	if len(to) == 0 {
+		return nil, nil
+	}
+	if amount == nil || amount.Sign() <= 0 {
+		return nil, fmt.Errorf("invalid fund amount %v", amount)
+	}
+	send, err := c.fundSender(method, amount)
+	if err != nil {
+		return nil, err
+	}
+
+	first, err := c.ReserveNonces(len(to))
+	if err != nil {
+		return nil, err
+	}
+	hashes := make([]common.Hash, 0, len(to))
+	for i, addr := range to {
+		hash, err := send(first+uint64(i), addr)
+		if err != nil {
+			// release the unsent nonces from the last one, so that they are rolled back
+			for j := len(to) - 1; j >= i; j-- {
+				c.releaseNonce(first+uint64(j), err)
+			}
+			return hashes, fmt.Errorf("fund %s failed, err: %w", addr.Hex(), err)
+		}
+		log.Infof("fund %s %s %s, tx %s", method, addr.Hex(), amount, hash.Hex())
+		hashes = append(hashes, hash)
+	}
+
+	return hashes, c.WaitTransactions(hashes)
+}
+
+type fundFn func(nonce uint64, to common.Address) (common.Hash, error)
+
+func (c *Client) fundSender(method string, amount *big.Int) (fundFn, error) {
+	switch method {
+	case FundNative:
+		return func(nonce uint64, to common.Address) (common.Hash, error) {
+			return c.SendValueWithNonce(nonce, to, amount)
+		}, nil
+	case FundTransfer, FundMint:
+		pltMethod := plt.MethodTransfer
+		if method == FundMint {
+			pltMethod = plt.MethodMint
+		}
+		return func(nonce uint64, to common.Address) (common.Hash, error) {
+			payload, err := c.packPLT(pltMethod, to, amount)
+			if err != nil {
+				return utils.EmptyHash, err
+			}
+			return c.SendTransactionWithNonce(nonce, PLTAddress, payload)
+		}, nil
+	default:
+		return nil, fmt.Errorf("invalid fund method %s, should be one of %s, %s and %s", method, FundNative, FundTransfer, FundMint)
+	}
+}
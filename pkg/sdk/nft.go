@@ -39,7 +39,7 @@ func (c *Client) NFTDeploy(name string, symbol string) (common.Hash, common.Addr
 
 	receipts, err := c.GetReceipt(hash)
 	if err != nil {
-		return utils.EmptyHash, utils.EmptyAddress, fmt.Errorf("nft depoly - get receipt %s err: %s", hash.Hex(), err)
+		return utils.EmptyHash, utils.EmptyAddress, fmt.Errorf("nft depoly - get receipt %s err: %w", hash.Hex(), err)
 	}
 	if len(receipts.Logs) == 0 {
 		return utils.EmptyHash, utils.EmptyAddress, fmt.Errorf("invalid tx %s, no receipts events", hash.Hex())
@@ -0,0 +1,101 @@
+package sdk
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/palettechain/deploy-tool/pkg/eth"
+	"github.com/palettechain/deploy-tool/pkg/log"
+)
+
+type transactFn func(auth *bind.TransactOpts) (*types.Transaction, error)
+type deployFn func(auth *bind.TransactOpts) (common.Address, *types.Transaction, error)
+
+// nonceManager returns the pending-aware nonce manager shared by all palette clients with the same
+// chain id, raw transactions and contract bindings reserve nonce from it.
+func (c *Client) nonceManager() (*eth.NonceManager, error) {
+	chainID, err := c.ChainID()
+	if err != nil {
+		return nil, err
+	}
+	return eth.SharedNonceManager(fmt.Sprintf("palette-%s", chainID), c.backend), nil
+}
+
+// ReserveNonces reserve a contiguous nonce range for the client address, and returns the first nonce.
+// the step should send txs with nonce in [first, first+n) by `SendTransactionWithNonce`. the retried
+// step fails with `errs.PendingError` if txs of the failed attempt are still pending.
+func (c *Client) ReserveNonces(n int) (uint64, error) {
+	nm, err := c.nonceManager()
+	if err != nil {
+		return 0, err
+	}
+	if err := nm.CheckPending(c.Context(), c.Address()); err != nil {
+		return 0, err
+	}
+	return nm.Reserve(c.Context(), c.Address(), n)
+}
+
+func (c *Client) reserveNonce() (uint64, error) {
+	return c.ReserveNonces(1)
+}
+
+// releaseNonce handle the nonce of tx which failed to be sent, the nonce is released and resynced
+// if it is stale.
+func (c *Client) releaseNonce(nonce uint64, sendErr error) {
+	nm, err := c.nonceManager()
+	if err != nil {
+		return
+	}
+	addr := c.Address()
+	if eth.IsNonceError(sendErr) {
+		log.Debugf("%s nonce %d is stale, resync nonce, err: %v", addr.Hex(), nonce, sendErr)
+		nm.Resync(addr)
+	}
+	nm.Release(addr, nonce)
+}
+
+// sentNonce settle the reserved nonce of tx which has been sent.
+func (c *Client) sentNonce() {
+	if nm, err := c.nonceManager(); err == nil {
+		nm.Sent(c.Address())
+	}
+}
+
+// transact send contract binding tx with nonce reserved from the shared nonce manager.
+func (c *Client) transact(auth *bind.TransactOpts, fn transactFn) (*types.Transaction, error) {
+	nonce, err := c.reserveNonce()
+	if err != nil {
+		return nil, err
+	}
+	auth.Nonce = new(big.Int).SetUint64(nonce)
+	if auth.Signer, err = c.transactSigner(auth.From); err != nil {
+		c.releaseNonce(nonce, err)
+		return nil, err
+	}
+
+	tx, err := fn(auth)
+	if err != nil {
+		c.releaseNonce(nonce, err)
+		return nil, err
+	}
+	c.sentNonce()
+	return tx, nil
+}
+
+// deploy send contract deployment tx with nonce reserved from the shared nonce manager.
+func (c *Client) deploy(auth *bind.TransactOpts, fn deployFn) (common.Address, *types.Transaction, error) {
+	var addr common.Address
+	tx, err := c.transact(auth, func(auth *bind.TransactOpts) (tx *types.Transaction, err error) {
+		addr, tx, err = fn(auth)
+		return
+	})
+	if err == nil && c.deploySent != nil {
+		if err := c.deploySent(addr, tx.Hash()); err != nil {
+			return addr, tx, fmt.Errorf("deployment %s of %s sent, err: %w", tx.Hash().Hex(), addr.Hex(), err)
+		}
+	}
+	return addr, tx, err
+}
@@ -4,11 +4,13 @@ import (
 	"context"
 	"github.com/ethereum/go-ethereum/contracts/native/plt"
 	"math/big"
-	"time"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/contracts/native/utils"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/palettechain/deploy-tool/pkg/eth"
 	"github.com/palettechain/deploy-tool/pkg/log"
+	"github.com/palettechain/deploy-tool/pkg/txwait"
 )
 
 func (c *Client) BalanceOf(owner common.Address, blockNum string) (*big.Int, error) {
@@ -30,26 +32,84 @@ func (c *Client) BalanceOf(owner common.Address, blockNum string) (*big.Int, err
 	return output.Balance, nil
 }
 
-func (self *Client) WaitTransaction(hash common.Hash) error {
-	for {
-		time.Sleep(time.Second * 1)
-		_, ispending, err := self.backend.TransactionByHash(context.Background(), hash)
-		if err != nil {
-			log.Errorf("failed to call TransactionByHash: %v", err)
-			continue
-		}
-		if ispending == true {
-			continue
-		}
+// waitOptions used to wait palette transactions, set by config.
+var waitOptions txwait.Options
 
-		if err := self.DumpEventLog(hash); err != nil {
+func SetWaitOptions(opts txwait.Options) {
+	waitOptions = opts
+}
+
+// WaitTransaction wait the receipt of tx with configured confirmations and dump the event logs,
+// typed errors defined in `errs` returned if the tx timeout, reverted or dropped.
+func (c *Client) WaitTransaction(hash common.Hash) error {
+	return c.WaitTransactionContext(c.Context(), hash)
+}
+
+// WaitTransactions wait the txs one by one after all of them sent, and returns the first error.
+func (c *Client) WaitTransactions(hashes []common.Hash) error {
+	for _, hash := range hashes {
+		if err := c.WaitTransaction(hash); err != nil {
 			return err
 		}
-		break
 	}
 	return nil
 }
 
+func (c *Client) WaitTransactionContext(ctx context.Context, hash common.Hash) error {
+	receipt, err := c.waitReceipt(ctx, []common.Hash{hash}, c.speedUp)
+	if err != nil {
+		return err
+	}
+	return c.DumpEventLog(receipt.TxHash)
+}
+
+// waitReceipt wait the txs with the same nonce until one of them confirmed, and the latest one is
+// replaced by replace if it is stuck longer than `EscalateAfter`.
+func (c *Client) waitReceipt(ctx context.Context, hashes []common.Hash, replace txwait.Replacer) (*types.Receipt, error) {
+	receipt, err := txwait.WaitEscalate(ctx, c.backend, hashes, waitOptions, replace)
+	if err != nil {
+		if signer, e := c.Signer(); e == nil {
+			err = eth.FillRevertReason(ctx, c.backend, signer, err)
+		}
+		return nil, err
+	}
+	return receipt, nil
+}
+
+func (c *Client) speedUp(ctx context.Context, hash common.Hash) (common.Hash, error) {
+	return eth.Replace(ctx, c.Client, c.Key, hash, false, nil)
+}
+
+// SpeedUp replace the pending tx with bumped gas price, and wait until the tx or the replacement
+// confirmed. the hash of the confirmed one is returned.
+func (c *Client) SpeedUp(hash common.Hash) (common.Hash, error) {
+	next, err := c.speedUp(c.Context(), hash)
+	if err != nil {
+		return utils.EmptyHash, err
+	}
+	log.Infof("tx %s replaced by %s with bumped gas price", hash.Hex(), next.Hex())
+	receipt, err := c.waitReceipt(c.Context(), []common.Hash{hash, next}, nil)
+	if err != nil {
+		return utils.EmptyHash, err
+	}
+	return receipt.TxHash, nil
+}
+
+// Cancel replace the pending tx with a zero value self transfer with bumped gas price, and wait until
+// the tx or the replacement confirmed. the tx is canceled if the returned hash is not the tx hash.
+func (c *Client) Cancel(hash common.Hash) (common.Hash, error) {
+	next, err := eth.Replace(c.Context(), c.Client, c.Key, hash, true, nil)
+	if err != nil {
+		return utils.EmptyHash, err
+	}
+	log.Infof("tx %s replaced by self transfer %s", hash.Hex(), next.Hex())
+	receipt, err := c.waitReceipt(c.Context(), []common.Hash{hash, next}, nil)
+	if err != nil {
+		return utils.EmptyHash, err
+	}
+	return receipt.TxHash, nil
+}
+
 func (c *Client) packPLT(method string, args ...interface{}) ([]byte, error) {
 	return utils.PackMethod(PLTABI, method, args...)
 }
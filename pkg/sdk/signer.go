@@ -0,0 +1,62 @@
+package sdk
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/palettechain/deploy-tool/pkg/errs"
+)
+
+// expectedChainID is the palette chain id configured, and the client refuse to send transactions
+// if the node chain id is different. no check if it is 0.
+var expectedChainID uint64
+
+func SetExpectedChainID(chainID uint64) {
+	expectedChainID = chainID
+}
+
+// ChainID fetch the chain id from node and cache it in client, error returned if it is not the
+// configured chain id.
+func (c *Client) ChainID() (*big.Int, error) {
+	if c.chainID != nil {
+		return c.chainID, nil
+	}
+	chainID, err := c.backend.ChainID(c.Context())
+	if err != nil {
+		return nil, fmt.Errorf("get palette chain id failed, err: %w", errs.RPC("eth_chainId", err))
+	}
+	if expectedChainID != 0 && (!chainID.IsUint64() || chainID.Uint64() != expectedChainID) {
+		return nil, fmt.Errorf("palette node %s chain id %s mismatch, expected %d", c.url, chainID, expectedChainID)
+	}
+	c.chainID = chainID
+	return chainID, nil
+}
+
+// Signer returns the EIP-155 signer with the node chain id, so that the signed transactions can not
+// be replayed on other palette networks.
+func (c *Client) Signer() (types.Signer, error) {
+	chainID, err := c.ChainID()
+	if err != nil {
+		return nil, err
+	}
+	return types.NewEIP155Signer(chainID), nil
+}
+
+// transactSigner returns the contract binding signer function with EIP-155 signer.
+func (c *Client) transactSigner(from common.Address) (bind.SignerFn, error) {
+	signer, err := c.Signer()
+	if err != nil {
+		return nil, err
+	}
+	key := c.Key
+	return func(_ types.Signer, address common.Address, tx *types.Transaction) (*types.Transaction, error) {
+		if address != from {
+			return nil, bind.ErrNotAuthorized
+		}
+		return types.SignTx(tx, signer, key)
+	}, nil
+}
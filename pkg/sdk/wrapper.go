@@ -5,20 +5,25 @@ import (
 	"fmt"
 	"math/big"
 	"strings"
+	"sync"
 
 	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/contracts/native"
 	"github.com/ethereum/go-ethereum/contracts/native/utils"
 	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/palettechain/deploy-tool/pkg/abis"
 	nftwp "github.com/polynetwork/nft-contracts/go_abi/nft_native_wrap_abi"
 	pltwp "github.com/polynetwork/nft-contracts/go_abi/plt_native_wrap_abi"
 	nftqy "github.com/polynetwork/nft-contracts/go_abi/nft_query_abi"
 )
 
 func (c *Client) DeployPalettePLTWrapper(owner, proxy common.Address, chainId *big.Int) (common.Address, error) {
-	auth := c.makeDeployAuth()
-	addr, tx, _, err := pltwp.DeployPolyWrapper(auth, c.backend, owner, proxy, chainId)
+	addr, tx, err := c.deploy(c.makeDeployAuth(), func(auth *bind.TransactOpts) (common.Address, *types.Transaction, error) {
+		addr, tx, _, err := pltwp.DeployPolyWrapper(auth, c.backend, owner, proxy, chainId)
+		return addr, tx, err
+	})
 	if err != nil {
 		return utils.EmptyAddress, err
 	}
@@ -29,8 +34,10 @@ func (c *Client) DeployPalettePLTWrapper(owner, proxy common.Address, chainId *b
 }
 
 func (c *Client) DeployPaletteNFTQuery(owner common.Address, limit uint64) (common.Address, error) {
-	auth := c.makeDeployAuth()
-	addr, tx, _, err := nftqy.DeployPolyNFTQuery(auth, c.backend, owner, new(big.Int).SetUint64(limit))
+	addr, tx, err := c.deploy(c.makeDeployAuth(), func(auth *bind.TransactOpts) (common.Address, *types.Transaction, error) {
+		addr, tx, _, err := nftqy.DeployPolyNFTQuery(auth, c.backend, owner, new(big.Int).SetUint64(limit))
+		return addr, tx, err
+	})
 	if err != nil {
 		return utils.EmptyAddress, err
 	}
@@ -41,8 +48,10 @@ func (c *Client) DeployPaletteNFTQuery(owner common.Address, limit uint64) (comm
 }
 
 func (c *Client) DeployPaletteNFTWrapper(owner, feeToken common.Address, chainId *big.Int) (common.Address, error) {
-	auth := c.makeDeployAuth()
-	addr, tx, _, err := nftwp.DeployPolyNativeNFTWrapper(auth, c.backend, owner, chainId, feeToken)
+	addr, tx, err := c.deploy(c.makeDeployAuth(), func(auth *bind.TransactOpts) (common.Address, *types.Transaction, error) {
+		addr, tx, _, err := nftwp.DeployPolyNativeNFTWrapper(auth, c.backend, owner, chainId, feeToken)
+		return addr, tx, err
+	})
 	if err != nil {
 		return utils.EmptyAddress, err
 	}
@@ -58,8 +67,9 @@ func (c *Client) PaletteNFTWrapSetLockProxy(wrapAddr, proxyAddr common.Address)
 		return utils.EmptyHash, err
 	}
 
-	auth := c.makeDeployAuth()
-	tx, err := wrapper.SetLockProxy(auth, proxyAddr)
+	tx, err := c.transact(c.makeDeployAuth(), func(auth *bind.TransactOpts) (*types.Transaction, error) {
+		return wrapper.SetLockProxy(auth, proxyAddr)
+	})
 	if err != nil {
 		return utils.EmptyHash, err
 	}
@@ -76,7 +86,7 @@ func (c *Client) GetPaletteNFTWrapLockProxy(wrapAddr common.Address) (common.Add
 		return utils.EmptyAddress, err
 	}
 
-	return wrapper.LockProxy(nil)
+	return wrapper.LockProxy(c.getCallOpts())
 }
 
 func (c *Client) PalettePLTWrapLock(wrapAddr, fromAsset, toAddr common.Address, toChainId uint64, amount, fee, id *big.Int) (common.Hash, error) {
@@ -85,8 +95,9 @@ func (c *Client) PalettePLTWrapLock(wrapAddr, fromAsset, toAddr common.Address,
 		return utils.EmptyHash, err
 	}
 
-	auth := c.makeDeployAuth()
-	tx, err := wrapper.Lock(auth, fromAsset, toChainId, toAddr.Bytes(), amount, fee, id)
+	tx, err := c.transact(c.makeDeployAuth(), func(auth *bind.TransactOpts) (*types.Transaction, error) {
+		return wrapper.Lock(auth, fromAsset, toChainId, toAddr.Bytes(), amount, fee, id)
+	})
 	if err != nil {
 		return utils.EmptyHash, err
 	}
@@ -98,6 +109,8 @@ func (c *Client) PalettePLTWrapLock(wrapAddr, fromAsset, toAddr common.Address,
 }
 
 var (
+	pltProxyOnce   sync.Once
+	pltProxyErr    error
 	abiLockEvent   abi.Event
 	abiUnLockEvent abi.Event
 )
@@ -125,13 +138,25 @@ type UnlockEvent struct {
 }
 
 func init() {
-	ab, err := abi.JSON(strings.NewReader(pltProxyAbiJsonStr))
-	if err != nil {
-		panic(err)
-	} else {
+	// the error is returned by the event getters if the abi is invalid
+	_ = loadPLTProxyEvents()
+}
+
+// loadPLTProxyEvents parse the lock and unlock events once, and register them as the events of palette
+// native PLT contract. the error is returned by every call if the abi is invalid.
+func loadPLTProxyEvents() error {
+	pltProxyOnce.Do(func() {
+		ab, err := abi.JSON(strings.NewReader(pltProxyAbiJsonStr))
+		if err != nil {
+			pltProxyErr = fmt.Errorf("read PLT proxy abi failed, err: %v", err)
+			return
+		}
 		abiLockEvent = ab.Events["lock"]
 		abiUnLockEvent = ab.Events["unlock"]
-	}
+		// lock and unlock events emitted by palette native PLT contract
+		abis.RegisterABI("PLT", ab)
+	})
+	return pltProxyErr
 }
 
 func (c *Client) GetPaletteLockEvent(hash common.Hash) (fromAsset, fromAddress, toAsset, toAddress common.Address, chainID uint64, amount *big.Int, err error) {
@@ -142,7 +167,10 @@ func (c *Client) GetPaletteLockEvent(hash common.Hash) (fromAsset, fromAddress,
 		proxyAddr = common.HexToAddress(native.PLTContractAddress)
 	)
 
-	if receipt, err = c.backend.TransactionReceipt(context.Background(), hash); err != nil {
+	if err = loadPLTProxyEvents(); err != nil {
+		return
+	}
+	if receipt, err = c.backend.TransactionReceipt(c.Context(), hash); err != nil {
 		return
 	}
 	if length := len(receipt.Logs); length < 3 {
@@ -185,7 +213,10 @@ func (c *Client) GetPaletteUnlockEvent(hash common.Hash) (toAddress, toAsset com
 		proxyAddr   = common.HexToAddress(native.PLTContractAddress)
 	)
 
-	if receipt, err = c.backend.TransactionReceipt(context.Background(), hash); err != nil {
+	if err = loadPLTProxyEvents(); err != nil {
+		return
+	}
+	if receipt, err = c.backend.TransactionReceipt(c.Context(), hash); err != nil {
 		return
 	}
 	if length := len(receipt.Logs); length < 3 {
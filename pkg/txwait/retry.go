@@ -0,0 +1,39 @@
+package txwait
+
+import (
+	"context"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+type retryKey struct{}
+
+// retryState records the accounts which have sent txs in the retried attempt.
+type retryState struct {
+	lock sync.Mutex
+	sent map[common.Address]bool
+}
+
+// WithRetry returns the context of a retried attempt, txs sent with it check the txs of the failed
+// attempt before sending, see `FirstRetrySend`.
+func WithRetry(ctx context.Context) context.Context {
+	return context.WithValue(ctx, retryKey{}, &retryState{sent: make(map[common.Address]bool)})
+}
+
+// FirstRetrySend returns true if the context is a retried attempt and the account sends its first tx
+// in it, the txs of the failed attempt may still be pending and should be checked before sending.
+func FirstRetrySend(ctx context.Context, account common.Address) bool {
+	state, ok := ctx.Value(retryKey{}).(*retryState)
+	if !ok {
+		return false
+	}
+	state.lock.Lock()
+	defer state.lock.Unlock()
+
+	if state.sent[account] {
+		return false
+	}
+	state.sent[account] = true
+	return true
+}
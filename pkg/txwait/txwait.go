@@ -0,0 +1,241 @@
+package txwait
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/palettechain/deploy-tool/pkg/errs"
+	"github.com/palettechain/deploy-tool/pkg/log"
+)
+
+const (
+	DefaultTimeout       = 10 * time.Minute
+	DefaultPollInterval  = time.Second
+	DefaultConfirmations = 1
+	DefaultDropTolerance = 60
+)
+
+// Backend is the chain reader used to wait transaction, which is implemented by `ethclient.Client`.
+type Backend interface {
+	TransactionByHash(ctx context.Context, hash common.Hash) (*types.Transaction, bool, error)
+	TransactionReceipt(ctx context.Context, hash common.Hash) (*types.Receipt, error)
+	HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error)
+}
+
+type Options struct {
+	// max duration to wait, default 10 minutes.
+	Timeout time.Duration
+	// duration between two polls, default 1 second.
+	PollInterval time.Duration
+	// number of blocks including the tx block, default 1 which means the tx is mined.
+	Confirmations uint64
+	// number of continuous polls that the tx can not be found in both mempool and chain,
+	// and the tx is treated as dropped after that, or the mined tx receipt can not be found,
+	// and the tx is treated as reorged out. default 60.
+	DropTolerance int
+	// duration to wait the pending tx before replacing it with bumped fees, and the replacement is
+	// replaced again after the same duration. 0 means never replace.
+	EscalateAfter time.Duration
+}
+
+func (o Options) withDefault() Options {
+	if o.Timeout == 0 {
+		o.Timeout = DefaultTimeout
+	}
+	if o.PollInterval == 0 {
+		o.PollInterval = DefaultPollInterval
+	}
+	if o.Confirmations == 0 {
+		o.Confirmations = DefaultConfirmations
+	}
+	if o.DropTolerance == 0 {
+		o.DropTolerance = DefaultDropTolerance
+	}
+	return o
+}
+
+// Replacer re-sign the pending tx with the same nonce and bumped fees, and returns the hash of the
+// replacement.
+type Replacer func(ctx context.Context, hash common.Hash) (common.Hash, error)
+
+// Wait poll the receipt of transaction until it gets enough confirmations, and returns typed errors
+// `errs.TimeoutError`, `errs.RevertedError`, `errs.DroppedError` or `errs.ReorgedError`, or the
+// context error if the context canceled by caller.
+//
+// confirmations are counted on the block hash of the receipt, and the receipt is fetched again in
+// every poll. the counting restarts if the tx is moved into another block by reorg, and the receipt
+// block should be the parent of the next block in chain before returning, so the tx block is still
+// canonical when the tx gets more than 1 confirmations, even if the receipt is fetched from a node on
+// a stale fork.
+func Wait(ctx context.Context, backend Backend, hash common.Hash, opts Options) (*types.Receipt, error) {
+	return WaitEscalate(ctx, backend, []common.Hash{hash}, opts, nil)
+}
+
+// WaitEscalate wait the transactions with the same nonce until one of them gets enough confirmations,
+// and the receipt of the mined one is returned. the latest tx is replaced by replace every
+// `EscalateAfter` if it is not nil, so that the tx stuck with low fees is sped up.
+func WaitEscalate(ctx context.Context, backend Backend, hashes []common.Hash, opts Options, replace Replacer) (*types.Receipt, error) {
+	opts = opts.withDefault()
+	ctx, cancel := context.WithTimeout(ctx, opts.Timeout)
+	defer cancel()
+
+	ticker := time.NewTicker(opts.PollInterval)
+	defer ticker.Stop()
+
+	hashes = append([]common.Hash{}, hashes...)
+	hash := hashes[0]
+	sent := time.Now()
+	notFound := 0
+	// the receipt which confirmations counted on, nil before the tx mined
+	var mined *types.Receipt
+	for {
+		select {
+		case <-ctx.Done():
+			if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+				return nil, &errs.TimeoutError{Hash: hash, Timeout: opts.Timeout}
+			}
+			return nil, ctx.Err()
+		case <-ticker.C:
+		}
+
+		receipt, found, err := receiptOf(ctx, backend, hashes)
+		if err != nil {
+			log.Debugf("failed to get receipt of tx %s, err: %v", hash.Hex(), err)
+			continue
+		}
+		if !found && mined != nil {
+			// the mined tx disappears from chain, wait it mined again in the new chain
+			if notFound++; notFound >= opts.DropTolerance {
+				return nil, &errs.ReorgedError{
+					Hash:        mined.TxHash,
+					BlockNumber: mined.BlockNumber.Uint64(),
+					BlockHash:   mined.BlockHash,
+				}
+			}
+			log.Debugf("receipt of mined tx %s not found, block %d %s may be reorged",
+				mined.TxHash.Hex(), mined.BlockNumber.Uint64(), mined.BlockHash.Hex())
+			continue
+		}
+		if !found {
+			if replace != nil && opts.EscalateAfter > 0 && time.Since(sent) >= opts.EscalateAfter {
+				latest := hashes[len(hashes)-1]
+				if next, err := replace(ctx, latest); err != nil {
+					log.Warnf("failed to replace pending tx %s, err: %v", latest.Hex(), err)
+				} else {
+					log.Infof("pending tx %s replaced by %s with bumped fees", latest.Hex(), next.Hex())
+					hashes = append(hashes, next)
+				}
+				sent = time.Now()
+			}
+			if pending(ctx, backend, hashes) {
+				notFound = 0
+				continue
+			}
+			if notFound++; notFound >= opts.DropTolerance {
+				return nil, &errs.DroppedError{Hash: hash}
+			}
+			continue
+		}
+		notFound = 0
+		hash = receipt.TxHash
+		if mined != nil && mined.BlockHash != receipt.BlockHash {
+			log.Warnf("tx %s reorged from block %d %s to block %d %s, recount confirmations", hash.Hex(),
+				mined.BlockNumber.Uint64(), mined.BlockHash.Hex(), receipt.BlockNumber.Uint64(), receipt.BlockHash.Hex())
+		}
+		mined = receipt
+
+		if receipt.Status == types.ReceiptStatusFailed {
+			return receipt, &errs.RevertedError{Hash: hash, BlockNumber: receipt.BlockNumber.Uint64()}
+		}
+		if opts.Confirmations <= 1 {
+			return receipt, nil
+		}
+
+		head, err := backend.HeaderByNumber(ctx, nil)
+		if err != nil {
+			log.Debugf("failed to get current header, err: %v", err)
+			continue
+		}
+		confirmations := new(big.Int).Sub(head.Number, receipt.BlockNumber).Uint64() + 1
+		if head.Number.Cmp(receipt.BlockNumber) >= 0 && confirmations >= opts.Confirmations {
+			ok, err := canonical(ctx, backend, receipt)
+			if err != nil {
+				log.Debugf("failed to check block %d of tx %s, err: %v", receipt.BlockNumber.Uint64(), hash.Hex(), err)
+				continue
+			}
+			if ok {
+				return receipt, nil
+			}
+			log.Warnf("block %d %s of tx %s is not canonical, recount confirmations", receipt.BlockNumber.Uint64(),
+				receipt.BlockHash.Hex(), hash.Hex())
+			continue
+		}
+		log.Debugf("tx %s confirmations %d/%d", hash.Hex(), confirmations, opts.Confirmations)
+	}
+}
+
+// canonical returns true if the receipt block is the parent of the next block in chain. the parent hash
+// is returned by node as it is, while the header hashed locally differs from node if the header has
+// fields unknown to the client, e.g: base fee.
+func canonical(ctx context.Context, backend Backend, receipt *types.Receipt) (bool, error) {
+	next, err := backend.HeaderByNumber(ctx, new(big.Int).Add(receipt.BlockNumber, common.Big1))
+	if err != nil {
+		return false, err
+	}
+	return next.ParentHash == receipt.BlockHash, nil
+}
+
+// receiptOf returns the receipt of the first mined tx, and the tx hash is set if the node omits it.
+func receiptOf(ctx context.Context, backend Backend, hashes []common.Hash) (*types.Receipt, bool, error) {
+	var lastErr error
+	for _, hash := range hashes {
+		receipt, err := backend.TransactionReceipt(ctx, hash)
+		if err == nil {
+			if receipt.TxHash == (common.Hash{}) {
+				receipt.TxHash = hash
+			}
+			return receipt, true, nil
+		}
+		if !errors.Is(err, ethereum.NotFound) {
+			lastErr = err
+		}
+	}
+	return nil, false, lastErr
+}
+
+// pending returns true if any tx can be found in mempool or chain.
+func pending(ctx context.Context, backend Backend, hashes []common.Hash) bool {
+	for _, hash := range hashes {
+		tx, _, err := backend.TransactionByHash(ctx, hash)
+		if err != nil {
+			if !errors.Is(err, ethereum.NotFound) {
+				// treat rpc error as unknown status, and never count it as dropped
+				log.Debugf("failed to call TransactionByHash %s, err: %v", hash.Hex(), err)
+				return true
+			}
+			continue
+		}
+		if tx != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// WaitAll wait all transactions, and returns the first error.
+func WaitAll(ctx context.Context, backend Backend, hashes []common.Hash, opts Options) ([]*types.Receipt, error) {
+	receipts := make([]*types.Receipt, len(hashes))
+	for i, hash := range hashes {
+		receipt, err := Wait(ctx, backend, hash, opts)
+		if err != nil {
+			return nil, err
+		}
+		receipts[i] = receipt
+	}
+	return receipts, nil
+}
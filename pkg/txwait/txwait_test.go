@@ -0,0 +1,227 @@
+package txwait
+
+import (
+	"context"
+	"math/big"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/palettechain/deploy-tool/pkg/errs"
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeBackend struct {
+	lock    sync.Mutex
+	pending bool
+	receipt *types.Receipt
+	head    uint64
+	// head increased by every header query
+	grow bool
+	// canonical block hashes by number, which are the parent hashes of the next headers
+	blocks map[uint64]common.Hash
+}
+
+func (b *fakeBackend) TransactionByHash(ctx context.Context, hash common.Hash) (*types.Transaction, bool, error) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	if b.pending {
+		return types.NewTransaction(0, common.Address{}, nil, 0, nil, nil), true, nil
+	}
+	return nil, false, ethereum.NotFound
+}
+
+func (b *fakeBackend) TransactionReceipt(ctx context.Context, hash common.Hash) (*types.Receipt, error) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	if b.receipt == nil {
+		return nil, ethereum.NotFound
+	}
+	return b.receipt, nil
+}
+
+func (b *fakeBackend) HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	if b.grow {
+		b.head++
+	}
+	if number != nil {
+		n := number.Uint64()
+		return &types.Header{Number: new(big.Int).SetUint64(n), ParentHash: b.blocks[n-1]}, nil
+	}
+	return &types.Header{Number: new(big.Int).SetUint64(b.head)}, nil
+}
+
+var (
+	testHash = common.HexToHash("0x01")
+	testOpts = Options{
+		Timeout:       200 * time.Millisecond,
+		PollInterval:  5 * time.Millisecond,
+		DropTolerance: 3,
+	}
+)
+
+func newReceipt(status, block uint64) *types.Receipt {
+	return &types.Receipt{Status: status, BlockNumber: new(big.Int).SetUint64(block)}
+}
+
+func TestWait(t *testing.T) {
+	backend := &fakeBackend{receipt: newReceipt(types.ReceiptStatusSuccessful, 10), head: 10}
+	receipt, err := Wait(context.Background(), backend, testHash, testOpts)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(10), receipt.BlockNumber.Uint64())
+}
+
+func TestWaitConfirmations(t *testing.T) {
+	backend := &fakeBackend{receipt: newReceipt(types.ReceiptStatusSuccessful, 10), head: 10, grow: true}
+	opts := testOpts
+	opts.Confirmations = 3
+	_, err := Wait(context.Background(), backend, testHash, opts)
+	assert.NoError(t, err)
+	assert.True(t, backend.head >= 12)
+
+	backend = &fakeBackend{receipt: newReceipt(types.ReceiptStatusSuccessful, 10), head: 10}
+	_, err = Wait(context.Background(), backend, testHash, opts)
+	assert.True(t, errs.IsTimeout(err))
+}
+
+func TestWaitReverted(t *testing.T) {
+	backend := &fakeBackend{receipt: newReceipt(types.ReceiptStatusFailed, 10), head: 10}
+	_, err := Wait(context.Background(), backend, testHash, testOpts)
+	assert.True(t, errs.IsReverted(err))
+}
+
+func TestWaitPending(t *testing.T) {
+	backend := &fakeBackend{pending: true}
+	_, err := Wait(context.Background(), backend, testHash, testOpts)
+	assert.True(t, errs.IsTimeout(err))
+}
+
+func TestWaitDropped(t *testing.T) {
+	backend := &fakeBackend{}
+	_, err := Wait(context.Background(), backend, testHash, testOpts)
+	assert.True(t, errs.IsDropped(err))
+}
+
+func TestWaitCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err := Wait(ctx, &fakeBackend{pending: true}, testHash, testOpts)
+	assert.Equal(t, context.Canceled, err)
+}
+
+// minedBackend only mines the tx of hash mined, and other txs are pending.
+type minedBackend struct {
+	fakeBackend
+	mined common.Hash
+}
+
+func (b *minedBackend) TransactionReceipt(ctx context.Context, hash common.Hash) (*types.Receipt, error) {
+	if hash != b.mined {
+		return nil, ethereum.NotFound
+	}
+	return newReceipt(types.ReceiptStatusSuccessful, 10), nil
+}
+
+func TestWaitEscalate(t *testing.T) {
+	replacement := common.HexToHash("0x02")
+	backend := &minedBackend{fakeBackend: fakeBackend{pending: true}, mined: replacement}
+	opts := testOpts
+	opts.EscalateAfter = 20 * time.Millisecond
+
+	replaced := 0
+	receipt, err := WaitEscalate(context.Background(), backend, []common.Hash{testHash}, opts,
+		func(ctx context.Context, hash common.Hash) (common.Hash, error) {
+			replaced++
+			assert.Equal(t, testHash, hash)
+			return replacement, nil
+		})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, replaced)
+	assert.Equal(t, replacement, receipt.TxHash)
+
+	// the stuck tx is never replaced without escalation
+	_, err = Wait(context.Background(), backend, testHash, testOpts)
+	assert.True(t, errs.IsTimeout(err))
+}
+
+// reorgBackend returns the receipts in order by every receipt query, and the last one is kept.
+// nil means the receipt can not be found.
+type reorgBackend struct {
+	fakeBackend
+	receipts []*types.Receipt
+}
+
+func (b *reorgBackend) TransactionReceipt(ctx context.Context, hash common.Hash) (*types.Receipt, error) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	receipt := b.receipts[0]
+	if len(b.receipts) > 1 {
+		b.receipts = b.receipts[1:]
+	}
+	if receipt == nil {
+		return nil, ethereum.NotFound
+	}
+	return receipt, nil
+}
+
+func minedReceipt(block uint64, blockHash string) *types.Receipt {
+	receipt := newReceipt(types.ReceiptStatusSuccessful, block)
+	receipt.BlockHash = common.HexToHash(blockHash)
+	return receipt
+}
+
+func TestWaitReorg(t *testing.T) {
+	opts := testOpts
+	opts.Confirmations = 3
+
+	// the tx is mined again in another block, and confirmations are counted on the new block
+	backend := &reorgBackend{
+		fakeBackend: fakeBackend{head: 10, grow: true, blocks: map[uint64]common.Hash{11: common.HexToHash("0x0b")}},
+		receipts:    []*types.Receipt{minedReceipt(10, "0x0a"), nil, minedReceipt(11, "0x0b")},
+	}
+	receipt, err := Wait(context.Background(), backend, testHash, opts)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(11), receipt.BlockNumber.Uint64())
+	assert.Equal(t, common.HexToHash("0x0b"), receipt.BlockHash)
+	assert.True(t, backend.head >= 13)
+
+	// the tx is reorged out and never mined again
+	backend = &reorgBackend{
+		fakeBackend: fakeBackend{head: 10},
+		receipts:    []*types.Receipt{minedReceipt(10, "0x0a"), nil},
+	}
+	_, err = Wait(context.Background(), backend, testHash, opts)
+	assert.True(t, errs.IsReorged(err))
+	assert.Contains(t, err.Error(), common.HexToHash("0x0a").Hex())
+}
+
+func TestWaitNotCanonical(t *testing.T) {
+	opts := testOpts
+	opts.Confirmations = 3
+
+	// the receipt is from a node on stale fork, and the block 10 in chain is another one
+	backend := &fakeBackend{
+		receipt: minedReceipt(10, "0x0a"),
+		head:    10,
+		grow:    true,
+		blocks:  map[uint64]common.Hash{10: common.HexToHash("0x0c")},
+	}
+	_, err := Wait(context.Background(), backend, testHash, opts)
+	assert.True(t, errs.IsTimeout(err))
+
+	backend.lock.Lock()
+	backend.blocks[10] = common.HexToHash("0x0a")
+	backend.lock.Unlock()
+	receipt, err := Wait(context.Background(), backend, testHash, opts)
+	assert.NoError(t, err)
+	assert.Equal(t, common.HexToHash("0x0a"), receipt.BlockHash)
+}